@@ -1,7 +1,9 @@
 package app
 
 import (
+	"context"
 	"log"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -11,11 +13,20 @@ import (
 	"github.com/smart-safety-hub/backend/internal/modules/aws"
 	"github.com/smart-safety-hub/backend/internal/modules/brand"
 	"github.com/smart-safety-hub/backend/internal/modules/categories"
+	"github.com/smart-safety-hub/backend/internal/modules/compliance"
+	"github.com/smart-safety-hub/backend/internal/modules/documents"
+	"github.com/smart-safety-hub/backend/internal/modules/pricing"
 	"github.com/smart-safety-hub/backend/internal/modules/products"
+	"github.com/smart-safety-hub/backend/internal/modules/reviews"
+	"github.com/smart-safety-hub/backend/internal/modules/tax"
 	"github.com/smart-safety-hub/backend/internal/modules/user"
+	catalogv1 "github.com/smart-safety-hub/backend/proto/catalog/v1"
 	"github.com/smart-safety-hub/backend/shared"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 )
 
 type Config struct {
@@ -33,6 +44,36 @@ type Container struct {
 	Logger     *zap.Logger
 }
 
+// grpcMethodScopes is the gRPC counterpart of the HasScope route wrappers.
+// Catalog reads are served to internal services, so they require catalog:view.
+var grpcMethodScopes = shared.MethodScopes{
+	// Brands
+	catalogv1.BrandService_GetBrand_FullMethodName:       "catalog:view",
+	catalogv1.BrandService_GetBrandBySlug_FullMethodName: "catalog:view",
+	catalogv1.BrandService_ListBrands_FullMethodName:     "catalog:view",
+
+	// Categories
+	catalogv1.CategoryService_GetCategory_FullMethodName:       "catalog:view",
+	catalogv1.CategoryService_GetCategoryBySlug_FullMethodName: "catalog:view",
+	catalogv1.CategoryService_ListCategories_FullMethodName:    "catalog:view",
+
+	// Products
+	catalogv1.ProductService_GetProduct_FullMethodName:           "catalog:view",
+	catalogv1.ProductService_GetProductBySlug_FullMethodName:     "catalog:view",
+	catalogv1.ProductService_ListProducts_FullMethodName:         "catalog:view",
+	catalogv1.ProductService_GetProductAttributes_FullMethodName: "catalog:view",
+	catalogv1.ProductService_GetProductVariants_FullMethodName:   "catalog:view",
+	catalogv1.ProductService_GetProductMedia_FullMethodName:      "catalog:view",
+	catalogv1.ProductService_GetProductSEO_FullMethodName:        "catalog:view",
+}
+
+// grpcPublicMethods are served without a token; any method missing from both
+// lists is denied.
+var grpcPublicMethods = shared.PublicMethods{
+	reflectionv1.ServerReflection_ServerReflectionInfo_FullMethodName,
+	reflectionv1alpha.ServerReflection_ServerReflectionInfo_FullMethodName,
+}
+
 func Bootstrap(cfg Config) (*Container, func()) {
 	l := shared.NewLogger()
 	sqlxDB := shared.Connect(cfg.DBURL, l)
@@ -44,6 +85,8 @@ func Bootstrap(cfg Config) (*Container, func()) {
 	// Create a shared JWT Manager
 	jwtManager, _ := shared.NewJWTManager(cfg.PrivateKey, cfg.PublicKey, l)
 	jwtMiddleware := shared.JWTMiddleware(jwtManager)
+	// Public routes that price for the signed-in buyer's company when a token is sent
+	optionalJWT := shared.OptionalJWTMiddleware(jwtManager)
 
 	// Initialize validator
 	v := validator.New(validator.WithRequiredStructEnabled())
@@ -62,19 +105,61 @@ func Bootstrap(cfg Config) (*Container, func()) {
 	brandRepo := brand.NewBrandRepo(sqlxDB)
 	brandService := brand.NewBrandService(l, brandRepo)
 	brandRestHandler := brand.NewRestHandler(brandService, v)
+	brandGrpcHandler := brand.NewGrpcHandler(brandService)
 
 	// Category
 	categoryRepo := categories.NewCategoryRepo(sqlxDB)
 	categoryService := categories.NewCategoryService(l, categoryRepo)
 	categoryRestHandler := categories.NewRestHandler(categoryService, v)
+	categoryGrpcHandler := categories.NewGrpcHandler(categoryService)
 
 	// Product
 	productRepo := products.NewProductRepo(sqlxDB)
 	productService := products.NewProductService(l, productRepo)
 	productRestHandler := products.NewRestHandler(productService, v)
+	productGrpcHandler := products.NewGrpcHandler(productService)
+
+	// Pricing
+	pricingRepo := pricing.NewPricingRepo(sqlxDB)
+	pricingService := pricing.NewPricingService(l, pricingRepo)
+	pricingRestHandler := pricing.NewRestHandler(pricingService, v)
+
+	// Tax
+	taxRepo := tax.NewTaxRepo(sqlxDB)
+	taxService := tax.NewTaxService(l, taxRepo)
+	taxRestHandler := tax.NewRestHandler(taxService, v)
+
+	// Compliance
+	complianceRepo := compliance.NewComplianceRepo(sqlxDB)
+	complianceService := compliance.NewComplianceService(l, complianceRepo, uploadService)
+	complianceRestHandler := compliance.NewRestHandler(complianceService, v)
+
+	// Documents
+	documentRepo := documents.NewDocumentRepo(sqlxDB)
+	documentService := documents.NewDocumentService(l, documentRepo, uploadService)
+	documentRestHandler := documents.NewRestHandler(documentService, v)
+
+	// Reviews
+	reviewRepo := reviews.NewReviewRepo(sqlxDB)
+	reviewService := reviews.NewReviewService(l, reviewRepo, uploadService)
+	reviewRestHandler := reviews.NewRestHandler(reviewService, v)
+
+	// Publishes and unpublishes scheduled products until shutdown
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	go productService.RunScheduler(schedulerCtx, time.Minute)
+	// Flags products with lapsed certificates once a day
+	go complianceService.RunExpiryJob(schedulerCtx, 24*time.Hour)
 
 	// GRPC
-	grpcSrv := grpc.NewServer()
+	grpcSrv := grpc.NewServer(
+		grpc.UnaryInterceptor(shared.UnaryAuthInterceptor(jwtManager, grpcMethodScopes, grpcPublicMethods)),
+		grpc.StreamInterceptor(shared.StreamAuthInterceptor(jwtManager, grpcMethodScopes, grpcPublicMethods)),
+	)
+	catalogv1.RegisterBrandServiceServer(grpcSrv, brandGrpcHandler)
+	catalogv1.RegisterCategoryServiceServer(grpcSrv, categoryGrpcHandler)
+	catalogv1.RegisterProductServiceServer(grpcSrv, productGrpcHandler)
+	// Lets grpcurl and similar tools discover the services
+	reflection.Register(grpcSrv)
 
 	// Http
 	router := chi.NewRouter()
@@ -109,28 +194,54 @@ func Bootstrap(cfg Config) (*Container, func()) {
 
 		// Brand
 		v1.Get("/get-brand/{id}", brandRestHandler.GetBrandByID)
+		v1.Get("/get-brand/slug/{slug}", brandRestHandler.GetBrandBySlug)
 		v1.Get("/get-all-brands", brandRestHandler.GetAllBrand)
 
 		// Category
 		v1.Get("/get-category/{id}", categoryRestHandler.GetCategoryByID)
+		v1.Get("/get-category/slug/{slug}", categoryRestHandler.GetCategoryBySlug)
 		v1.Get("/get-all-category", categoryRestHandler.GetAllCategory)
+		v1.Get("/categories/tree", categoryRestHandler.GetCategoryTree)
+		v1.Get("/categories/{id}/breadcrumb", categoryRestHandler.GetBreadcrumb)
+		v1.Get("/categories/slug/{slug}/breadcrumb", categoryRestHandler.GetBreadcrumb)
+		v1.Get("/categories/{id}/attribute-schema", categoryRestHandler.GetAttributeSchema)
 
 		// Product
-		v1.Get("/get-product/id/{id}", productRestHandler.GetProductByID)
-		v1.Get("/get-product/slug/{slug}", productRestHandler.GetProductBySlug)
-		v1.Get("/get-all-products", productRestHandler.GetAllProducts)
+		// Sellers and catalog reviewers also see unpublished products
+		v1.With(optionalJWT).Get("/get-product/id/{id}", productRestHandler.GetProductByID)
+		v1.With(optionalJWT).Get("/get-product/slug/{slug}", productRestHandler.GetProductBySlug)
+		v1.With(optionalJWT).Get("/get-all-products", productRestHandler.GetAllProducts)
+
+		// Product Detail (product with brand, breadcrumb, attributes, variants, media and SEO)
+		v1.With(optionalJWT).Get("/get-product-detail/id/{id}", productRestHandler.GetProductDetailByID)
+		v1.With(optionalJWT).Get("/get-product-detail/slug/{slug}", productRestHandler.GetProductDetailBySlug)
 
 		// Product Attribute
-		v1.Get("/get-product-attribute/{id}", productRestHandler.GetProductAttributeByID)
+		v1.With(optionalJWT).Get("/get-product-attribute/{id}", productRestHandler.GetProductAttributeByID)
 
 		// Product Media
-		v1.Get("/get-product-media/{id}", productRestHandler.GetProductMedia)
+		v1.With(optionalJWT).Get("/get-product-media/{id}", productRestHandler.GetProductMedia)
 
 		// Product Variant
-		v1.Get("/get-product-variants/{id}", productRestHandler.GetProductVariants)
+		v1.With(optionalJWT).Get("/get-product-variants/{id}", productRestHandler.GetProductVariants)
 
 		// Product SEO
-		v1.Get("/get-product-seo/{id}", productRestHandler.GetProductSEO)
+		v1.With(optionalJWT).Get("/get-product-seo/{id}", productRestHandler.GetProductSEO)
+
+		// Product Bundles
+		v1.With(optionalJWT).Post("/products/{id}/bundle/configure", productRestHandler.ConfigureBundle)
+
+		// Pricing
+		v1.Get("/variants/{id}/price-tiers", pricingRestHandler.GetPriceTiers)
+		v1.With(optionalJWT).Get("/price-quote", pricingRestHandler.Quote)
+
+		// Technical documents of published products
+		v1.Get("/products/{id}/documents", documentRestHandler.GetProductDocuments)
+		v1.Get("/documents/{id}/download", documentRestHandler.DownloadDocument)
+
+		// Approved reviews of published products
+		v1.Get("/products/{id}/reviews", reviewRestHandler.GetProductReviews)
+		v1.Get("/exchange-rates", pricingRestHandler.GetExchangeRates)
 		v1.Group(func(r chi.Router) {
 			r.Use(jwtMiddleware)
 			// Protected Routes
@@ -144,23 +255,93 @@ func Bootstrap(cfg Config) (*Container, func()) {
 			r.With(shared.HasScope("catalog:create")).Post("/create-category", categoryRestHandler.CreateCategory)
 			r.With(shared.HasScope("catalog:update")).Patch("/update-category/{id}", categoryRestHandler.UpdateCategory)
 			r.With(shared.HasScope("catalog:delete")).Delete("/delete-category/{id}", categoryRestHandler.DeleteCategory)
+			r.With(shared.HasScope("catalog:update")).Post("/move-category/{id}", categoryRestHandler.MoveCategory)
+			r.With(shared.HasScope("catalog:delete")).Post("/merge-category/{id}", categoryRestHandler.MergeCategory)
+			r.With(shared.HasScope("catalog:update")).Put("/categories/{id}/attributes", categoryRestHandler.SaveCategoryAttribute)
+			r.With(shared.HasScope("catalog:update")).Delete("/categories/{id}/attributes/{key}", categoryRestHandler.DeleteCategoryAttribute)
 
 			// Products
 			r.With(shared.HasScope("catalog:create")).Post("/create-product", productRestHandler.CreateProduct)
 			r.With(shared.HasScope("catalog:update")).Patch("/update-product/{id}", productRestHandler.UpdateProduct)
 			r.With(shared.HasScope("catalog:delete")).Delete("/delete-product/{id}", productRestHandler.DeleteProduct)
 
+			// Product Workflow (each action checks its own scope, see products.Transitions)
+			r.Post("/products/{id}/workflow/{action}", productRestHandler.TransitionProduct)
+
+			// Product Import
+			r.With(shared.HasScope("catalog:create")).Post("/import-products", productRestHandler.ImportProducts)
+			r.With(shared.HasScope("catalog:create")).Get("/import-products/{id}", productRestHandler.GetImportJob)
+
+			// Product History
+			r.With(shared.HasScope("catalog:update")).Get("/products/{id}/versions", productRestHandler.GetProductVersions)
+			r.With(shared.HasScope("catalog:update")).Get("/products/{id}/versions/diff", productRestHandler.DiffProductVersions)
+			r.With(shared.HasScope("catalog:update")).Get("/products/{id}/versions/{version}", productRestHandler.GetProductVersion)
+			r.With(shared.HasScope("catalog:update")).Post("/products/{id}/versions/{version}/restore", productRestHandler.RestoreProductVersion)
+
+			// Product Export
+			r.With(shared.HasScope("catalog:update")).Get("/export-products", productRestHandler.ExportProducts)
+
 			// Product Attributes
 			r.With(shared.HasScope("catalog:update")).Post("/add-product-attribute", productRestHandler.AddProductAttribute)
 
 			// Product Variants
 			r.With(shared.HasScope("catalog:update")).Post("/add-product-variants/{id}", productRestHandler.SyncProductVariants)
+			r.With(shared.HasScope("catalog:update")).Post("/products/{id}/variants/generate", productRestHandler.GenerateVariantMatrix)
+
+			// Product Bundles
+			r.With(shared.HasScope("catalog:update")).Put("/products/{id}/bundle", productRestHandler.SaveBundle)
 
 			// Products Media
 			r.With(shared.HasScope("catalog:update")).Post("/add-product-media/{id}", productRestHandler.AddProductMedia)
 
 			// Product SEO
 			r.With(shared.HasScope("catalog:update")).Post("/add-product-seo/{id}", productRestHandler.SaveProductSEO)
+
+			// Pricing
+			r.With(shared.HasScope("pricing:update")).Put("/variants/{id}/price-tiers", pricingRestHandler.SavePriceTiers)
+
+			// Price Lists
+			r.With(shared.HasScope("pricing:lists")).Get("/price-lists", pricingRestHandler.GetPriceLists)
+			r.With(shared.HasScope("pricing:lists")).Get("/price-lists/{id}", pricingRestHandler.GetPriceList)
+			r.With(shared.HasScope("pricing:lists")).Post("/price-lists", pricingRestHandler.CreatePriceList)
+			r.With(shared.HasScope("pricing:lists")).Put("/price-lists/{id}", pricingRestHandler.UpdatePriceList)
+			r.With(shared.HasScope("pricing:lists")).Delete("/price-lists/{id}", pricingRestHandler.DeletePriceList)
+
+			// Exchange Rates
+			r.With(shared.HasScope("pricing:rates")).Put("/exchange-rates", pricingRestHandler.SaveExchangeRates)
+			r.With(shared.HasScope("pricing:rates")).Post("/exchange-rates/import", pricingRestHandler.ImportExchangeRates)
+
+			// Tax Classes
+			r.With(shared.HasScope("tax:view")).Get("/tax-classes", taxRestHandler.GetTaxClasses)
+			r.With(shared.HasScope("tax:view")).Get("/tax-classes/{id}", taxRestHandler.GetTaxClass)
+			r.With(shared.HasScope("tax:update")).Post("/tax-classes", taxRestHandler.CreateTaxClass)
+			r.With(shared.HasScope("tax:update")).Put("/tax-classes/{id}", taxRestHandler.UpdateTaxClass)
+			r.With(shared.HasScope("tax:update")).Delete("/tax-classes/{id}", taxRestHandler.DeleteTaxClass)
+			r.With(shared.HasScope("tax:update")).Put("/products/{id}/tax-class", taxRestHandler.AssignProductTaxClass)
+			r.With(shared.HasScope("tax:update")).Put("/categories/{id}/tax-class", taxRestHandler.AssignCategoryTaxClass)
+
+			// Compliance Certificates
+			r.With(shared.HasScope("compliance:view")).Get("/certificates", complianceRestHandler.GetCertificates)
+			r.With(shared.HasScope("compliance:view")).Get("/certificates/{id}", complianceRestHandler.GetCertificate)
+			r.With(shared.HasScope("compliance:view")).Get("/products/{id}/certificates", complianceRestHandler.GetProductCertificates)
+			r.With(shared.HasScope("compliance:upload")).Post("/products/{id}/certificates", complianceRestHandler.UploadCertificate)
+			r.With(shared.HasScope("compliance:upload")).Put("/certificates/{id}", complianceRestHandler.UpdateCertificate)
+			r.With(shared.HasScope("compliance:upload")).Delete("/certificates/{id}", complianceRestHandler.DeleteCertificate)
+
+			// Documents
+			r.With(shared.HasScope("document:view")).Get("/documents", documentRestHandler.GetDocuments)
+			r.With(shared.HasScope("document:view")).Get("/documents/{id}", documentRestHandler.GetDocument)
+			r.With(shared.HasScope("document:upload")).Post("/products/{id}/documents", documentRestHandler.CreateDocument)
+			r.With(shared.HasScope("document:upload")).Post("/documents/{id}/versions", documentRestHandler.AddDocumentVersion)
+			r.With(shared.HasScope("document:upload")).Put("/documents/{id}", documentRestHandler.UpdateDocument)
+			r.With(shared.HasScope("document:upload")).Delete("/documents/{id}", documentRestHandler.DeleteDocument)
+
+			// Reviews
+			r.With(shared.HasScope("review:create")).Post("/upload-review-photo", reviewRestHandler.UploadReviewPhotos)
+			r.With(shared.HasScope("review:create")).Post("/products/{id}/reviews", reviewRestHandler.CreateReview)
+			r.With(shared.HasScope("review:moderate")).Get("/reviews", reviewRestHandler.GetReviews)
+			r.With(shared.HasScope("review:moderate")).Post("/reviews/{id}/moderate", reviewRestHandler.ModerateReview)
+			r.With(shared.HasScope("review:reply")).Put("/reviews/{id}/reply", reviewRestHandler.ReplyToReview)
 		})
 	})
 
@@ -172,6 +353,7 @@ func Bootstrap(cfg Config) (*Container, func()) {
 	}
 
 	cleanup := func() {
+		stopScheduler()
 		l.Sync()
 		sqlxDB.Close()
 	}
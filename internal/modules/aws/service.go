@@ -70,6 +70,12 @@ func (u *UploadService) UploadImage(ctx context.Context, file multipart.File, he
 	return response, nil
 }
 
+// URLPrefix is how the URLs UploadImage returns for bucketName start, so
+// callers can check a URL was uploaded there.
+func (u *UploadService) URLPrefix(bucketName string) string {
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s/", u.bucket, u.region, bucketName)
+}
+
 func isValidFileType(file multipart.File, header *multipart.FileHeader) bool {
 	clientType := header.Header.Get("Content-Type")
 
@@ -1,6 +1,14 @@
 package brand
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrBrandNotFound = errors.New("Brand not found")
+	ErrBrandInUse    = errors.New("Brand is still in use")
+)
 
 type Brand struct {
 	ID          string    `db:"id"`
@@ -12,10 +20,13 @@ type Brand struct {
 	IsActive    bool      `db:"is_active"`
 	CreatedAt   time.Time `db:"created_at"`
 	UpdatedAt   time.Time `db:"updated_at"`
-	TotalCount  int       `db:"total_count"`
+	CursorKey   []byte    `db:"cursor_key"`
 }
 
 type BrandList struct {
-	Brands []Brand `db:"brands"`
-	Total  int     `db:"total"`
+	Brands     []Brand
+	Total      *int
+	Estimated  bool
+	NextCursor *string
+	PrevCursor *string
 }
@@ -4,7 +4,7 @@ import "time"
 
 type BrandsRequestDTO struct {
 	Name        string  `json:"name" validate:"required"`
-	Slug        string  `json:"slug" validate:"required"`
+	Slug        string  `json:"slug"`
 	LogoUrl     *string `json:"logo_url"`
 	WebsiteUrl  *string `json:"website_url"`
 	Description *string `json:"description"`
@@ -23,8 +23,36 @@ type BrandResponse struct {
 }
 
 type BrandListResponse struct {
-	Brands []BrandResponse `json:"brands"`
-	Total  int             `json:"total"`
+	Brands         []BrandResponse `json:"brands"`
+	Total          *int            `json:"total"`
+	TotalEstimated bool            `json:"total_estimated,omitempty"`
+	NextCursor     *string         `json:"next_cursor"`
+	PrevCursor     *string         `json:"prev_cursor"`
+}
+
+// Sort orders accepted by GetAllBrand.
+const (
+	SortNewest = "newest"
+	SortName   = "name"
+)
+
+var BrandSortOrders = []string{SortNewest, SortName}
+
+type BrandFilters struct {
+	Sort string `query:"sort"`
+	// Page > 0 selects LIMIT/OFFSET paging, otherwise the page starts at Cursor.
+	Page   int    `query:"page"`
+	Limit  int    `query:"limit"`
+	Cursor string `query:"cursor"`
+	// Count is one of shared.CountModes, exact by default.
+	Count string `query:"count"`
+}
+
+func (f BrandFilters) EffectiveSort() string {
+	if f.Sort == "" {
+		return SortNewest
+	}
+	return f.Sort
 }
 
 type GetBrandByID struct {
@@ -32,6 +60,7 @@ type GetBrandByID struct {
 }
 
 type GenericResponseDTO struct {
+	Slug    string `json:"slug,omitempty"`
 	Status  string `json:"success"`
 	Message string `json:"message"`
 }
@@ -0,0 +1,141 @@
+package brand
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	catalogv1 "github.com/smart-safety-hub/backend/proto/catalog/v1"
+	"github.com/smart-safety-hub/backend/shared"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type GrpcHandler struct {
+	catalogv1.UnimplementedBrandServiceServer
+	service *BrandService
+}
+
+func NewGrpcHandler(service *BrandService) *GrpcHandler {
+	return &GrpcHandler{
+		service: service,
+	}
+}
+
+func (h *GrpcHandler) GetBrand(ctx context.Context, request *catalogv1.GetBrandRequest) (*catalogv1.Brand, error) {
+	if request.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "ID is required")
+	}
+
+	response, err := h.service.GetBrandByID(ctx, request.GetId())
+	if err != nil {
+		return nil, status.Error(brandErrorCode(err), err.Error())
+	}
+
+	return toProtoBrand(*response), nil
+}
+
+func (h *GrpcHandler) GetBrandBySlug(ctx context.Context, request *catalogv1.GetBrandBySlugRequest) (*catalogv1.Brand, error) {
+	if request.GetSlug() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Slug is required")
+	}
+
+	response, err := h.service.GetBrandBySlug(ctx, request.GetSlug())
+
+	// gRPC has no redirects: an old slug resolves to the brand under its
+	// current slug, which the caller can see in the response
+	var moved *shared.SlugMovedError
+	if errors.As(err, &moved) {
+		response, err = h.service.GetBrandBySlug(ctx, moved.Slug)
+	}
+	if err != nil {
+		return nil, status.Error(brandErrorCode(err), err.Error())
+	}
+
+	return toProtoBrand(*response), nil
+}
+
+func (h *GrpcHandler) ListBrands(ctx context.Context, request *catalogv1.ListBrandsRequest) (*catalogv1.ListBrandsResponse, error) {
+	if request.GetLimit() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Limit is missing in params")
+	}
+
+	if request.GetPage() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "Page cannot be negative")
+	}
+
+	filters := BrandFilters{
+		Sort:   request.GetSort(),
+		Page:   int(request.GetPage()),
+		Limit:  int(request.GetLimit()),
+		Cursor: request.GetCursor(),
+		Count:  request.GetCount(),
+	}
+
+	if filters.Sort != "" && !slices.Contains(BrandSortOrders, filters.Sort) {
+		return nil, status.Error(codes.InvalidArgument, "Invalid sort, allowed: "+strings.Join(BrandSortOrders, ","))
+	}
+
+	if filters.Count != "" && !slices.Contains(shared.CountModes, filters.Count) {
+		return nil, status.Error(codes.InvalidArgument, "Invalid count, allowed: "+strings.Join(shared.CountModes, ","))
+	}
+
+	if filters.Cursor != "" && filters.Page == 0 {
+		cursor, err := shared.DecodeCursor(filters.Cursor)
+		if err != nil || cursor.Sort != filters.EffectiveSort() {
+			return nil, status.Error(codes.InvalidArgument, "Invalid cursor")
+		}
+	}
+
+	response, err := h.service.GetAllBrand(ctx, filters)
+	if err != nil {
+		return nil, status.Error(brandErrorCode(err), err.Error())
+	}
+
+	brands := make([]*catalogv1.Brand, 0, len(response.Brands))
+	for _, data := range response.Brands {
+		brands = append(brands, toProtoBrand(data))
+	}
+
+	listResponse := &catalogv1.ListBrandsResponse{
+		Brands:         brands,
+		NextCursor:     response.NextCursor,
+		PrevCursor:     response.PrevCursor,
+		TotalEstimated: response.TotalEstimated,
+	}
+
+	if response.Total != nil {
+		total := int32(*response.Total)
+		listResponse.Total = &total
+	}
+
+	return listResponse, nil
+}
+
+// brandErrorCode maps service errors to gRPC codes; unknown errors are
+// Internal.
+func brandErrorCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, ErrBrandNotFound):
+		return codes.NotFound
+	case errors.Is(err, shared.ErrInvalidCursor), errors.Is(err, shared.ErrInvalidSlug):
+		return codes.InvalidArgument
+	}
+	return codes.Internal
+}
+
+func toProtoBrand(data BrandResponse) *catalogv1.Brand {
+	return &catalogv1.Brand{
+		Id:          data.ID,
+		Name:        data.Name,
+		Slug:        data.Slug,
+		LogoUrl:     data.LogoUrl,
+		WebsiteUrl:  data.WebsiteUrl,
+		Description: data.Description,
+		IsActive:    data.IsActive,
+		CreatedAt:   timestamppb.New(data.CreatedAt),
+		UpdatedAt:   timestamppb.New(data.UpdatedAt),
+	}
+}
@@ -2,11 +2,15 @@ package brand
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
+	"github.com/smart-safety-hub/backend/shared"
 )
 
 type RestHandler struct {
@@ -36,7 +40,7 @@ func (h *RestHandler) CreateBrand(w http.ResponseWriter, r *http.Request) {
 
 	response, err := h.service.CreateBrand(r.Context(), request)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), shared.SlugErrorStatus(err, http.StatusInternalServerError))
 		return
 	}
 
@@ -67,7 +71,7 @@ func (h *RestHandler) UpdateBrand(w http.ResponseWriter, r *http.Request) {
 
 	response, err := h.service.UpdateBrand(r.Context(), brandID, request)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), shared.SlugErrorStatus(err, http.StatusInternalServerError))
 		return
 	}
 
@@ -86,7 +90,7 @@ func (h *RestHandler) DeleteBrand(w http.ResponseWriter, r *http.Request) {
 
 	response, err := h.service.DeleteBrand(r.Context(), brandID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), brandErrorStatus(err))
 		return
 	}
 
@@ -105,7 +109,7 @@ func (h *RestHandler) GetBrandByID(w http.ResponseWriter, r *http.Request) {
 
 	response, err := h.service.GetBrandByID(r.Context(), brandId)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), brandErrorStatus(err))
 		return
 	}
 
@@ -115,32 +119,88 @@ func (h *RestHandler) GetBrandByID(w http.ResponseWriter, r *http.Request) {
 
 }
 
+// GetBrandBySlug answers an old slug with 301 and the Location of the
+// current one.
+func (h *RestHandler) GetBrandBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+
+	if slug == "" {
+		http.Error(w, "Slug is required", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.GetBrandBySlug(r.Context(), slug)
+	if err != nil {
+		var moved *shared.SlugMovedError
+		if errors.As(err, &moved) {
+			shared.WriteMoved(w, r, moved)
+			return
+		}
+		http.Error(w, err.Error(), brandErrorStatus(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusBadRequest)
+	}
+}
+
 func (h *RestHandler) GetAllBrand(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query()
 	page := query.Get("page")
 	limit := query.Get("limit")
 
-	if page == "" || limit == "" {
-		http.Error(w, "Page and Limit is missing in params", http.StatusBadRequest)
+	if limit == "" {
+		http.Error(w, "Limit is missing in params", http.StatusBadRequest)
 		return
 	}
 
-	pageInt, err := strconv.Atoi(page)
-	if err != nil {
-		http.Error(w, "Bad Request", http.StatusBadRequest)
-		return
+	request := BrandFilters{
+		Sort:   query.Get("sort"),
+		Cursor: query.Get("cursor"),
+		Count:  query.Get("count"),
+	}
 
+	// page keeps the LIMIT/OFFSET behaviour for existing clients; without it
+	// the list is paged with next_cursor/prev_cursor.
+	if page != "" {
+		pageInt, err := strconv.Atoi(page)
+		if err != nil || pageInt <= 0 {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		request.Page = pageInt
 	}
 
 	limitInt, err := strconv.Atoi(limit)
-	if err != nil {
+	if err != nil || limitInt <= 0 {
 		http.Error(w, "Bad Request", http.StatusBadRequest)
 		return
 	}
+	request.Limit = limitInt
 
-	response, err := h.service.GetAllBrand(r.Context(), pageInt, limitInt)
+	if request.Sort != "" && !slices.Contains(BrandSortOrders, request.Sort) {
+		http.Error(w, "Invalid sort, allowed: "+strings.Join(BrandSortOrders, ","), http.StatusBadRequest)
+		return
+	}
+
+	if request.Count != "" && !slices.Contains(shared.CountModes, request.Count) {
+		http.Error(w, "Invalid count, allowed: "+strings.Join(shared.CountModes, ","), http.StatusBadRequest)
+		return
+	}
+
+	if request.Cursor != "" && request.Page == 0 {
+		cursor, err := shared.DecodeCursor(request.Cursor)
+		if err != nil || cursor.Sort != request.EffectiveSort() {
+			http.Error(w, "Invalid cursor", http.StatusBadRequest)
+			return
+		}
+	}
+
+	response, err := h.service.GetAllBrand(r.Context(), request)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
@@ -148,3 +208,13 @@ func (h *RestHandler) GetAllBrand(w http.ResponseWriter, r *http.Request) {
 	}
 
 }
+
+func brandErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrBrandNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrBrandInUse):
+		return http.StatusConflict
+	}
+	return shared.SlugErrorStatus(err, http.StatusInternalServerError)
+}
@@ -3,8 +3,10 @@ package brand
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/smart-safety-hub/backend/shared"
@@ -20,16 +22,31 @@ func NewBrandRepo(db *sqlx.DB) *BrandRepo {
 	}
 }
 
-func (r *BrandRepo) SaveBrand(ctx context.Context, request BrandsRequestDTO) error {
+// SaveBrand inserts a brand and returns its slug, generated from the name
+// when the request has none.
+func (r *BrandRepo) SaveBrand(ctx context.Context, request BrandsRequestDTO) (string, error) {
+	slug, err := shared.UniqueSlug(ctx, r.db, "brands", request.Name, request.Slug, "")
+	if err != nil {
+		return "", err
+	}
+
 	query := "INSERT INTO brands(name, slug, logo_url, website_url, description) VALUES ($1,$2,$3,$4,$5)"
-	if _, err := r.db.ExecContext(ctx, query, request.Name, request.Slug, request.LogoUrl, request.WebsiteUrl, request.Description); err != nil {
-		return shared.PostgresError(err)
+	if _, err := r.db.ExecContext(ctx, query, request.Name, slug, request.LogoUrl, request.WebsiteUrl, request.Description); err != nil {
+		return "", shared.PostgresError(err)
 	}
 
-	return nil
+	return slug, nil
 }
 
 func (r *BrandRepo) UpdateBrand(ctx context.Context, brandID string, request BrandsRequestDTO) error {
+	if request.Slug != "" {
+		slug, err := shared.UniqueSlug(ctx, r.db, "brands", "", request.Slug, brandID)
+		if err != nil {
+			return err
+		}
+		request.Slug = slug
+	}
+
 	query := "UPDATE brands SET name=COALESCE(NULLIF($1, ''), name), slug=COALESCE(NULLIF($2, ''), slug), logo_url=COALESCE($3, logo_url), website_url=COALESCE($4, website_url), description=COALESCE($5, description) WHERE id=$6"
 	if _, err := r.db.ExecContext(ctx, query, request.Name, request.Slug, request.LogoUrl, request.WebsiteUrl, request.Description, brandID); err != nil {
 		return shared.PostgresError(err)
@@ -41,7 +58,12 @@ func (r *BrandRepo) UpdateBrand(ctx context.Context, brandID string, request Bra
 func (r *BrandRepo) DeleteBrand(ctx context.Context, brandID string) error {
 	query := "DELETE FROM brands WHERE id=$1"
 	if _, err := r.db.ExecContext(ctx, query, brandID); err != nil {
-		return shared.PostgresError(err)
+		// Price list discounts restrict the delete rather than vanishing with it
+		err = shared.PostgresError(err)
+		if errors.Is(err, shared.ErrForeignKeyViolation) {
+			return fmt.Errorf("%w: remove the price list discounts on it first", ErrBrandInUse)
+		}
+		return err
 	}
 
 	return nil
@@ -51,29 +73,143 @@ func (r *BrandRepo) GetBrandByID(ctx context.Context, brandId string) (*Brand, e
 	var brand Brand
 	query := "SELECT * FROM brands WHERE id=$1"
 	if err := r.db.GetContext(ctx, &brand, query, brandId); err != nil {
-		fmt.Println("err", err)
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("Brand not found")
+			return nil, ErrBrandNotFound
+		}
+		return nil, shared.PostgresError(err)
+	}
+	return &brand, nil
+}
+
+// GetBrandBySlug returns a *shared.SlugMovedError when slug is an old slug of
+// a brand.
+func (r *BrandRepo) GetBrandBySlug(ctx context.Context, slug string) (*Brand, error) {
+	var brand Brand
+	query := "SELECT * FROM brands WHERE slug=$1"
+	if err := r.db.GetContext(ctx, &brand, query, slug); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			if err := shared.MovedSlug(ctx, r.db, "brands", slug); !errors.Is(err, sql.ErrNoRows) {
+				return nil, err
+			}
+			return nil, ErrBrandNotFound
 		}
 		return nil, shared.PostgresError(err)
 	}
 	return &brand, nil
 }
 
-func (r *BrandRepo) GetAllBrand(limit, offset int) (*BrandList, error) {
+func (r *BrandRepo) GetAllBrand(ctx context.Context, request BrandFilters) (*BrandList, error) {
+	keys := brandSortKeys(request.EffectiveSort())
+	cursorKey, args := shared.KeysetValues(keys)
+
+	query := "SELECT id, name, slug, logo_url, is_active, website_url, created_at, " + cursorKey + " AS cursor_key FROM brands WHERE 1=1"
+
+	// Page > 0 keeps the LIMIT/OFFSET behaviour, otherwise the page starts at
+	// the cursor (or at the beginning when there is none).
+	var cursor *shared.Cursor
+	if request.Page <= 0 && request.Cursor != "" {
+		var err error
+		cursor, err = shared.DecodeCursor(request.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if cursor.Sort != request.EffectiveSort() {
+			return nil, shared.ErrInvalidCursor
+		}
+
+		condition, conditionArgs, err := shared.KeysetCondition(keys, cursor.Values, cursor.Backward)
+		if err != nil {
+			return nil, err
+		}
+		query += condition
+		args = append(args, conditionArgs...)
+	}
+	backward := cursor != nil && cursor.Backward
+
+	order, orderArgs := shared.KeysetOrder(keys, backward)
+	query += order
+	args = append(args, orderArgs...)
+
+	if request.Page > 0 {
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, request.Limit, (request.Page-1)*request.Limit)
+	} else {
+		// One extra row tells whether there is another page in this direction
+		query += " LIMIT ?"
+		args = append(args, request.Limit+1)
+	}
+
 	var brands []Brand
-	query := "SELECT id, name, slug, logo_url, is_active, website_url, created_at, COUNT(*) OVER() as total_count FROM brands ORDER BY id DESC LIMIT $1 OFFSET $2"
-	if err := r.db.Select(&brands, query, limit, offset); err != nil {
+	if err := r.db.SelectContext(ctx, &brands, r.db.Rebind(query), args...); err != nil {
 		return nil, shared.PostgresError(err)
 	}
 
-	total := 0
-	if len(brands) > 0 {
-		total = brands[0].TotalCount
+	list := &BrandList{}
+	if request.Page <= 0 {
+		hasMore := len(brands) > request.Limit
+		if hasMore {
+			brands = brands[:request.Limit]
+		}
+		if backward {
+			slices.Reverse(brands)
+		}
+
+		if len(brands) > 0 {
+			var err error
+			sort := request.EffectiveSort()
+			first, last := brands[0], brands[len(brands)-1]
+			if (!backward && hasMore) || backward {
+				if list.NextCursor, err = brandCursor(sort, last, false); err != nil {
+					return nil, err
+				}
+			}
+			if (backward && hasMore) || (!backward && cursor != nil) {
+				if list.PrevCursor, err = brandCursor(sort, first, true); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	list.Brands = brands
+
+	countQuery := "SELECT COUNT(*) FROM brands"
+	switch request.Count {
+	case shared.CountNone:
+	case shared.CountEstimated:
+		total, err := shared.EstimateCount(ctx, r.db, countQuery)
+		if err != nil {
+			return nil, err
+		}
+		list.Total = &total
+		list.Estimated = true
+	default:
+		var total int
+		if err := r.db.GetContext(ctx, &total, countQuery); err != nil {
+			return nil, shared.PostgresError(err)
+		}
+		list.Total = &total
+	}
+
+	return list, nil
+}
+
+// brandSortKeys returns the keyset ordering for the requested sort, ending
+// with id so pages are stable when the sort key ties.
+func brandSortKeys(sort string) []shared.SortKey {
+	switch sort {
+	case SortName:
+		return []shared.SortKey{{Expr: "name", Type: "text"}, {Expr: "id", Type: "uuid"}}
+	default:
+		return []shared.SortKey{{Expr: "created_at", Type: "timestamp", Desc: true}, {Expr: "id", Type: "uuid", Desc: true}}
+	}
+}
+
+func brandCursor(sort string, row Brand, backward bool) (*string, error) {
+	var values []*string
+	if err := json.Unmarshal(row.CursorKey, &values); err != nil {
+		return nil, err
 	}
 
-	return &BrandList{
-		Brands: brands,
-		Total:  total,
-	}, nil
+	cursor := shared.EncodeCursor(shared.Cursor{Sort: sort, Backward: backward, Values: values})
+	return &cursor, nil
 }
@@ -20,11 +20,13 @@ func NewBrandService(logger *zap.Logger, repo *BrandRepo) *BrandService {
 }
 
 func (b *BrandService) CreateBrand(ctx context.Context, request BrandsRequestDTO) (*GenericResponseDTO, error) {
-	if err := b.repo.SaveBrand(ctx, request); err != nil {
-		return nil, fmt.Errorf("Error came while saving it to DB: %v", err)
+	slug, err := b.repo.SaveBrand(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
 	}
 
 	return &GenericResponseDTO{
+		Slug:    slug,
 		Status:  "success",
 		Message: "Brand Created Successfully",
 	}, nil
@@ -32,7 +34,7 @@ func (b *BrandService) CreateBrand(ctx context.Context, request BrandsRequestDTO
 
 func (b *BrandService) UpdateBrand(ctx context.Context, brandId string, request BrandsRequestDTO) (*GenericResponseDTO, error) {
 	if err := b.repo.UpdateBrand(ctx, brandId, request); err != nil {
-		return nil, fmt.Errorf("Error came while saving it to DB: %v", err)
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
 	}
 
 	return &GenericResponseDTO{
@@ -43,7 +45,7 @@ func (b *BrandService) UpdateBrand(ctx context.Context, brandId string, request
 
 func (b *BrandService) DeleteBrand(ctx context.Context, brandID string) (*GenericResponseDTO, error) {
 	if err := b.repo.DeleteBrand(ctx, brandID); err != nil {
-		return nil, fmt.Errorf("Error came while saving it to DB: %v", err)
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
 	}
 
 	return &GenericResponseDTO{
@@ -55,30 +57,27 @@ func (b *BrandService) DeleteBrand(ctx context.Context, brandID string) (*Generi
 func (b *BrandService) GetBrandByID(ctx context.Context, brandId string) (*BrandResponse, error) {
 	resp, err := b.repo.GetBrandByID(ctx, brandId)
 	if err != nil {
-		return nil, fmt.Errorf("Error came while getting data from DB: %v", err)
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
 	}
 
-	response := &BrandResponse{
-		ID:          resp.ID,
-		Name:        resp.Name,
-		Slug:        resp.Slug,
-		LogoUrl:     resp.LogoUrl,
-		WebsiteUrl:  resp.WebsiteUrl,
-		Description: resp.Description,
-		IsActive:    resp.IsActive,
-		CreatedAt:   resp.CreatedAt,
+	return toBrandResponse(resp), nil
+}
+
+func (b *BrandService) GetBrandBySlug(ctx context.Context, slug string) (*BrandResponse, error) {
+	resp, err := b.repo.GetBrandBySlug(ctx, slug)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
 	}
 
-	return response, nil
+	return toBrandResponse(resp), nil
 }
 
-func (b *BrandService) GetAllBrand(ctx context.Context, page, limit int) (*BrandListResponse, error) {
-	offset := (page - 1) * limit
+func (b *BrandService) GetAllBrand(ctx context.Context, request BrandFilters) (*BrandListResponse, error) {
 	var brandResponse []BrandResponse
 
-	response, err := b.repo.GetAllBrand(limit, offset)
+	response, err := b.repo.GetAllBrand(ctx, request)
 	if err != nil {
-		return nil, fmt.Errorf("Error came while getting data from DB: %v", err)
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
 	}
 
 	for _, data := range response.Brands {
@@ -97,7 +96,23 @@ func (b *BrandService) GetAllBrand(ctx context.Context, page, limit int) (*Brand
 	}
 
 	return &BrandListResponse{
-		Brands: brandResponse,
-		Total:  response.Total,
+		Brands:         brandResponse,
+		Total:          response.Total,
+		TotalEstimated: response.Estimated,
+		NextCursor:     response.NextCursor,
+		PrevCursor:     response.PrevCursor,
 	}, nil
 }
+
+func toBrandResponse(resp *Brand) *BrandResponse {
+	return &BrandResponse{
+		ID:          resp.ID,
+		Name:        resp.Name,
+		Slug:        resp.Slug,
+		LogoUrl:     resp.LogoUrl,
+		WebsiteUrl:  resp.WebsiteUrl,
+		Description: resp.Description,
+		IsActive:    resp.IsActive,
+		CreatedAt:   resp.CreatedAt,
+	}
+}
@@ -0,0 +1,42 @@
+package categories
+
+import "fmt"
+
+// checkDeletion decides whether a category can be deleted with strategy, one
+// of DeleteStrategies, given what still references it, and returns
+// ErrCategoryInUse when the strategy cannot deal with it. isRoot tells whether
+// the category has no parent. subtreeDiscounts counts the price list discounts
+// on the category and its descendants; only DeleteCascadeArchive, which
+// deletes the whole subtree, looks at it.
+func checkDeletion(strategy string, isRoot bool, usage CategoryUsage, subtreeDiscounts int) error {
+	switch strategy {
+	case DeleteReparent:
+		if usage.Discounts > 0 {
+			return discountsInUse(usage.Discounts)
+		}
+		if usage.Products > 0 && isRoot {
+			return fmt.Errorf("%w: root category has %d products, merge it into another category instead", ErrCategoryInUse, usage.Products)
+		}
+
+	case DeleteCascadeArchive:
+		if subtreeDiscounts > 0 {
+			return discountsInUse(subtreeDiscounts)
+		}
+
+	default:
+		if usage.Discounts > 0 {
+			return discountsInUse(usage.Discounts)
+		}
+		if usage.Children > 0 || usage.Products > 0 {
+			return fmt.Errorf("%w: it has %d subcategories and %d products", ErrCategoryInUse, usage.Children, usage.Products)
+		}
+	}
+	return nil
+}
+
+// discountsInUse refuses to take away a category that price list discounts
+// apply to. Moving a discount to another category would extend it to that
+// category's own products, so the admin has to move or remove it.
+func discountsInUse(discounts int) error {
+	return fmt.Errorf("%w: %d price list discounts apply to it, remove them first", ErrCategoryInUse, discounts)
+}
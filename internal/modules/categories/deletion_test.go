@@ -0,0 +1,45 @@
+package categories
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckDeletion(t *testing.T) {
+	tests := []struct {
+		name             string
+		strategy         string
+		isRoot           bool
+		usage            CategoryUsage
+		subtreeDiscounts int
+		err              error
+	}{
+		{name: "reject unused", strategy: DeleteReject},
+		{name: "reject with subcategories", strategy: DeleteReject, usage: CategoryUsage{Children: 2}, err: ErrCategoryInUse},
+		{name: "reject with products", strategy: DeleteReject, usage: CategoryUsage{Products: 5}, err: ErrCategoryInUse},
+		{name: "reject with discounts", strategy: DeleteReject, usage: CategoryUsage{Discounts: 1}, err: ErrCategoryInUse},
+		{name: "reparent with subcategories and products", strategy: DeleteReparent, usage: CategoryUsage{Children: 2, Products: 5}},
+		{name: "reparent with discounts", strategy: DeleteReparent, usage: CategoryUsage{Discounts: 1}, err: ErrCategoryInUse},
+		{name: "reparent keeps subcategory discounts", strategy: DeleteReparent, usage: CategoryUsage{Children: 1}, subtreeDiscounts: 3},
+		{name: "reparent root with subcategories", strategy: DeleteReparent, isRoot: true, usage: CategoryUsage{Children: 2}},
+		{name: "reparent root with products", strategy: DeleteReparent, isRoot: true, usage: CategoryUsage{Products: 5}, err: ErrCategoryInUse},
+		{name: "cascade with subcategories and products", strategy: DeleteCascadeArchive, isRoot: true, usage: CategoryUsage{Children: 2, Products: 5}},
+		{name: "cascade with subcategory discounts", strategy: DeleteCascadeArchive, usage: CategoryUsage{Children: 1}, subtreeDiscounts: 3, err: ErrCategoryInUse},
+		{name: "cascade with discounts", strategy: DeleteCascadeArchive, usage: CategoryUsage{Discounts: 1}, subtreeDiscounts: 1, err: ErrCategoryInUse},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkDeletion(test.strategy, test.isRoot, test.usage, test.subtreeDiscounts)
+			if test.err != nil {
+				if !errors.Is(err, test.err) {
+					t.Fatalf("error = %v, want %v", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error = %v, want nil", err)
+			}
+		})
+	}
+}
@@ -1,6 +1,19 @@
 package categories
 
-import "time"
+import (
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrCategoryNotFound = errors.New("Category not found")
+	ErrCategoryCycle    = errors.New("Category cannot be placed under itself or one of its descendants")
+	ErrCategoryInUse    = errors.New("Category is still in use")
+
+	ErrInvalidAttributeDefinition = errors.New("invalid attribute definition")
+)
 
 type Category struct {
 	ID        string    `db:"id"`
@@ -8,6 +21,41 @@ type Category struct {
 	Slug      string    `db:"slug"`
 	ParentId  *string   `db:"parent_id"`
 	Level     *int      `db:"level"`
+	Path      string    `db:"path"`
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
 }
+
+// CategoryUsage counts what still references a category.
+type CategoryUsage struct {
+	Children int `db:"children"`
+	Products int `db:"products"`
+	// Discounts counts the price list discounts granted on the category.
+	Discounts int `db:"discounts"`
+}
+
+type AttributeType string
+
+const (
+	AttributeEnum    AttributeType = "ENUM"
+	AttributeNumber  AttributeType = "NUMBER"
+	AttributeBoolean AttributeType = "BOOLEAN"
+	AttributeText    AttributeType = "TEXT"
+)
+
+type CategoryAttribute struct {
+	ID            string         `db:"id"`
+	CategoryID    string         `db:"category_id"`
+	AttributeKey  string         `db:"attribute_key"`
+	Label         string         `db:"label"`
+	Type          AttributeType  `db:"type"`
+	Unit          *string        `db:"unit"`
+	Required      bool           `db:"required"`
+	AllowedValues pq.StringArray `db:"allowed_values"`
+	DisplayOrder  int            `db:"display_order"`
+	CreatedAt     time.Time      `db:"created_at"`
+	UpdatedAt     time.Time      `db:"updated_at"`
+	// CategorySlug is the category the definition is attached to, which is an
+	// ancestor when the definition is inherited.
+	CategorySlug string `db:"category_slug"`
+}
@@ -4,7 +4,7 @@ import "time"
 
 type CategoryRequestDTO struct {
 	Name     string  `json:"name" validate:"required"`
-	Slug     string  `json:"slug" validate:"required"`
+	Slug     string  `json:"slug"`
 	ParentId *string `json:"parent_id"`
 }
 
@@ -14,10 +14,88 @@ type CategoryResponse struct {
 	Slug      string    `json:"slug"`
 	ParentId  *string   `json:"parent_id"`
 	Level     *int      `json:"level"`
+	Path      string    `json:"path"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+type CategoryTreeNode struct {
+	ID       string              `json:"id"`
+	Name     string              `json:"name"`
+	Slug     string              `json:"slug"`
+	ParentId *string             `json:"parent_id"`
+	Level    *int                `json:"level"`
+	Path     string              `json:"path"`
+	Children []*CategoryTreeNode `json:"children"`
+}
+
+type CategoryTreeResponse struct {
+	Categories []*CategoryTreeNode `json:"categories"`
+}
+
+type BreadcrumbItem struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Slug  string `json:"slug"`
+	Level *int   `json:"level"`
+}
+
+type BreadcrumbResponse struct {
+	Breadcrumb []BreadcrumbItem `json:"breadcrumb"`
+}
+
+// Strategies accepted by DeleteCategory. Each refuses while price list
+// discounts apply to a category it would delete.
+const (
+	// DeleteReject refuses to delete a category that has subcategories or products.
+	DeleteReject = "reject"
+	// DeleteReparent moves subcategories, products and attribute definitions
+	// to the deleted category's parent.
+	DeleteReparent = "reparent"
+	// DeleteCascadeArchive archives every product in the subtree and deletes the subtree.
+	DeleteCascadeArchive = "cascade_archive"
+)
+
+var DeleteStrategies = []string{DeleteReject, DeleteReparent, DeleteCascadeArchive}
+
+type MoveCategoryDTO struct {
+	// ParentId is the new parent; null moves the category to the root.
+	ParentId *string `json:"parent_id"`
+}
+
+type MergeCategoryDTO struct {
+	TargetID string `json:"target_id" validate:"required"`
+}
+
+type CategoryAttributeRequestDTO struct {
+	// Key is normalized with shared.AttributeKey; Label defaults to Key.
+	Key           string        `json:"key" validate:"required,max=100"`
+	Label         string        `json:"label" validate:"max=255"`
+	Type          AttributeType `json:"type" validate:"required,oneof=ENUM NUMBER BOOLEAN TEXT"`
+	Unit          *string       `json:"unit" validate:"omitempty,max=50"`
+	Required      bool          `json:"required"`
+	AllowedValues []string      `json:"allowed_values" validate:"required_if=Type ENUM,dive,required"`
+	DisplayOrder  int           `json:"display_order" validate:"min=0"`
+}
+
+type CategoryAttributeDTO struct {
+	Key           string        `json:"key"`
+	Label         string        `json:"label"`
+	Type          AttributeType `json:"type"`
+	Unit          *string       `json:"unit"`
+	Required      bool          `json:"required"`
+	AllowedValues []string      `json:"allowed_values"`
+	DisplayOrder  int           `json:"display_order"`
+	// Inherited is set when the definition comes from an ancestor category.
+	Inherited    bool   `json:"inherited"`
+	CategorySlug string `json:"category_slug"`
+}
+
+type CategoryAttributeSchemaResponse struct {
+	CategoryID string                 `json:"category_id"`
+	Attributes []CategoryAttributeDTO `json:"attributes"`
+}
+
 type GetAllCategory struct {
 	Categories []CategoryResponse `json:"categories"`
 }
@@ -27,6 +105,7 @@ type GetCategoryByID struct {
 }
 
 type GenericResponseDTO struct {
+	Slug    string `json:"slug,omitempty"`
 	Status  string `json:"success"`
 	Message string `json:"message"`
 }
@@ -0,0 +1,104 @@
+package categories
+
+import (
+	"context"
+	"errors"
+
+	catalogv1 "github.com/smart-safety-hub/backend/proto/catalog/v1"
+	"github.com/smart-safety-hub/backend/shared"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type GrpcHandler struct {
+	catalogv1.UnimplementedCategoryServiceServer
+	service *CategoryService
+}
+
+func NewGrpcHandler(service *CategoryService) *GrpcHandler {
+	return &GrpcHandler{
+		service: service,
+	}
+}
+
+func (h *GrpcHandler) GetCategory(ctx context.Context, request *catalogv1.GetCategoryRequest) (*catalogv1.Category, error) {
+	if request.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "ID is required")
+	}
+
+	response, err := h.service.GetCategoryByID(ctx, request.GetId())
+	if err != nil {
+		return nil, status.Error(categoryErrorCode(err), err.Error())
+	}
+
+	return toProtoCategory(*response), nil
+}
+
+func (h *GrpcHandler) GetCategoryBySlug(ctx context.Context, request *catalogv1.GetCategoryBySlugRequest) (*catalogv1.Category, error) {
+	if request.GetSlug() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Slug is required")
+	}
+
+	response, err := h.service.GetCategoryBySlug(ctx, request.GetSlug())
+
+	// gRPC has no redirects: an old slug resolves to the category under its
+	// current slug, which the caller can see in the response
+	var moved *shared.SlugMovedError
+	if errors.As(err, &moved) {
+		response, err = h.service.GetCategoryBySlug(ctx, moved.Slug)
+	}
+	if err != nil {
+		return nil, status.Error(categoryErrorCode(err), err.Error())
+	}
+
+	return toProtoCategory(*response), nil
+}
+
+func (h *GrpcHandler) ListCategories(ctx context.Context, request *catalogv1.ListCategoriesRequest) (*catalogv1.ListCategoriesResponse, error) {
+	response, err := h.service.GetAllCategory(ctx)
+	if err != nil {
+		return nil, status.Error(categoryErrorCode(err), err.Error())
+	}
+
+	categories := make([]*catalogv1.Category, 0, len(response.Categories))
+	for _, data := range response.Categories {
+		categories = append(categories, toProtoCategory(data))
+	}
+
+	return &catalogv1.ListCategoriesResponse{
+		Categories: categories,
+	}, nil
+}
+
+// categoryErrorCode is the gRPC counterpart of categoryErrorStatus.
+func categoryErrorCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, ErrCategoryNotFound):
+		return codes.NotFound
+	case errors.Is(err, ErrCategoryCycle), errors.Is(err, ErrInvalidAttributeDefinition), errors.Is(err, shared.ErrInvalidSlug):
+		return codes.InvalidArgument
+	case errors.Is(err, ErrCategoryInUse):
+		return codes.FailedPrecondition
+	}
+	return codes.Internal
+}
+
+func toProtoCategory(data CategoryResponse) *catalogv1.Category {
+	category := &catalogv1.Category{
+		Id:        data.ID,
+		Name:      data.Name,
+		Slug:      data.Slug,
+		ParentId:  data.ParentId,
+		Path:      data.Path,
+		CreatedAt: timestamppb.New(data.CreatedAt),
+		UpdatedAt: timestamppb.New(data.UpdatedAt),
+	}
+
+	if data.Level != nil {
+		level := int32(*data.Level)
+		category.Level = &level
+	}
+
+	return category
+}
@@ -2,10 +2,14 @@ package categories
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
+	"github.com/smart-safety-hub/backend/shared"
 )
 
 type RestHandler struct {
@@ -35,7 +39,7 @@ func (h *RestHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
 
 	response, err := h.service.CreateCategory(r.Context(), request)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), categoryErrorStatus(err))
 		return
 	}
 
@@ -66,7 +70,7 @@ func (h *RestHandler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
 
 	response, err := h.service.UpdateCategory(r.Context(), categoryID, request)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), categoryErrorStatus(err))
 		return
 	}
 
@@ -83,9 +87,76 @@ func (h *RestHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response, err := h.service.DeleteCategory(r.Context(), categoryID)
+	strategy := r.URL.Query().Get("strategy")
+	if strategy == "" {
+		strategy = DeleteReject
+	}
+
+	if !slices.Contains(DeleteStrategies, strategy) {
+		http.Error(w, "strategy must be one of "+strings.Join(DeleteStrategies, ", "), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.DeleteCategory(r.Context(), categoryID, strategy)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), categoryErrorStatus(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RestHandler) MoveCategory(w http.ResponseWriter, r *http.Request) {
+	categoryID := chi.URLParam(r, "id")
+
+	if categoryID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var request MoveCategoryDTO
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.MoveCategory(r.Context(), categoryID, request)
+	if err != nil {
+		http.Error(w, err.Error(), categoryErrorStatus(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RestHandler) MergeCategory(w http.ResponseWriter, r *http.Request) {
+	categoryID := chi.URLParam(r, "id")
+
+	if categoryID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var request MergeCategoryDTO
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.validator.Struct(request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.MergeCategory(r.Context(), categoryID, request)
+	if err != nil {
+		http.Error(w, err.Error(), categoryErrorStatus(err))
 		return
 	}
 
@@ -104,7 +175,7 @@ func (h *RestHandler) GetCategoryByID(w http.ResponseWriter, r *http.Request) {
 
 	response, err := h.service.GetCategoryByID(r.Context(), categoryId)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), categoryErrorStatus(err))
 		return
 	}
 
@@ -114,6 +185,32 @@ func (h *RestHandler) GetCategoryByID(w http.ResponseWriter, r *http.Request) {
 
 }
 
+// GetCategoryBySlug answers an old slug with 301 and the Location of the
+// current one.
+func (h *RestHandler) GetCategoryBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+
+	if slug == "" {
+		http.Error(w, "Slug is required", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.GetCategoryBySlug(r.Context(), slug)
+	if err != nil {
+		var moved *shared.SlugMovedError
+		if errors.As(err, &moved) {
+			shared.WriteMoved(w, r, moved)
+			return
+		}
+		http.Error(w, err.Error(), categoryErrorStatus(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusBadRequest)
+	}
+}
+
 func (h *RestHandler) GetAllCategory(w http.ResponseWriter, r *http.Request) {
 	response, err := h.service.GetAllCategory(r.Context())
 	if err != nil {
@@ -125,3 +222,128 @@ func (h *RestHandler) GetAllCategory(w http.ResponseWriter, r *http.Request) {
 	}
 
 }
+
+func (h *RestHandler) GetCategoryTree(w http.ResponseWriter, r *http.Request) {
+	response, err := h.service.GetCategoryTree(r.Context(), r.URL.Query().Get("root"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RestHandler) GetBreadcrumb(w http.ResponseWriter, r *http.Request) {
+	idOrSlug := chi.URLParam(r, "id")
+	if idOrSlug == "" {
+		idOrSlug = chi.URLParam(r, "slug")
+	}
+
+	if idOrSlug == "" {
+		http.Error(w, "ID or slug is required", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.GetBreadcrumb(r.Context(), idOrSlug)
+	if err != nil {
+		var moved *shared.SlugMovedError
+		if errors.As(err, &moved) {
+			shared.WriteMoved(w, r, moved)
+			return
+		}
+		http.Error(w, err.Error(), categoryErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func categoryErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrCategoryNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrCategoryCycle), errors.Is(err, ErrInvalidAttributeDefinition):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrCategoryInUse):
+		return http.StatusConflict
+	default:
+		return shared.SlugErrorStatus(err, http.StatusInternalServerError)
+	}
+}
+
+func (h *RestHandler) GetAttributeSchema(w http.ResponseWriter, r *http.Request) {
+	idOrSlug := chi.URLParam(r, "id")
+
+	if idOrSlug == "" {
+		http.Error(w, "ID or slug is required", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.GetAttributeSchema(r.Context(), idOrSlug)
+	if err != nil {
+		http.Error(w, err.Error(), categoryErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RestHandler) SaveCategoryAttribute(w http.ResponseWriter, r *http.Request) {
+	categoryID := chi.URLParam(r, "id")
+
+	if categoryID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var request CategoryAttributeRequestDTO
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.validator.Struct(request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.SaveCategoryAttribute(r.Context(), categoryID, request)
+	if err != nil {
+		http.Error(w, err.Error(), categoryErrorStatus(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RestHandler) DeleteCategoryAttribute(w http.ResponseWriter, r *http.Request) {
+	categoryID := chi.URLParam(r, "id")
+	key := chi.URLParam(r, "key")
+
+	if categoryID == "" || key == "" {
+		http.Error(w, "ID and key are required", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.DeleteCategoryAttribute(r.Context(), categoryID, key)
+	if err != nil {
+		http.Error(w, err.Error(), categoryErrorStatus(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
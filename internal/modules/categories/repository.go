@@ -5,8 +5,11 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/smart-safety-hub/backend/internal/modules/products"
 	"github.com/smart-safety-hub/backend/shared"
 )
 
@@ -20,52 +23,411 @@ func NewCategoryRepo(db *sqlx.DB) *CategoryRepo {
 	}
 }
 
-func (r *CategoryRepo) SaveCategory(ctx context.Context, request CategoryRequestDTO) error {
+// SaveCategory inserts a category and returns its slug, generated from the
+// name when the request has none.
+func (r *CategoryRepo) SaveCategory(ctx context.Context, request CategoryRequestDTO) (string, error) {
+	slug, err := shared.UniqueSlug(ctx, r.db, "categories", request.Name, request.Slug, "")
+	if err != nil {
+		return "", err
+	}
+
 	query := "INSERT INTO categories(name, slug, parent_id) VALUES ($1,$2,NULLIF($3, '')::uuid)"
-	if _, err := r.db.ExecContext(ctx, query, request.Name, request.Slug, request.ParentId); err != nil {
-		return shared.PostgresError(err)
+	if _, err := r.db.ExecContext(ctx, query, request.Name, slug, request.ParentId); err != nil {
+		return "", shared.PostgresError(err)
 	}
 
-	return nil
+	return slug, nil
 }
 
 func (r *CategoryRepo) UpdateCategory(ctx context.Context, categoryID string, request CategoryRequestDTO) error {
-	query := "UPDATE categories SET name=COALESCE(NULLIF($1, ''), name), slug=COALESCE(NULLIF($2, ''), slug), parent_id=COALESCE($3, parent_id) WHERE id=$4"
-	if _, err := r.db.ExecContext(ctx, query, request.Name, request.Slug, request.ParentId, categoryID); err != nil {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
 		return shared.PostgresError(err)
 	}
 
-	return nil
+	defer tx.Rollback()
+
+	if request.ParentId != nil && *request.ParentId != "" {
+		if err := checkMove(ctx, tx, categoryID, *request.ParentId); err != nil {
+			return err
+		}
+	}
+
+	if request.Slug != "" {
+		if request.Slug, err = shared.UniqueSlug(ctx, tx, "categories", "", request.Slug, categoryID); err != nil {
+			return err
+		}
+	}
+
+	query := "UPDATE categories SET name=COALESCE(NULLIF($1, ''), name), slug=COALESCE(NULLIF($2, ''), slug), parent_id=COALESCE(NULLIF($3, '')::uuid, parent_id) WHERE id=$4"
+	if _, err := tx.ExecContext(ctx, query, request.Name, request.Slug, request.ParentId, categoryID); err != nil {
+		return shared.PostgresError(err)
+	}
+
+	return tx.Commit()
+}
+
+// MoveCategory re-parents a category, or makes it a root when parentID is nil.
+// The path triggers recompute path and level for the whole subtree.
+func (r *CategoryRepo) MoveCategory(ctx context.Context, categoryID string, parentID *string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return shared.PostgresError(err)
+	}
+
+	defer tx.Rollback()
+
+	if parentID != nil {
+		if err := checkMove(ctx, tx, categoryID, *parentID); err != nil {
+			return err
+		}
+	} else if _, err := lockCategory(ctx, tx, categoryID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE categories SET parent_id=$1 WHERE id=$2", parentID, categoryID); err != nil {
+		return shared.PostgresError(err)
+	}
+
+	return tx.Commit()
+}
+
+// MergeCategory moves the subcategories, products and attribute definitions
+// of source under target and deletes source. It returns ErrCategoryInUse while
+// price list discounts apply to source.
+func (r *CategoryRepo) MergeCategory(ctx context.Context, sourceID, targetID string) (*CategoryUsage, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, shared.PostgresError(err)
+	}
+
+	defer tx.Rollback()
+
+	if err := checkMove(ctx, tx, sourceID, targetID); err != nil {
+		return nil, err
+	}
+
+	usage, err := categoryUsage(ctx, tx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if usage.Discounts > 0 {
+		return nil, discountsInUse(usage.Discounts)
+	}
+
+	if err := copyCategoryAttributes(ctx, tx, sourceID, []string{targetID}); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE categories SET parent_id=$1 WHERE parent_id=$2", targetID, sourceID); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+
+	if err := products.MoveCategoryProducts(ctx, tx, sourceID, targetID); err != nil {
+		return nil, err
+	}
+
+	if err := deleteCategories(ctx, tx, "DELETE FROM categories WHERE id=$1", sourceID); err != nil {
+		return nil, err
+	}
+
+	return usage, tx.Commit()
+}
+
+// DeleteCategory deletes a category according to strategy, one of
+// DeleteStrategies. It returns ErrCategoryInUse when the strategy cannot deal
+// with what still references the category.
+func (r *CategoryRepo) DeleteCategory(ctx context.Context, categoryID string, strategy string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return shared.PostgresError(err)
+	}
+
+	defer tx.Rollback()
+
+	category, err := lockCategory(ctx, tx, categoryID)
+	if err != nil {
+		return err
+	}
+
+	usage, err := categoryUsage(ctx, tx, categoryID)
+	if err != nil {
+		return err
+	}
+
+	var subtreeDiscounts int
+	if strategy == DeleteCascadeArchive {
+		query := "SELECT COUNT(*) FROM price_list_discounts WHERE category_id IN (SELECT id FROM categories WHERE path LIKE $1 || '%')"
+		if err := tx.GetContext(ctx, &subtreeDiscounts, query, category.Path); err != nil {
+			return shared.PostgresError(err)
+		}
+	}
+
+	if err := checkDeletion(strategy, category.ParentId == nil, *usage, subtreeDiscounts); err != nil {
+		return err
+	}
+
+	switch strategy {
+	case DeleteReparent:
+		// The parent inherits the definitions, or the subcategories of a root
+		// take them over
+		heirs := []string{}
+		if category.ParentId != nil {
+			heirs = append(heirs, *category.ParentId)
+		} else if err := tx.SelectContext(ctx, &heirs, "SELECT id FROM categories WHERE parent_id=$1", categoryID); err != nil {
+			return shared.PostgresError(err)
+		}
+
+		if err := copyCategoryAttributes(ctx, tx, categoryID, heirs); err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, "UPDATE categories SET parent_id=$1 WHERE parent_id=$2", category.ParentId, categoryID); err != nil {
+			return shared.PostgresError(err)
+		}
+
+		if usage.Products > 0 {
+			if err := products.MoveCategoryProducts(ctx, tx, categoryID, *category.ParentId); err != nil {
+				return err
+			}
+		}
+
+	case DeleteCascadeArchive:
+		// Products are archived through the workflow so they keep their history
+		if err := products.ArchiveCategoryProducts(ctx, tx, category.Path); err != nil {
+			return err
+		}
+
+		if err := deleteCategories(ctx, tx, "DELETE FROM categories WHERE path LIKE $1 || '%'", category.Path); err != nil {
+			return err
+		}
+
+		return tx.Commit()
+	}
+
+	if err := deleteCategories(ctx, tx, "DELETE FROM categories WHERE id=$1", categoryID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
-func (r *CategoryRepo) DeleteCategory(ctx context.Context, categoryID string) error {
-	query := "DELETE FROM categories WHERE id=$1"
-	if _, err := r.db.ExecContext(ctx, query, categoryID); err != nil {
+// copyCategoryAttributes copies the attribute definitions of a category that
+// is going away to the categories taking over its products and subcategories.
+// A definition the receiving category has of its own is kept, and copies are
+// optional so the products already under it stay valid.
+func copyCategoryAttributes(ctx context.Context, tx *sqlx.Tx, fromID string, toIDs []string) error {
+	query := `INSERT INTO category_attributes(category_id, attribute_key, label, type, unit, required, allowed_values, display_order)
+		SELECT t.id, ca.attribute_key, ca.label, ca.type, ca.unit, FALSE, ca.allowed_values, ca.display_order
+		FROM category_attributes ca, unnest($2::uuid[]) AS t(id)
+		WHERE ca.category_id = $1
+		ON CONFLICT (category_id, attribute_key) DO NOTHING`
+	if _, err := tx.ExecContext(ctx, query, fromID, pq.Array(toIDs)); err != nil {
 		return shared.PostgresError(err)
 	}
+	return nil
+}
+
+// deleteCategories runs a DELETE on categories. Price list discounts restrict
+// it rather than vanishing with the category, and are reported as
+// ErrCategoryInUse.
+func deleteCategories(ctx context.Context, tx *sqlx.Tx, query string, args ...interface{}) error {
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		err = shared.PostgresError(err)
+		if errors.Is(err, shared.ErrForeignKeyViolation) {
+			return fmt.Errorf("%w: remove the price list discounts on it first", ErrCategoryInUse)
+		}
+		return err
+	}
+	return nil
+}
+
+// lockCategory reads a category and locks its row until the transaction ends.
+func lockCategory(ctx context.Context, tx *sqlx.Tx, categoryID string) (*Category, error) {
+	var category Category
+	query := "SELECT id, name, slug, parent_id, level, path, created_at, updated_at FROM categories WHERE id=$1 FOR UPDATE"
+	if err := tx.GetContext(ctx, &category, query, categoryID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, shared.PostgresError(err)
+	}
+	return &category, nil
+}
+
+// checkMove locks both categories and rejects placing categoryID under
+// parentID when parentID is the category itself or one of its descendants.
+func checkMove(ctx context.Context, tx *sqlx.Tx, categoryID, parentID string) error {
+	category, err := lockCategory(ctx, tx, categoryID)
+	if err != nil {
+		return err
+	}
+
+	parent, err := lockCategory(ctx, tx, parentID)
+	if err != nil {
+		return err
+	}
+
+	if strings.HasPrefix(parent.Path, category.Path) {
+		return ErrCategoryCycle
+	}
 
 	return nil
 }
 
+func categoryUsage(ctx context.Context, tx *sqlx.Tx, categoryID string) (*CategoryUsage, error) {
+	var usage CategoryUsage
+	query := `SELECT
+		(SELECT COUNT(*) FROM categories WHERE parent_id=$1) AS children,
+		(SELECT COUNT(*) FROM products WHERE category_id=$1) AS products,
+		(SELECT COUNT(*) FROM price_list_discounts WHERE category_id=$1) AS discounts`
+	if err := tx.GetContext(ctx, &usage, query, categoryID); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+	return &usage, nil
+}
+
 func (r *CategoryRepo) GetCategoryByID(ctx context.Context, categoryID string) (*Category, error) {
 	var category Category
-	query := "SELECT * FROM categories WHERE id=$1"
+	query := "SELECT id, name, slug, parent_id, level, path, created_at, updated_at FROM categories WHERE id=$1"
 	if err := r.db.GetContext(ctx, &category, query, categoryID); err != nil {
-		fmt.Println("err", err)
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("Category not found")
+			return nil, ErrCategoryNotFound
+		}
+		return nil, shared.PostgresError(err)
+	}
+	return &category, nil
+}
+
+// GetCategoryBySlug returns a *shared.SlugMovedError when slug is an old slug
+// of a category.
+func (r *CategoryRepo) GetCategoryBySlug(ctx context.Context, slug string) (*Category, error) {
+	var category Category
+	query := "SELECT id, name, slug, parent_id, level, path, created_at, updated_at FROM categories WHERE slug=$1"
+	if err := r.db.GetContext(ctx, &category, query, slug); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, r.movedOrNotFound(ctx, slug)
 		}
 		return nil, shared.PostgresError(err)
 	}
 	return &category, nil
 }
 
+// movedOrNotFound tells an old slug, which gets redirected, from one that
+// never existed.
+func (r *CategoryRepo) movedOrNotFound(ctx context.Context, slug string) error {
+	if err := shared.MovedSlug(ctx, r.db, "categories", slug); !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	return ErrCategoryNotFound
+}
+
 func (r *CategoryRepo) GetAllCategory(ctx context.Context) ([]Category, error) {
 	var categories []Category
-	query := "SELECT id, name, slug, parent_id,level, created_at, updated_at FROM categories"
+	query := "SELECT id, name, slug, parent_id, level, path, created_at, updated_at FROM categories ORDER BY level, name"
 	if err := r.db.SelectContext(ctx, &categories, query); err != nil {
 		return nil, shared.PostgresError(err)
 	}
 
 	return categories, nil
 }
+
+// GetCategorySubtree returns the category matching rootIDOrSlug and all of its
+// descendants, or every category when rootIDOrSlug is empty.
+func (r *CategoryRepo) GetCategorySubtree(ctx context.Context, rootIDOrSlug string) ([]Category, error) {
+	var categories []Category
+	query := "SELECT id, name, slug, parent_id, level, path, created_at, updated_at FROM categories ORDER BY level, name"
+	args := []interface{}{}
+
+	if rootIDOrSlug != "" {
+		query = `SELECT c.id, c.name, c.slug, c.parent_id, c.level, c.path, c.created_at, c.updated_at
+		FROM categories root
+		JOIN categories c ON c.path LIKE root.path || '%'
+		WHERE root.id::text = $1 OR root.slug = $1
+		ORDER BY c.level, c.name`
+		args = append(args, rootIDOrSlug)
+	}
+
+	if err := r.db.SelectContext(ctx, &categories, query, args...); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+
+	return categories, nil
+}
+
+// GetBreadcrumb returns the ancestors of the category matching idOrSlug,
+// ordered from the root down to the category itself.
+func (r *CategoryRepo) GetBreadcrumb(ctx context.Context, idOrSlug string) ([]Category, error) {
+	var categories []Category
+	query := `SELECT a.id, a.name, a.slug, a.parent_id, a.level, a.path, a.created_at, a.updated_at
+		FROM categories c
+		JOIN categories a ON c.path LIKE a.path || '%'
+		WHERE c.id::text = $1 OR c.slug = $1
+		ORDER BY a.level`
+
+	if err := r.db.SelectContext(ctx, &categories, query, idOrSlug); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+
+	if len(categories) == 0 {
+		return nil, r.movedOrNotFound(ctx, idOrSlug)
+	}
+
+	return categories, nil
+}
+
+func (r *CategoryRepo) SaveCategoryAttribute(ctx context.Context, categoryID string, attribute CategoryAttribute) error {
+	query := `INSERT INTO category_attributes(category_id, attribute_key, label, type, unit, required, allowed_values, display_order)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+		ON CONFLICT (category_id, attribute_key) DO UPDATE SET
+		label = EXCLUDED.label, type = EXCLUDED.type, unit = EXCLUDED.unit, required = EXCLUDED.required,
+		allowed_values = EXCLUDED.allowed_values, display_order = EXCLUDED.display_order, updated_at = CURRENT_TIMESTAMP`
+	if _, err := r.db.ExecContext(ctx, query, categoryID, attribute.AttributeKey, attribute.Label, attribute.Type, attribute.Unit, attribute.Required, attribute.AllowedValues, attribute.DisplayOrder); err != nil {
+		return shared.PostgresError(err)
+	}
+
+	return nil
+}
+
+func (r *CategoryRepo) DeleteCategoryAttribute(ctx context.Context, categoryID string, key string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM category_attributes WHERE category_id=$1 AND attribute_key=$2", categoryID, key)
+	if err != nil {
+		return shared.PostgresError(err)
+	}
+
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return errors.New("Category attribute not found")
+	}
+
+	return nil
+}
+
+// GetAttributeSchema returns the effective attribute definitions of the
+// category matching idOrSlug, including those inherited from its ancestors.
+// When a key is defined more than once the nearest category wins.
+func (r *CategoryRepo) GetAttributeSchema(ctx context.Context, idOrSlug string) (*Category, []CategoryAttribute, error) {
+	var category Category
+	query := "SELECT id, name, slug, parent_id, level, path, created_at, updated_at FROM categories WHERE id::text=$1 OR slug=$1"
+	if err := r.db.GetContext(ctx, &category, query, idOrSlug); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, ErrCategoryNotFound
+		}
+		return nil, nil, shared.PostgresError(err)
+	}
+
+	var attributes []CategoryAttribute
+	query = `SELECT * FROM (
+			SELECT DISTINCT ON (ca.attribute_key) ca.*, a.slug AS category_slug
+			FROM categories a
+			JOIN category_attributes ca ON ca.category_id = a.id
+			WHERE $1 LIKE a.path || '%'
+			ORDER BY ca.attribute_key, a.level DESC
+		) schema
+		ORDER BY display_order, attribute_key`
+	if err := r.db.SelectContext(ctx, &attributes, query, category.Path); err != nil {
+		return nil, nil, shared.PostgresError(err)
+	}
+
+	return &category, attributes, nil
+}
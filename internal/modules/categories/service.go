@@ -3,7 +3,10 @@ package categories
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
+	"github.com/smart-safety-hub/backend/shared"
 	"go.uber.org/zap"
 )
 
@@ -20,11 +23,13 @@ func NewCategoryService(logger *zap.Logger, repo *CategoryRepo) *CategoryService
 }
 
 func (b *CategoryService) CreateCategory(ctx context.Context, request CategoryRequestDTO) (*GenericResponseDTO, error) {
-	if err := b.repo.SaveCategory(ctx, request); err != nil {
-		return nil, fmt.Errorf("Error came while saving it to DB: %v", err)
+	slug, err := b.repo.SaveCategory(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
 	}
 
 	return &GenericResponseDTO{
+		Slug:    slug,
 		Status:  "success",
 		Message: "Category Created Successfully",
 	}, nil
@@ -32,7 +37,7 @@ func (b *CategoryService) CreateCategory(ctx context.Context, request CategoryRe
 
 func (b *CategoryService) UpdateCategory(ctx context.Context, categoryId string, request CategoryRequestDTO) (*GenericResponseDTO, error) {
 	if err := b.repo.UpdateCategory(ctx, categoryId, request); err != nil {
-		return nil, fmt.Errorf("Error came while saving it to DB: %v", err)
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
 	}
 
 	return &GenericResponseDTO{
@@ -41,9 +46,32 @@ func (b *CategoryService) UpdateCategory(ctx context.Context, categoryId string,
 	}, nil
 }
 
-func (b *CategoryService) DeleteCategory(ctx context.Context, categoryID string) (*GenericResponseDTO, error) {
-	if err := b.repo.DeleteCategory(ctx, categoryID); err != nil {
-		return nil, fmt.Errorf("Error came while saving it to DB: %v", err)
+func (b *CategoryService) MoveCategory(ctx context.Context, categoryID string, request MoveCategoryDTO) (*GenericResponseDTO, error) {
+	if err := b.repo.MoveCategory(ctx, categoryID, request.ParentId); err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return &GenericResponseDTO{
+		Status:  "success",
+		Message: "Category Moved Successfully",
+	}, nil
+}
+
+func (b *CategoryService) MergeCategory(ctx context.Context, sourceID string, request MergeCategoryDTO) (*GenericResponseDTO, error) {
+	usage, err := b.repo.MergeCategory(ctx, sourceID, request.TargetID)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return &GenericResponseDTO{
+		Status:  "success",
+		Message: fmt.Sprintf("Category Merged Successfully, moved %d subcategories and %d products", usage.Children, usage.Products),
+	}, nil
+}
+
+func (b *CategoryService) DeleteCategory(ctx context.Context, categoryID string, strategy string) (*GenericResponseDTO, error) {
+	if err := b.repo.DeleteCategory(ctx, categoryID, strategy); err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
 	}
 
 	return &GenericResponseDTO{
@@ -55,7 +83,7 @@ func (b *CategoryService) DeleteCategory(ctx context.Context, categoryID string)
 func (b *CategoryService) GetCategoryByID(ctx context.Context, categoryId string) (*CategoryResponse, error) {
 	resp, err := b.repo.GetCategoryByID(ctx, categoryId)
 	if err != nil {
-		return nil, fmt.Errorf("Error came while getting data from DB: %v", err)
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
 	}
 
 	response := &CategoryResponse{
@@ -64,16 +92,36 @@ func (b *CategoryService) GetCategoryByID(ctx context.Context, categoryId string
 		Slug:      resp.Slug,
 		ParentId:  resp.ParentId,
 		Level:     resp.Level,
+		Path:      resp.Path,
 		CreatedAt: resp.CreatedAt,
+		UpdatedAt: resp.UpdatedAt,
 	}
 
 	return response, nil
 }
 
+func (b *CategoryService) GetCategoryBySlug(ctx context.Context, slug string) (*CategoryResponse, error) {
+	resp, err := b.repo.GetCategoryBySlug(ctx, slug)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	return &CategoryResponse{
+		ID:        resp.ID,
+		Name:      resp.Name,
+		Slug:      resp.Slug,
+		ParentId:  resp.ParentId,
+		Level:     resp.Level,
+		Path:      resp.Path,
+		CreatedAt: resp.CreatedAt,
+		UpdatedAt: resp.UpdatedAt,
+	}, nil
+}
+
 func (b *CategoryService) GetAllCategory(ctx context.Context) (*GetAllCategory, error) {
 	response, err := b.repo.GetAllCategory(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Error came while getting data from DB: %v", err)
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
 	}
 
 	categories := make([]CategoryResponse, 0, len(response))
@@ -85,6 +133,7 @@ func (b *CategoryService) GetAllCategory(ctx context.Context) (*GetAllCategory,
 			Slug:      data.Slug,
 			ParentId:  data.ParentId,
 			Level:     data.Level,
+			Path:      data.Path,
 			CreatedAt: data.CreatedAt,
 			UpdatedAt: data.UpdatedAt,
 		})
@@ -94,3 +143,149 @@ func (b *CategoryService) GetAllCategory(ctx context.Context) (*GetAllCategory,
 		Categories: categories,
 	}, nil
 }
+
+func (b *CategoryService) GetCategoryTree(ctx context.Context, root string) (*CategoryTreeResponse, error) {
+	response, err := b.repo.GetCategorySubtree(ctx, root)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	if root != "" && len(response) == 0 {
+		return nil, ErrCategoryNotFound
+	}
+
+	// Rows come ordered by level, so every parent is placed before its children
+	nodes := make(map[string]*CategoryTreeNode, len(response))
+	roots := make([]*CategoryTreeNode, 0)
+
+	for _, data := range response {
+		node := &CategoryTreeNode{
+			ID:       data.ID,
+			Name:     data.Name,
+			Slug:     data.Slug,
+			ParentId: data.ParentId,
+			Level:    data.Level,
+			Path:     data.Path,
+			Children: []*CategoryTreeNode{},
+		}
+		nodes[data.ID] = node
+
+		if data.ParentId != nil {
+			if parent, ok := nodes[*data.ParentId]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+
+	return &CategoryTreeResponse{
+		Categories: roots,
+	}, nil
+}
+
+func (b *CategoryService) GetBreadcrumb(ctx context.Context, idOrSlug string) (*BreadcrumbResponse, error) {
+	response, err := b.repo.GetBreadcrumb(ctx, idOrSlug)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	breadcrumb := make([]BreadcrumbItem, 0, len(response))
+	for _, data := range response {
+		breadcrumb = append(breadcrumb, BreadcrumbItem{
+			ID:    data.ID,
+			Name:  data.Name,
+			Slug:  data.Slug,
+			Level: data.Level,
+		})
+	}
+
+	return &BreadcrumbResponse{
+		Breadcrumb: breadcrumb,
+	}, nil
+}
+
+func (b *CategoryService) SaveCategoryAttribute(ctx context.Context, categoryID string, request CategoryAttributeRequestDTO) (*GenericResponseDTO, error) {
+	key := shared.AttributeKey(request.Key)
+	if key == "" {
+		return nil, fmt.Errorf("%w: key must contain letters or digits", ErrInvalidAttributeDefinition)
+	}
+
+	label := strings.TrimSpace(request.Label)
+	if label == "" {
+		label = strings.TrimSpace(request.Key)
+	}
+
+	allowed := make([]string, 0, len(request.AllowedValues))
+	for _, value := range request.AllowedValues {
+		value = strings.TrimSpace(value)
+		if !slices.ContainsFunc(allowed, func(v string) bool { return strings.EqualFold(v, value) }) {
+			allowed = append(allowed, value)
+		}
+	}
+
+	if request.Type == AttributeBoolean && len(allowed) > 0 {
+		return nil, fmt.Errorf("%w: boolean attributes cannot have allowed values", ErrInvalidAttributeDefinition)
+	}
+
+	if request.Unit != nil && request.Type != AttributeNumber {
+		return nil, fmt.Errorf("%w: only number attributes can have a unit", ErrInvalidAttributeDefinition)
+	}
+
+	attribute := CategoryAttribute{
+		AttributeKey:  key,
+		Label:         label,
+		Type:          request.Type,
+		Unit:          request.Unit,
+		Required:      request.Required,
+		AllowedValues: allowed,
+		DisplayOrder:  request.DisplayOrder,
+	}
+
+	if err := b.repo.SaveCategoryAttribute(ctx, categoryID, attribute); err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return &GenericResponseDTO{
+		Status:  "success",
+		Message: "Category Attribute Saved Successfully",
+	}, nil
+}
+
+func (b *CategoryService) DeleteCategoryAttribute(ctx context.Context, categoryID string, key string) (*GenericResponseDTO, error) {
+	if err := b.repo.DeleteCategoryAttribute(ctx, categoryID, shared.AttributeKey(key)); err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return &GenericResponseDTO{
+		Status:  "success",
+		Message: "Category Attribute Deleted Successfully",
+	}, nil
+}
+
+func (b *CategoryService) GetAttributeSchema(ctx context.Context, idOrSlug string) (*CategoryAttributeSchemaResponse, error) {
+	category, response, err := b.repo.GetAttributeSchema(ctx, idOrSlug)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	attributes := make([]CategoryAttributeDTO, 0, len(response))
+	for _, data := range response {
+		attributes = append(attributes, CategoryAttributeDTO{
+			Key:           data.AttributeKey,
+			Label:         data.Label,
+			Type:          data.Type,
+			Unit:          data.Unit,
+			Required:      data.Required,
+			AllowedValues: data.AllowedValues,
+			DisplayOrder:  data.DisplayOrder,
+			Inherited:     data.CategoryID != category.ID,
+			CategorySlug:  data.CategorySlug,
+		})
+	}
+
+	return &CategoryAttributeSchemaResponse{
+		CategoryID: category.ID,
+		Attributes: attributes,
+	}, nil
+}
@@ -0,0 +1,117 @@
+package compliance
+
+import (
+	"fmt"
+	"time"
+)
+
+// What the expiry job does with the product of a lapsed mandatory
+// certificate, see migrations/016_compliance.sql.
+const (
+	OnExpiryFlag    = "FLAG"
+	OnExpiryArchive = "ARCHIVE"
+)
+
+// Certificate statuses, derived from the issue and expiry dates.
+const (
+	StatusValid       = "VALID"
+	StatusExpiring    = "EXPIRING"
+	StatusExpired     = "EXPIRED"
+	StatusNotYetValid = "NOT_YET_VALID"
+)
+
+var CertificateStatuses = []string{StatusValid, StatusExpiring, StatusExpired, StatusNotYetValid}
+
+// ExpiringWindow is how long before its expiry date a certificate is reported
+// as EXPIRING.
+const ExpiringWindow = 30 * 24 * time.Hour
+
+// documentBucket is the key prefix certificate PDFs are uploaded under.
+const documentBucket = "certificates"
+
+// maxDocumentSize caps the size of an uploaded certificate PDF.
+const maxDocumentSize = 20 << 20
+
+// validateCertificate checks the dates of a certificate: the expiry date, if
+// any, must come after the issue date.
+func validateCertificate(request CertificateRequestDTO) error {
+	issuedOn, err := time.Parse(time.DateOnly, request.IssuedOn)
+	if err != nil {
+		return fmt.Errorf("%w: issued_on must be YYYY-MM-DD", ErrInvalidCertificate)
+	}
+
+	if request.ExpiresOn != nil {
+		expiresOn, err := time.Parse(time.DateOnly, *request.ExpiresOn)
+		if err != nil {
+			return fmt.Errorf("%w: expires_on must be YYYY-MM-DD", ErrInvalidCertificate)
+		}
+		if !expiresOn.After(issuedOn) {
+			return fmt.Errorf("%w: expires_on must be after issued_on", ErrInvalidCertificate)
+		}
+	}
+
+	return nil
+}
+
+// validateNewCertificate checks an uploaded certificate: besides its dates
+// making sense, it must not have expired already, or the expiry job would
+// act on the product as soon as it is uploaded.
+func validateNewCertificate(request CertificateRequestDTO, now time.Time) error {
+	if err := validateCertificate(request); err != nil {
+		return err
+	}
+
+	if request.ExpiresOn != nil && *request.ExpiresOn < now.Format(time.DateOnly) {
+		return fmt.Errorf("%w: the certificate expired on %s", ErrInvalidCertificate, *request.ExpiresOn)
+	}
+
+	return nil
+}
+
+// certificateStatus tells where today falls in the validity of a
+// certificate. Dates are whole days: a certificate is still valid on its
+// expiry date.
+func certificateStatus(certificate Certificate, now time.Time) string {
+	today, _ := time.Parse(time.DateOnly, now.Format(time.DateOnly))
+
+	switch {
+	case today.Before(certificate.IssuedOn):
+		return StatusNotYetValid
+	case certificate.ExpiresOn == nil:
+		return StatusValid
+	case today.After(*certificate.ExpiresOn):
+		return StatusExpired
+	case !today.Add(ExpiringWindow).Before(*certificate.ExpiresOn):
+		return StatusExpiring
+	default:
+		return StatusValid
+	}
+}
+
+func toCertificateResponse(certificate Certificate, now time.Time) CertificateResponse {
+	response := CertificateResponse{
+		ID:                certificate.ID,
+		ProductID:         certificate.ProductID,
+		ProductName:       certificate.ProductName,
+		VariantID:         certificate.VariantID,
+		VariantSKU:        certificate.VariantSKU,
+		Standard:          certificate.Standard,
+		CertifyingBody:    certificate.CertifyingBody,
+		CertificateNumber: certificate.CertificateNumber,
+		IssuedOn:          certificate.IssuedOn.Format(time.DateOnly),
+		Status:            certificateStatus(certificate, now),
+		DocumentURL:       certificate.DocumentURL,
+		IsMandatory:       certificate.IsMandatory,
+		OnExpiry:          certificate.OnExpiry,
+		UploadedBy:        certificate.UploadedBy,
+		CreatedAt:         certificate.CreatedAt,
+		UpdatedAt:         certificate.UpdatedAt,
+	}
+
+	if certificate.ExpiresOn != nil {
+		expiresOn := certificate.ExpiresOn.Format(time.DateOnly)
+		response.ExpiresOn = &expiresOn
+	}
+
+	return response
+}
@@ -0,0 +1,50 @@
+package compliance
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrCertificateNotFound = errors.New("certificate not found")
+	ErrInvalidCertificate  = errors.New("invalid certificate")
+	ErrProductNotFound     = errors.New("product not found")
+	ErrNotProductSeller    = errors.New("only the seller of the product can change its certificates")
+)
+
+type Certificate struct {
+	ID                string     `db:"id"`
+	ProductID         string     `db:"product_id"`
+	VariantID         *string    `db:"variant_id"`
+	ProductName       string     `db:"product_name"`
+	VariantSKU        *string    `db:"variant_sku"`
+	Standard          string     `db:"standard"`
+	CertifyingBody    string     `db:"certifying_body"`
+	CertificateNumber string     `db:"certificate_number"`
+	IssuedOn          time.Time  `db:"issued_on"`
+	ExpiresOn         *time.Time `db:"expires_on"`
+	DocumentURL       string     `db:"document_url"`
+	IsMandatory       bool       `db:"is_mandatory"`
+	OnExpiry          string     `db:"on_expiry"`
+	UploadedBy        *string    `db:"uploaded_by"`
+	CreatedAt         time.Time  `db:"created_at"`
+	UpdatedAt         time.Time  `db:"updated_at"`
+}
+
+// CertificateFilters narrow the certificate listing. Status is one of
+// CertificateStatuses.
+type CertificateFilters struct {
+	ProductID string
+	Standard  string
+	Search    string
+	Status    string
+	Page      int
+	Limit     int
+}
+
+// ExpiryCheck counts what one run of the expiry job changed.
+type ExpiryCheck struct {
+	Flagged  int
+	Cleared  int
+	Archived int
+}
@@ -0,0 +1,52 @@
+package compliance
+
+import "time"
+
+// CertificateRequestDTO describes a certificate of a product, or of one of its
+// variants when VariantID is set. Dates are YYYY-MM-DD; certificates without
+// an expiry date never expire. On upload IsMandatory defaults to true and
+// OnExpiry to FLAG; an update that omits them keeps the stored values.
+type CertificateRequestDTO struct {
+	VariantID         *string `json:"variant_id" validate:"omitempty,uuid"`
+	Standard          string  `json:"standard" validate:"required,max=100"`
+	CertifyingBody    string  `json:"certifying_body" validate:"required,max=200"`
+	CertificateNumber string  `json:"certificate_number" validate:"required,max=100"`
+	IssuedOn          string  `json:"issued_on" validate:"required,datetime=2006-01-02"`
+	ExpiresOn         *string `json:"expires_on" validate:"omitempty,datetime=2006-01-02"`
+	IsMandatory       *bool   `json:"is_mandatory"`
+	OnExpiry          *string `json:"on_expiry" validate:"omitempty,oneof=FLAG ARCHIVE"`
+}
+
+// CertificateResponse carries Status, derived from the dates: VALID,
+// EXPIRING (within ExpiringWindow), EXPIRED or NOT_YET_VALID.
+type CertificateResponse struct {
+	ID                string    `json:"id"`
+	ProductID         string    `json:"product_id"`
+	ProductName       string    `json:"product_name"`
+	VariantID         *string   `json:"variant_id"`
+	VariantSKU        *string   `json:"variant_sku"`
+	Standard          string    `json:"standard"`
+	CertifyingBody    string    `json:"certifying_body"`
+	CertificateNumber string    `json:"certificate_number"`
+	IssuedOn          string    `json:"issued_on"`
+	ExpiresOn         *string   `json:"expires_on"`
+	Status            string    `json:"status"`
+	DocumentURL       string    `json:"document_url"`
+	IsMandatory       bool      `json:"is_mandatory"`
+	OnExpiry          string    `json:"on_expiry"`
+	UploadedBy        *string   `json:"uploaded_by"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+type CertificateListResponse struct {
+	Certificates []CertificateResponse `json:"certificates"`
+	Page         int                   `json:"page"`
+	Limit        int                   `json:"limit"`
+}
+
+type GenericResponseDTO struct {
+	ID      *string `json:"id,omitempty"`
+	Status  string  `json:"success"`
+	Message string  `json:"message"`
+}
@@ -0,0 +1,249 @@
+package compliance
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+type RestHandler struct {
+	service   *ComplianceService
+	validator *validator.Validate
+}
+
+func NewRestHandler(service *ComplianceService, validator *validator.Validate) *RestHandler {
+	return &RestHandler{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// GetCertificates lists certificates:
+// ?[product_id=<id>][&standard=<standard>][&search=<text>][&status=<status>][&page=<n>][&limit=<n>].
+func (h *RestHandler) GetCertificates(w http.ResponseWriter, r *http.Request) {
+	h.getCertificates(w, r, r.URL.Query().Get("product_id"))
+}
+
+// GetProductCertificates lists the certificates of one product, with the
+// same filters as GetCertificates.
+func (h *RestHandler) GetProductCertificates(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "id")
+
+	if productID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	h.getCertificates(w, r, productID)
+}
+
+func (h *RestHandler) getCertificates(w http.ResponseWriter, r *http.Request, productID string) {
+	query := r.URL.Query()
+
+	filters := CertificateFilters{
+		ProductID: productID,
+		Standard:  query.Get("standard"),
+		Search:    query.Get("search"),
+		Status:    strings.ToUpper(query.Get("status")),
+		Page:      1,
+		Limit:     100,
+	}
+
+	if filters.Status != "" && !slices.Contains(CertificateStatuses, filters.Status) {
+		http.Error(w, "Invalid status, allowed: "+strings.Join(CertificateStatuses, ","), http.StatusBadRequest)
+		return
+	}
+
+	if p, err := strconv.Atoi(query.Get("page")); err == nil && p > 0 {
+		filters.Page = p
+	}
+
+	if l, err := strconv.Atoi(query.Get("limit")); err == nil && l > 0 {
+		filters.Limit = min(l, 500)
+	}
+
+	response, err := h.service.GetCertificates(r.Context(), filters)
+	if err != nil {
+		http.Error(w, err.Error(), complianceErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RestHandler) GetCertificate(w http.ResponseWriter, r *http.Request) {
+	certificateID := chi.URLParam(r, "id")
+
+	if certificateID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.GetCertificate(r.Context(), certificateID)
+	if err != nil {
+		http.Error(w, err.Error(), complianceErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// UploadCertificate takes a multipart form with the certificate PDF as "file"
+// and the fields of CertificateRequestDTO as form values.
+func (h *RestHandler) UploadCertificate(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(shared.UserClaimsKey).(*shared.UserClaims)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	productID := chi.URLParam(r, "id")
+
+	if productID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxDocumentSize+1<<20)
+	if err := r.ParseMultipartForm(maxDocumentSize); err != nil {
+		http.Error(w, "Multipart parse error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	request := CertificateRequestDTO{
+		Standard:          strings.TrimSpace(r.PostFormValue("standard")),
+		CertifyingBody:    strings.TrimSpace(r.PostFormValue("certifying_body")),
+		CertificateNumber: strings.TrimSpace(r.PostFormValue("certificate_number")),
+		IssuedOn:          r.PostFormValue("issued_on"),
+	}
+	if value := r.PostFormValue("variant_id"); value != "" {
+		request.VariantID = &value
+	}
+	if value := r.PostFormValue("expires_on"); value != "" {
+		request.ExpiresOn = &value
+	}
+	if value := strings.ToUpper(r.PostFormValue("on_expiry")); value != "" {
+		request.OnExpiry = &value
+	}
+	if value := r.PostFormValue("is_mandatory"); value != "" {
+		isMandatory, err := strconv.ParseBool(value)
+		if err != nil {
+			http.Error(w, "is_mandatory must be true or false", http.StatusBadRequest)
+			return
+		}
+		request.IsMandatory = &isMandatory
+	}
+
+	if err := h.validator.Struct(request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "file is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	// Certificates are PDFs; the upload service also takes images
+	contentType, err := shared.DetectContentType(file)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if contentType != "application/pdf" {
+		http.Error(w, "file must be a PDF", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.UploadCertificate(r.Context(), claims.UserID, productID, request, file, header)
+	if err != nil {
+		http.Error(w, err.Error(), complianceErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// UpdateCertificate replaces the details of a certificate. The document
+// stays; a renewed certificate is uploaded as a new one.
+func (h *RestHandler) UpdateCertificate(w http.ResponseWriter, r *http.Request) {
+	certificateID := chi.URLParam(r, "id")
+
+	if certificateID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var request CertificateRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.validator.Struct(request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.UpdateCertificate(r.Context(), certificateID, request)
+	if err != nil {
+		http.Error(w, err.Error(), complianceErrorStatus(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RestHandler) DeleteCertificate(w http.ResponseWriter, r *http.Request) {
+	certificateID := chi.URLParam(r, "id")
+
+	if certificateID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.DeleteCertificate(r.Context(), certificateID)
+	if err != nil {
+		http.Error(w, err.Error(), complianceErrorStatus(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func complianceErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrCertificateNotFound), errors.Is(err, ErrProductNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrNotProductSeller):
+		return http.StatusForbidden
+	case errors.Is(err, ErrInvalidCertificate), errors.Is(err, shared.ErrForeignKeyViolation):
+		return http.StatusBadRequest
+	case errors.Is(err, shared.ErrUniqueViolation):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
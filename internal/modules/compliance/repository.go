@@ -0,0 +1,233 @@
+package compliance
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+type ComplianceRepo struct {
+	db *sqlx.DB
+}
+
+func NewComplianceRepo(db *sqlx.DB) *ComplianceRepo {
+	return &ComplianceRepo{
+		db: db,
+	}
+}
+
+const certificateColumns = `pc.id, pc.product_id, pc.variant_id, p.name AS product_name, pv.sku AS variant_sku,
+	pc.standard, pc.certifying_body, pc.certificate_number, pc.issued_on, pc.expires_on, pc.document_url,
+	pc.is_mandatory, pc.on_expiry, pc.uploaded_by, pc.created_at, pc.updated_at
+	FROM product_certificates pc
+	JOIN products p ON p.id = pc.product_id
+	LEFT JOIN product_variants pv ON pv.id = pc.variant_id`
+
+func (r *ComplianceRepo) GetCertificates(ctx context.Context, filters CertificateFilters) ([]Certificate, error) {
+	query := "SELECT " + certificateColumns + " WHERE 1=1"
+	var args []interface{}
+
+	if filters.ProductID != "" {
+		query += " AND pc.product_id = ?"
+		args = append(args, filters.ProductID)
+	}
+
+	if filters.Standard != "" {
+		query += " AND UPPER(pc.standard) = UPPER(?)"
+		args = append(args, filters.Standard)
+	}
+
+	if filters.Search != "" {
+		pattern := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(filters.Search) + "%"
+		query += " AND (pc.standard ILIKE ? OR pc.certifying_body ILIKE ? OR pc.certificate_number ILIKE ? OR p.name ILIKE ?)"
+		args = append(args, pattern, pattern, pattern, pattern)
+	}
+
+	// Statuses as in certificateStatus, on the database's today
+	switch filters.Status {
+	case StatusValid:
+		query += " AND certificate_valid(pc.issued_on, pc.expires_on, CURRENT_DATE)"
+	case StatusExpiring:
+		query += " AND certificate_valid(pc.issued_on, pc.expires_on, CURRENT_DATE) AND pc.expires_on <= CURRENT_DATE + ?::int"
+		args = append(args, int(ExpiringWindow.Hours()/24))
+	case StatusExpired:
+		query += " AND pc.expires_on < CURRENT_DATE"
+	case StatusNotYetValid:
+		query += " AND pc.issued_on > CURRENT_DATE"
+	}
+
+	query += " ORDER BY pc.expires_on NULLS LAST, pc.standard, pc.id LIMIT ? OFFSET ?"
+	args = append(args, filters.Limit, (filters.Page-1)*filters.Limit)
+
+	var certificates []Certificate
+	if err := r.db.SelectContext(ctx, &certificates, r.db.Rebind(query), args...); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+	return certificates, nil
+}
+
+func (r *ComplianceRepo) GetCertificate(ctx context.Context, id string) (*Certificate, error) {
+	var certificate Certificate
+	if err := r.db.GetContext(ctx, &certificate, "SELECT "+certificateColumns+" WHERE pc.id = $1", id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCertificateNotFound
+		}
+		return nil, shared.PostgresError(err)
+	}
+	return &certificate, nil
+}
+
+// CheckProductSeller checks that the product exists and that the user making
+// the request may change its certificates, see shared.CanManageProduct.
+func (r *ComplianceRepo) CheckProductSeller(ctx context.Context, productID string) error {
+	var sellerID string
+	if err := r.db.GetContext(ctx, &sellerID, "SELECT seller_id FROM products WHERE id = $1", productID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrProductNotFound
+		}
+		return shared.PostgresError(err)
+	}
+	if !shared.CanManageProduct(ctx, sellerID) {
+		return ErrNotProductSeller
+	}
+	return nil
+}
+
+// checkScope checks that the user may change the certificates of the product,
+// see CheckProductSeller, and that the variant, if any, is one of its
+// variants.
+func (r *ComplianceRepo) checkScope(ctx context.Context, productID string, variantID *string) error {
+	if err := r.CheckProductSeller(ctx, productID); err != nil {
+		return err
+	}
+
+	if variantID == nil {
+		return nil
+	}
+	var exists bool
+	query := "SELECT EXISTS (SELECT 1 FROM product_variants WHERE id = $1 AND product_id = $2)"
+	if err := r.db.GetContext(ctx, &exists, query, *variantID, productID); err != nil {
+		return shared.PostgresError(err)
+	}
+	if !exists {
+		return fmt.Errorf("%w: the variant does not belong to the product", ErrInvalidCertificate)
+	}
+	return nil
+}
+
+func (r *ComplianceRepo) SaveCertificate(ctx context.Context, productID string, request CertificateRequestDTO, documentURL string, uploadedBy *string) (string, error) {
+	if err := r.checkScope(ctx, productID, request.VariantID); err != nil {
+		return "", err
+	}
+
+	var id string
+	query := `INSERT INTO product_certificates (product_id, variant_id, standard, certifying_body, certificate_number, issued_on, expires_on, document_url, is_mandatory, on_expiry, uploaded_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, COALESCE($9, TRUE), COALESCE($10, 'FLAG'), $11) RETURNING id`
+	if err := r.db.GetContext(ctx, &id, query, productID, request.VariantID, request.Standard, request.CertifyingBody, request.CertificateNumber,
+		request.IssuedOn, request.ExpiresOn, documentURL, request.IsMandatory, request.OnExpiry, uploadedBy); err != nil {
+		return "", shared.PostgresError(err)
+	}
+	return id, nil
+}
+
+// UpdateCertificate replaces the details of a certificate; its document is
+// kept, as are is_mandatory and on_expiry when the request omits them.
+func (r *ComplianceRepo) UpdateCertificate(ctx context.Context, id string, request CertificateRequestDTO) error {
+	certificate, err := r.GetCertificate(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.checkScope(ctx, certificate.ProductID, request.VariantID); err != nil {
+		return err
+	}
+
+	query := `UPDATE product_certificates SET variant_id = $1, standard = $2, certifying_body = $3, certificate_number = $4, issued_on = $5, expires_on = $6,
+		is_mandatory = COALESCE($7, is_mandatory), on_expiry = COALESCE($8, on_expiry) WHERE id = $9`
+	if _, err := r.db.ExecContext(ctx, query, request.VariantID, request.Standard, request.CertifyingBody, request.CertificateNumber,
+		request.IssuedOn, request.ExpiresOn, request.IsMandatory, request.OnExpiry, id); err != nil {
+		return shared.PostgresError(err)
+	}
+	return nil
+}
+
+func (r *ComplianceRepo) DeleteCertificate(ctx context.Context, id string) error {
+	certificate, err := r.GetCertificate(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.CheckProductSeller(ctx, certificate.ProductID); err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, "DELETE FROM product_certificates WHERE id = $1", id)
+	if err != nil {
+		return shared.PostgresError(err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrCertificateNotFound
+	}
+	return nil
+}
+
+// RunExpiryCheck flags the products with a lapsed mandatory certificate on
+// the given day, clears the flag of products whose certificates were renewed
+// and schedules the active products with a lapsed ARCHIVE certificate for
+// archiving through unpublish_at, which the product scheduler acts on.
+func (r *ComplianceRepo) RunExpiryCheck(ctx context.Context, on time.Time) (*ExpiryCheck, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, shared.PostgresError(err)
+	}
+
+	defer tx.Rollback()
+
+	day := on.Format(time.DateOnly)
+	var check ExpiryCheck
+
+	query := `WITH lapsed AS (
+		SELECT DISTINCT product_id FROM product_certificates pc
+		WHERE pc.is_mandatory AND pc.expires_on < $1::date AND certificate_lapsed(pc.id, $1::date)
+	)
+	UPDATE products SET compliance_flagged_at = CURRENT_TIMESTAMP
+	WHERE compliance_flagged_at IS NULL AND id IN (SELECT product_id FROM lapsed)`
+	result, err := tx.ExecContext(ctx, query, day)
+	if err != nil {
+		return nil, shared.PostgresError(err)
+	}
+	flagged, _ := result.RowsAffected()
+	check.Flagged = int(flagged)
+
+	query = `UPDATE products p SET compliance_flagged_at = NULL
+	WHERE p.compliance_flagged_at IS NOT NULL AND NOT EXISTS (
+		SELECT 1 FROM product_certificates pc WHERE pc.product_id = p.id AND certificate_lapsed(pc.id, $1::date)
+	)`
+	result, err = tx.ExecContext(ctx, query, day)
+	if err != nil {
+		return nil, shared.PostgresError(err)
+	}
+	cleared, _ := result.RowsAffected()
+	check.Cleared = int(cleared)
+
+	query = `UPDATE products p SET unpublish_at = CURRENT_TIMESTAMP
+	WHERE p.status = 'ACTIVE' AND (p.unpublish_at IS NULL OR p.unpublish_at > CURRENT_TIMESTAMP) AND EXISTS (
+		SELECT 1 FROM product_certificates pc
+		WHERE pc.product_id = p.id AND pc.on_expiry = 'ARCHIVE' AND certificate_lapsed(pc.id, $1::date)
+	)`
+	result, err = tx.ExecContext(ctx, query, day)
+	if err != nil {
+		return nil, shared.PostgresError(err)
+	}
+	archived, _ := result.RowsAffected()
+	check.Archived = int(archived)
+
+	if err := tx.Commit(); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+	return &check, nil
+}
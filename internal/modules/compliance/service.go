@@ -0,0 +1,133 @@
+package compliance
+
+import (
+	"context"
+	"fmt"
+	"mime/multipart"
+	"time"
+
+	"github.com/smart-safety-hub/backend/internal/modules/aws"
+	"go.uber.org/zap"
+)
+
+type ComplianceService struct {
+	logger   *zap.Logger
+	repo     *ComplianceRepo
+	uploader *aws.UploadService
+}
+
+func NewComplianceService(logger *zap.Logger, repo *ComplianceRepo, uploader *aws.UploadService) *ComplianceService {
+	return &ComplianceService{
+		logger:   logger,
+		repo:     repo,
+		uploader: uploader,
+	}
+}
+
+func (b *ComplianceService) GetCertificates(ctx context.Context, filters CertificateFilters) (*CertificateListResponse, error) {
+	certificates, err := b.repo.GetCertificates(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	now := time.Now()
+	response := &CertificateListResponse{
+		Certificates: make([]CertificateResponse, 0, len(certificates)),
+		Page:         filters.Page,
+		Limit:        filters.Limit,
+	}
+	for _, certificate := range certificates {
+		response.Certificates = append(response.Certificates, toCertificateResponse(certificate, now))
+	}
+	return response, nil
+}
+
+func (b *ComplianceService) GetCertificate(ctx context.Context, id string) (*CertificateResponse, error) {
+	certificate, err := b.repo.GetCertificate(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	response := toCertificateResponse(*certificate, time.Now())
+	return &response, nil
+}
+
+// UploadCertificate stores the certificate PDF through the upload service and
+// records the certificate against the product.
+func (b *ComplianceService) UploadCertificate(ctx context.Context, userID string, productID string, request CertificateRequestDTO, file multipart.File, header *multipart.FileHeader) (*GenericResponseDTO, error) {
+	if err := validateNewCertificate(request, time.Now()); err != nil {
+		return nil, err
+	}
+
+	// The product is checked before the file is uploaded
+	if err := b.repo.CheckProductSeller(ctx, productID); err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	upload, err := b.uploader.UploadImage(ctx, file, header, documentBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := b.repo.SaveCertificate(ctx, productID, request, upload.URL, &userID)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return &GenericResponseDTO{
+		ID:      &id,
+		Status:  "success",
+		Message: "Certificate Uploaded Successfully",
+	}, nil
+}
+
+func (b *ComplianceService) UpdateCertificate(ctx context.Context, id string, request CertificateRequestDTO) (*GenericResponseDTO, error) {
+	if err := validateCertificate(request); err != nil {
+		return nil, err
+	}
+
+	if err := b.repo.UpdateCertificate(ctx, id, request); err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return &GenericResponseDTO{
+		ID:      &id,
+		Status:  "success",
+		Message: "Certificate Updated Successfully",
+	}, nil
+}
+
+func (b *ComplianceService) DeleteCertificate(ctx context.Context, id string) (*GenericResponseDTO, error) {
+	if err := b.repo.DeleteCertificate(ctx, id); err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return &GenericResponseDTO{
+		Status:  "success",
+		Message: "Certificate Deleted Successfully",
+	}, nil
+}
+
+// RunExpiryJob checks certificate expiry once at start and then every
+// interval, normally a day, until ctx is cancelled. See
+// ComplianceRepo.RunExpiryCheck.
+func (b *ComplianceService) RunExpiryJob(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		check, err := b.repo.RunExpiryCheck(ctx, time.Now())
+		if err != nil && ctx.Err() == nil {
+			b.logger.Error("failed to check certificate expiry", zap.Error(err))
+		}
+		if check != nil && (check.Flagged > 0 || check.Cleared > 0 || check.Archived > 0) {
+			b.logger.Info("certificate expiry check", zap.Int("flagged", check.Flagged), zap.Int("cleared", check.Cleared), zap.Int("archived", check.Archived))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package documents
+
+import "strings"
+
+// Document kinds, see migrations/017_documents.sql.
+const (
+	KindSDS       = "SDS"
+	KindDatasheet = "DATASHEET"
+	KindManual    = "MANUAL"
+	KindBrochure  = "BROCHURE"
+	KindOther     = "OTHER"
+)
+
+var DocumentKinds = []string{KindSDS, KindDatasheet, KindManual, KindBrochure, KindOther}
+
+// DefaultLanguage is downloaded when the request names no language and the
+// document has a file in it.
+const DefaultLanguage = "en"
+
+// documentBucket is the key prefix document files are uploaded under.
+const documentBucket = "documents"
+
+// maxDocumentSize caps the size of an uploaded document file.
+const maxDocumentSize = 50 << 20
+
+// normalizeLanguage lower-cases a BCP 47 tag ("en-IN" -> "en-in") so one
+// language is stored and matched one way.
+func normalizeLanguage(language string) string {
+	return strings.ToLower(strings.TrimSpace(language))
+}
+
+// toDocumentResponses groups files under their documents, in the order of
+// both. The uploader is left out when public.
+func toDocumentResponses(documents []Document, files []DocumentFile, public bool) []DocumentResponse {
+	byDocument := make(map[string][]DocumentFileResponse, len(documents))
+	for _, file := range files {
+		response := DocumentFileResponse{
+			ID:        file.ID,
+			Language:  file.Language,
+			Version:   file.Version,
+			URL:       file.FileURL,
+			FileName:  file.FileName,
+			FileSize:  file.FileSize,
+			CreatedAt: file.CreatedAt,
+		}
+		if !public {
+			response.UploadedBy = file.UploadedBy
+		}
+		byDocument[file.DocumentID] = append(byDocument[file.DocumentID], response)
+	}
+
+	responses := make([]DocumentResponse, 0, len(documents))
+	for _, document := range documents {
+		documentFiles := byDocument[document.ID]
+		if documentFiles == nil {
+			documentFiles = []DocumentFileResponse{}
+		}
+		responses = append(responses, DocumentResponse{
+			ID:        document.ID,
+			ProductID: document.ProductID,
+			Kind:      document.Kind,
+			Title:     document.Title,
+			Files:     documentFiles,
+			CreatedAt: document.CreatedAt,
+			UpdatedAt: document.UpdatedAt,
+		})
+	}
+	return responses
+}
@@ -0,0 +1,46 @@
+package documents
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrDocumentNotFound = errors.New("document not found")
+	ErrProductNotFound  = errors.New("product not found")
+	ErrNotProductSeller = errors.New("only the seller of the product can change its documents")
+)
+
+type Document struct {
+	ID        string    `db:"id"`
+	ProductID string    `db:"product_id"`
+	Kind      string    `db:"kind"`
+	Title     string    `db:"title"`
+	CreatedBy *string   `db:"created_by"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// DocumentFile is one version of a document in one language.
+type DocumentFile struct {
+	ID         string    `db:"id"`
+	DocumentID string    `db:"document_id"`
+	Language   string    `db:"language"`
+	Version    int       `db:"version"`
+	FileURL    string    `db:"file_url"`
+	FileName   string    `db:"file_name"`
+	FileSize   int64     `db:"file_size"`
+	UploadedBy *string   `db:"uploaded_by"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// DocumentFilters narrow a document listing. Published limits it to ACTIVE
+// products and LatestOnly to the latest version per language, as the public
+// endpoints show them.
+type DocumentFilters struct {
+	ProductID  string
+	Kind       string
+	Language   string
+	Published  bool
+	LatestOnly bool
+}
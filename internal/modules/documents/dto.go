@@ -0,0 +1,48 @@
+package documents
+
+import "time"
+
+// DocumentRequestDTO describes a document; Language is the language of the
+// file uploaded with it.
+type DocumentRequestDTO struct {
+	Kind     string `json:"kind" validate:"required,oneof=SDS DATASHEET MANUAL BROCHURE OTHER"`
+	Title    string `json:"title" validate:"required,max=255"`
+	Language string `json:"language" validate:"required,bcp47_language_tag"`
+}
+
+type DocumentUpdateDTO struct {
+	Kind  string `json:"kind" validate:"required,oneof=SDS DATASHEET MANUAL BROCHURE OTHER"`
+	Title string `json:"title" validate:"required,max=255"`
+}
+
+type DocumentResponse struct {
+	ID        string                 `json:"id"`
+	ProductID string                 `json:"product_id"`
+	Kind      string                 `json:"kind"`
+	Title     string                 `json:"title"`
+	Files     []DocumentFileResponse `json:"files"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
+}
+
+type DocumentFileResponse struct {
+	ID       string `json:"id"`
+	Language string `json:"language"`
+	Version  int    `json:"version"`
+	URL      string `json:"url"`
+	FileName string `json:"file_name"`
+	// FileSize is in bytes.
+	FileSize   int64     `json:"file_size"`
+	UploadedBy *string   `json:"uploaded_by,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type DocumentListResponse struct {
+	Documents []DocumentResponse `json:"documents"`
+}
+
+type GenericResponseDTO struct {
+	ID      *string `json:"id,omitempty"`
+	Status  string  `json:"success"`
+	Message string  `json:"message"`
+}
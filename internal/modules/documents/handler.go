@@ -0,0 +1,287 @@
+package documents
+
+import (
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+type RestHandler struct {
+	service   *DocumentService
+	validator *validator.Validate
+}
+
+func NewRestHandler(service *DocumentService, validator *validator.Validate) *RestHandler {
+	return &RestHandler{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// GetProductDocuments is the public document library of an ACTIVE product
+// with the latest version per language: ?[kind=<kind>][&language=<tag>].
+func (h *RestHandler) GetProductDocuments(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "id")
+
+	if productID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	h.getDocuments(w, r, DocumentFilters{ProductID: productID, Published: true, LatestOnly: true})
+}
+
+// GetDocuments lists the documents of any product with every version:
+// ?product_id=<id>[&kind=<kind>][&language=<tag>].
+func (h *RestHandler) GetDocuments(w http.ResponseWriter, r *http.Request) {
+	productID := r.URL.Query().Get("product_id")
+
+	if productID == "" {
+		http.Error(w, "product_id is required", http.StatusBadRequest)
+		return
+	}
+
+	h.getDocuments(w, r, DocumentFilters{ProductID: productID})
+}
+
+func (h *RestHandler) getDocuments(w http.ResponseWriter, r *http.Request, filters DocumentFilters) {
+	query := r.URL.Query()
+	filters.Kind = strings.ToUpper(query.Get("kind"))
+	filters.Language = query.Get("language")
+
+	if filters.Kind != "" && !slices.Contains(DocumentKinds, filters.Kind) {
+		http.Error(w, "Invalid kind, allowed: "+strings.Join(DocumentKinds, ","), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.GetProductDocuments(r.Context(), filters)
+	if err != nil {
+		http.Error(w, err.Error(), documentErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RestHandler) GetDocument(w http.ResponseWriter, r *http.Request) {
+	documentID := chi.URLParam(r, "id")
+
+	if documentID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.GetDocument(r.Context(), documentID)
+	if err != nil {
+		http.Error(w, err.Error(), documentErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// DownloadDocument redirects to the latest version of a document:
+// ?[language=<tag>], DefaultLanguage or the latest upload without one.
+func (h *RestHandler) DownloadDocument(w http.ResponseWriter, r *http.Request) {
+	documentID := chi.URLParam(r, "id")
+
+	if documentID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	url, err := h.service.DownloadURL(r.Context(), documentID, r.URL.Query().Get("language"))
+	if err != nil {
+		http.Error(w, err.Error(), documentErrorStatus(err))
+		return
+	}
+
+	http.Redirect(w, r, url, http.StatusFound)
+}
+
+// CreateDocument takes a multipart form with the document file as "file" and
+// the kind, title and language fields of DocumentRequestDTO.
+func (h *RestHandler) CreateDocument(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(shared.UserClaimsKey).(*shared.UserClaims)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	productID := chi.URLParam(r, "id")
+
+	if productID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	file, header, ok := documentUpload(w, r)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	request := DocumentRequestDTO{
+		Kind:     strings.ToUpper(r.PostFormValue("kind")),
+		Title:    strings.TrimSpace(r.PostFormValue("title")),
+		Language: r.PostFormValue("language"),
+	}
+
+	if err := h.validator.Struct(request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.CreateDocument(r.Context(), claims.UserID, productID, request, file, header)
+	if err != nil {
+		http.Error(w, err.Error(), documentErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// AddDocumentVersion takes a multipart form with the new file as "file" and
+// its "language".
+func (h *RestHandler) AddDocumentVersion(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(shared.UserClaimsKey).(*shared.UserClaims)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	documentID := chi.URLParam(r, "id")
+
+	if documentID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	file, header, ok := documentUpload(w, r)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	language := r.PostFormValue("language")
+	if err := h.validator.Var(language, "required,bcp47_language_tag"); err != nil {
+		http.Error(w, "language must be a BCP 47 language tag", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.AddDocumentVersion(r.Context(), claims.UserID, documentID, language, file, header)
+	if err != nil {
+		http.Error(w, err.Error(), documentErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// documentUpload parses the multipart form of an upload and returns its PDF
+// "file". It writes the error response and returns false when there is none.
+func documentUpload(w http.ResponseWriter, r *http.Request) (multipart.File, *multipart.FileHeader, bool) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxDocumentSize+1<<20)
+	if err := r.ParseMultipartForm(maxDocumentSize); err != nil {
+		http.Error(w, "Multipart parse error: "+err.Error(), http.StatusBadRequest)
+		return nil, nil, false
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "file is required", http.StatusBadRequest)
+		return nil, nil, false
+	}
+
+	contentType, err := shared.DetectContentType(file)
+	if err != nil || contentType != "application/pdf" {
+		file.Close()
+		http.Error(w, "file must be a PDF", http.StatusBadRequest)
+		return nil, nil, false
+	}
+
+	return file, header, true
+}
+
+func (h *RestHandler) UpdateDocument(w http.ResponseWriter, r *http.Request) {
+	documentID := chi.URLParam(r, "id")
+
+	if documentID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var request DocumentUpdateDTO
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.validator.Struct(request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.UpdateDocument(r.Context(), documentID, request)
+	if err != nil {
+		http.Error(w, err.Error(), documentErrorStatus(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RestHandler) DeleteDocument(w http.ResponseWriter, r *http.Request) {
+	documentID := chi.URLParam(r, "id")
+
+	if documentID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.DeleteDocument(r.Context(), documentID)
+	if err != nil {
+		http.Error(w, err.Error(), documentErrorStatus(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func documentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrDocumentNotFound), errors.Is(err, ErrProductNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrNotProductSeller):
+		return http.StatusForbidden
+	case errors.Is(err, shared.ErrForeignKeyViolation):
+		return http.StatusBadRequest
+	case errors.Is(err, shared.ErrUniqueViolation):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
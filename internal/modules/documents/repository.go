@@ -0,0 +1,267 @@
+package documents
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+type DocumentRepo struct {
+	db *sqlx.DB
+}
+
+func NewDocumentRepo(db *sqlx.DB) *DocumentRepo {
+	return &DocumentRepo{
+		db: db,
+	}
+}
+
+const documentColumns = "d.id, d.product_id, d.kind, d.title, d.created_by, d.created_at, d.updated_at"
+
+const fileColumns = "f.id, f.document_id, f.language, f.version, f.file_url, f.file_name, f.file_size, f.uploaded_by, f.created_at"
+
+// GetProductDocuments returns the documents of a product and their files,
+// ordered by language and newest version first.
+func (r *DocumentRepo) GetProductDocuments(ctx context.Context, filters DocumentFilters) ([]Document, []DocumentFile, error) {
+	var exists bool
+	query := "SELECT EXISTS (SELECT 1 FROM products WHERE id = $1 AND ($2 = FALSE OR status = 'ACTIVE'))"
+	if err := r.db.GetContext(ctx, &exists, query, filters.ProductID, filters.Published); err != nil {
+		return nil, nil, shared.PostgresError(err)
+	}
+	if !exists {
+		return nil, nil, ErrProductNotFound
+	}
+
+	query = "SELECT " + documentColumns + " FROM product_documents d WHERE d.product_id = ?"
+	args := []interface{}{filters.ProductID}
+
+	if filters.Kind != "" {
+		query += " AND d.kind = ?"
+		args = append(args, filters.Kind)
+	}
+
+	if filters.Language != "" {
+		query += " AND EXISTS (SELECT 1 FROM product_document_files f WHERE f.document_id = d.id AND f.language = ?)"
+		args = append(args, filters.Language)
+	}
+
+	query += " ORDER BY d.kind, d.title, d.id"
+
+	var documents []Document
+	if err := r.db.SelectContext(ctx, &documents, r.db.Rebind(query), args...); err != nil {
+		return nil, nil, shared.PostgresError(err)
+	}
+
+	query = "SELECT " + fileColumns + " FROM product_document_files f JOIN product_documents d ON d.id = f.document_id WHERE d.product_id = ?"
+	if filters.LatestOnly {
+		query = "SELECT DISTINCT ON (f.document_id, f.language) " + fileColumns + " FROM product_document_files f JOIN product_documents d ON d.id = f.document_id WHERE d.product_id = ?"
+	}
+	args = []interface{}{filters.ProductID}
+
+	if filters.Kind != "" {
+		query += " AND d.kind = ?"
+		args = append(args, filters.Kind)
+	}
+
+	if filters.Language != "" {
+		query += " AND f.language = ?"
+		args = append(args, filters.Language)
+	}
+
+	query += " ORDER BY f.document_id, f.language, f.version DESC"
+
+	var files []DocumentFile
+	if err := r.db.SelectContext(ctx, &files, r.db.Rebind(query), args...); err != nil {
+		return nil, nil, shared.PostgresError(err)
+	}
+
+	return documents, files, nil
+}
+
+// GetDocument returns a document with every version of its files.
+func (r *DocumentRepo) GetDocument(ctx context.Context, id string) (*Document, []DocumentFile, error) {
+	var document Document
+	if err := r.db.GetContext(ctx, &document, "SELECT "+documentColumns+" FROM product_documents d WHERE d.id = $1", id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, ErrDocumentNotFound
+		}
+		return nil, nil, shared.PostgresError(err)
+	}
+
+	var files []DocumentFile
+	query := "SELECT " + fileColumns + " FROM product_document_files f WHERE f.document_id = $1 ORDER BY f.language, f.version DESC"
+	if err := r.db.SelectContext(ctx, &files, query, id); err != nil {
+		return nil, nil, shared.PostgresError(err)
+	}
+
+	return &document, files, nil
+}
+
+// GetLatestFile returns the latest version of a document of an ACTIVE
+// product in the language, or without one in DefaultLanguage, falling back
+// to the latest version in any language.
+func (r *DocumentRepo) GetLatestFile(ctx context.Context, documentID string, language string) (*DocumentFile, error) {
+	query := `SELECT ` + fileColumns + ` FROM product_document_files f
+		JOIN product_documents d ON d.id = f.document_id
+		JOIN products p ON p.id = d.product_id AND p.status = 'ACTIVE'
+		WHERE f.document_id = $1 AND ($2 = '' OR f.language = $2)
+		ORDER BY (f.language = $3) DESC, f.version DESC, f.created_at DESC LIMIT 1`
+
+	var file DocumentFile
+	if err := r.db.GetContext(ctx, &file, query, documentID, language, DefaultLanguage); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			if language != "" {
+				return nil, fmt.Errorf("%w: no %s version", ErrDocumentNotFound, language)
+			}
+			return nil, ErrDocumentNotFound
+		}
+		return nil, shared.PostgresError(err)
+	}
+	return &file, nil
+}
+
+// CheckProductSeller checks that the product exists and that the user making
+// the request may change its documents, see shared.CanManageProduct.
+func (r *DocumentRepo) CheckProductSeller(ctx context.Context, productID string) error {
+	return checkProductSeller(ctx, r.db, productID)
+}
+
+// CheckDocumentSeller is CheckProductSeller for the product of a document.
+func (r *DocumentRepo) CheckDocumentSeller(ctx context.Context, documentID string) error {
+	return checkDocumentSeller(ctx, r.db, documentID)
+}
+
+func checkProductSeller(ctx context.Context, q sqlx.QueryerContext, productID string) error {
+	var sellerID string
+	if err := sqlx.GetContext(ctx, q, &sellerID, "SELECT seller_id FROM products WHERE id = $1", productID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrProductNotFound
+		}
+		return shared.PostgresError(err)
+	}
+	if !shared.CanManageProduct(ctx, sellerID) {
+		return ErrNotProductSeller
+	}
+	return nil
+}
+
+func checkDocumentSeller(ctx context.Context, q sqlx.QueryerContext, documentID string) error {
+	var sellerID string
+	query := "SELECT p.seller_id FROM product_documents d JOIN products p ON p.id = d.product_id WHERE d.id = $1"
+	if err := sqlx.GetContext(ctx, q, &sellerID, query, documentID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrDocumentNotFound
+		}
+		return shared.PostgresError(err)
+	}
+	if !shared.CanManageProduct(ctx, sellerID) {
+		return ErrNotProductSeller
+	}
+	return nil
+}
+
+// CreateDocument saves a document with its first file as version 1.
+func (r *DocumentRepo) CreateDocument(ctx context.Context, productID string, request DocumentRequestDTO, file DocumentFile) (string, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return "", shared.PostgresError(err)
+	}
+
+	defer tx.Rollback()
+
+	if err := checkProductSeller(ctx, tx, productID); err != nil {
+		return "", err
+	}
+
+	var id string
+	query := "INSERT INTO product_documents (product_id, kind, title, created_by) VALUES ($1, $2, $3, $4) RETURNING id"
+	if err := tx.GetContext(ctx, &id, query, productID, request.Kind, request.Title, file.UploadedBy); err != nil {
+		return "", shared.PostgresError(err)
+	}
+
+	query = `INSERT INTO product_document_files (document_id, language, version, file_url, file_name, file_size, uploaded_by)
+		VALUES ($1, $2, 1, $3, $4, $5, $6)`
+	if _, err := tx.ExecContext(ctx, query, id, file.Language, file.FileURL, file.FileName, file.FileSize, file.UploadedBy); err != nil {
+		return "", shared.PostgresError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", shared.PostgresError(err)
+	}
+	return id, nil
+}
+
+// AddDocumentVersion saves a file as the next version of the document in its
+// language and returns the version number.
+func (r *DocumentRepo) AddDocumentVersion(ctx context.Context, documentID string, file DocumentFile) (int, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, shared.PostgresError(err)
+	}
+
+	defer tx.Rollback()
+
+	// Locking the document numbers concurrent uploads one after the other
+	var id string
+	if err := tx.GetContext(ctx, &id, "SELECT id FROM product_documents WHERE id = $1 FOR UPDATE", documentID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrDocumentNotFound
+		}
+		return 0, shared.PostgresError(err)
+	}
+
+	if err := checkDocumentSeller(ctx, tx, documentID); err != nil {
+		return 0, err
+	}
+
+	var version int
+	query := `INSERT INTO product_document_files (document_id, language, version, file_url, file_name, file_size, uploaded_by)
+		SELECT $1, $2, COALESCE(MAX(version), 0) + 1, $3, $4, $5, $6 FROM product_document_files WHERE document_id = $1 AND language = $2
+		RETURNING version`
+	if err := tx.GetContext(ctx, &version, query, documentID, file.Language, file.FileURL, file.FileName, file.FileSize, file.UploadedBy); err != nil {
+		return 0, shared.PostgresError(err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE product_documents SET updated_at = CURRENT_TIMESTAMP WHERE id = $1", documentID); err != nil {
+		return 0, shared.PostgresError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, shared.PostgresError(err)
+	}
+	return version, nil
+}
+
+func (r *DocumentRepo) UpdateDocument(ctx context.Context, id string, request DocumentUpdateDTO) error {
+	if err := checkDocumentSeller(ctx, r.db, id); err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, "UPDATE product_documents SET kind = $1, title = $2 WHERE id = $3", request.Kind, request.Title, id)
+	if err != nil {
+		return shared.PostgresError(err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrDocumentNotFound
+	}
+	return nil
+}
+
+func (r *DocumentRepo) DeleteDocument(ctx context.Context, id string) error {
+	if err := checkDocumentSeller(ctx, r.db, id); err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, "DELETE FROM product_documents WHERE id = $1", id)
+	if err != nil {
+		return shared.PostgresError(err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrDocumentNotFound
+	}
+	return nil
+}
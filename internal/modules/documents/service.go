@@ -0,0 +1,151 @@
+package documents
+
+import (
+	"context"
+	"fmt"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+
+	"github.com/smart-safety-hub/backend/internal/modules/aws"
+	"go.uber.org/zap"
+)
+
+type DocumentService struct {
+	logger   *zap.Logger
+	repo     *DocumentRepo
+	uploader *aws.UploadService
+}
+
+func NewDocumentService(logger *zap.Logger, repo *DocumentRepo, uploader *aws.UploadService) *DocumentService {
+	return &DocumentService{
+		logger:   logger,
+		repo:     repo,
+		uploader: uploader,
+	}
+}
+
+func (b *DocumentService) GetProductDocuments(ctx context.Context, filters DocumentFilters) (*DocumentListResponse, error) {
+	filters.Language = normalizeLanguage(filters.Language)
+
+	documents, files, err := b.repo.GetProductDocuments(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	return &DocumentListResponse{
+		Documents: toDocumentResponses(documents, files, filters.Published),
+	}, nil
+}
+
+func (b *DocumentService) GetDocument(ctx context.Context, id string) (*DocumentResponse, error) {
+	document, files, err := b.repo.GetDocument(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	response := toDocumentResponses([]Document{*document}, files, false)[0]
+	return &response, nil
+}
+
+// DownloadURL returns the URL of the latest version of a document in the
+// language, see DocumentRepo.GetLatestFile.
+func (b *DocumentService) DownloadURL(ctx context.Context, id string, language string) (string, error) {
+	file, err := b.repo.GetLatestFile(ctx, id, normalizeLanguage(language))
+	if err != nil {
+		return "", fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+	return file.FileURL, nil
+}
+
+// CreateDocument uploads the first file of a document and saves the document
+// with it as version 1.
+func (b *DocumentService) CreateDocument(ctx context.Context, userID string, productID string, request DocumentRequestDTO, file multipart.File, header *multipart.FileHeader) (*GenericResponseDTO, error) {
+	// The product is checked before the file is uploaded
+	if err := b.repo.CheckProductSeller(ctx, productID); err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	documentFile, err := b.upload(ctx, userID, request.Language, file, header)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := b.repo.CreateDocument(ctx, productID, request, *documentFile)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return &GenericResponseDTO{
+		ID:      &id,
+		Status:  "success",
+		Message: "Document Created Successfully",
+	}, nil
+}
+
+// AddDocumentVersion uploads a file as the next version of a document in the
+// language.
+func (b *DocumentService) AddDocumentVersion(ctx context.Context, userID string, documentID string, language string, file multipart.File, header *multipart.FileHeader) (*GenericResponseDTO, error) {
+	if err := b.repo.CheckDocumentSeller(ctx, documentID); err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	documentFile, err := b.upload(ctx, userID, language, file, header)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := b.repo.AddDocumentVersion(ctx, documentID, *documentFile)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return &GenericResponseDTO{
+		ID:      &documentID,
+		Status:  "success",
+		Message: fmt.Sprintf("Document Version %d Uploaded Successfully", version),
+	}, nil
+}
+
+func (b *DocumentService) upload(ctx context.Context, userID string, language string, file multipart.File, header *multipart.FileHeader) (*DocumentFile, error) {
+	upload, err := b.uploader.UploadImage(ctx, file, header, documentBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	fileName := filepath.Base(header.Filename)
+	if len(fileName) > 255 {
+		fileName = strings.ToValidUTF8(fileName[len(fileName)-255:], "")
+	}
+
+	return &DocumentFile{
+		Language:   normalizeLanguage(language),
+		FileURL:    upload.URL,
+		FileName:   fileName,
+		FileSize:   header.Size,
+		UploadedBy: &userID,
+	}, nil
+}
+
+func (b *DocumentService) UpdateDocument(ctx context.Context, id string, request DocumentUpdateDTO) (*GenericResponseDTO, error) {
+	if err := b.repo.UpdateDocument(ctx, id, request); err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return &GenericResponseDTO{
+		ID:      &id,
+		Status:  "success",
+		Message: "Document Updated Successfully",
+	}, nil
+}
+
+func (b *DocumentService) DeleteDocument(ctx context.Context, id string) (*GenericResponseDTO, error) {
+	if err := b.repo.DeleteDocument(ctx, id); err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return &GenericResponseDTO{
+		Status:  "success",
+		Message: "Document Deleted Successfully",
+	}, nil
+}
@@ -0,0 +1,95 @@
+package pricing
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+var (
+	ErrVariantNotFound   = errors.New("variant not found")
+	ErrInvalidTiers      = errors.New("invalid price tiers")
+	ErrPriceListNotFound = errors.New("price list not found")
+	ErrInvalidPriceList  = errors.New("invalid price list")
+	ErrInvalidRates      = errors.New("invalid exchange rates")
+	ErrNotProductSeller  = errors.New("only the seller of the product can change its price tiers")
+)
+
+// activeStatus is the product status whose variants are priced publicly.
+const activeStatus = "ACTIVE"
+
+type Variant struct {
+	ID            string         `db:"id"`
+	ProductID     string         `db:"product_id"`
+	SKU           string         `db:"sku"`
+	Price         shared.Decimal `db:"price"`
+	Currency      string         `db:"currency"`
+	IsActive      bool           `db:"is_active"`
+	SellerID      string         `db:"seller_id"`
+	ProductStatus string         `db:"product_status"`
+}
+
+type PriceTier struct {
+	ID          string         `db:"id"`
+	VariantID   string         `db:"variant_id"`
+	MinQuantity int            `db:"min_quantity"`
+	MaxQuantity *int           `db:"max_quantity"`
+	UnitPrice   shared.Decimal `db:"unit_price"`
+	CreatedAt   time.Time      `db:"created_at"`
+	UpdatedAt   time.Time      `db:"updated_at"`
+}
+
+type PriceList struct {
+	ID        string     `db:"id"`
+	Name      string     `db:"name"`
+	Currency  string     `db:"currency"`
+	ValidFrom time.Time  `db:"valid_from"`
+	ValidTo   *time.Time `db:"valid_to"`
+	IsActive  bool       `db:"is_active"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
+}
+
+type PriceListItem struct {
+	VariantID string         `db:"variant_id"`
+	SKU       string         `db:"sku"`
+	Price     shared.Decimal `db:"price"`
+}
+
+type PriceListDiscount struct {
+	ID              string         `db:"id"`
+	CategoryID      *string        `db:"category_id"`
+	BrandID         *string        `db:"brand_id"`
+	DiscountPercent shared.Decimal `db:"discount_percent"`
+}
+
+// PriceListDetail is a price list with its prices, discounts and companies.
+type PriceListDetail struct {
+	PriceList
+	Items     []PriceListItem
+	Discounts []PriceListDiscount
+	Companies []string
+}
+
+// AppliedPriceList is the price list a company is priced with.
+type AppliedPriceList struct {
+	ID       string `db:"id" json:"id"`
+	Name     string `db:"name" json:"name"`
+	Currency string `db:"currency" json:"currency"`
+}
+
+// ExchangeRate is a currency with the rate in force against the store
+// currency. Rate is kept as the decimal text Postgres returns, since rates
+// have more places than a Decimal; it is nil when no rate is set yet.
+type ExchangeRate struct {
+	Currency          string         `db:"code"`
+	Name              string         `db:"name"`
+	RoundingIncrement shared.Decimal `db:"rounding_increment"`
+	RoundingMode      string         `db:"rounding_mode"`
+	IsBase            bool           `db:"is_base"`
+	Rate              *json.Number   `db:"rate"`
+	EffectiveAt       *time.Time     `db:"effective_at"`
+	Source            *string        `db:"source"`
+}
@@ -0,0 +1,116 @@
+package pricing
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+type PriceTierDTO struct {
+	MinQuantity int            `json:"min_quantity" validate:"required,gte=1"`
+	MaxQuantity *int           `json:"max_quantity" validate:"omitempty,gtefield=MinQuantity"`
+	UnitPrice   shared.Decimal `json:"unit_price" validate:"gte=0"`
+}
+
+type PriceTiersRequestDTO struct {
+	Tiers []PriceTierDTO `json:"tiers" validate:"dive"`
+}
+
+type PriceTiersResponse struct {
+	VariantID string         `json:"variant_id"`
+	SKU       string         `json:"sku"`
+	BasePrice shared.Decimal `json:"base_price"`
+	Currency  string         `json:"currency"`
+	Tiers     []PriceTierDTO `json:"tiers"`
+}
+
+// QuoteResponse is the price of Quantity units of a variant, in Currency.
+// Tier is the tier that applied, or null when the base price did. PriceList
+// is the contract price list of the buyer's company, when one applied. Tax is
+// the GST on ExtendedPrice, omitted for products without a tax class.
+type QuoteResponse struct {
+	VariantID     string            `json:"variant_id"`
+	SKU           string            `json:"sku"`
+	Quantity      int               `json:"quantity"`
+	Unit          string            `json:"unit"`
+	BaseQuantity  int               `json:"base_quantity"`
+	Currency      string            `json:"currency"`
+	BasePrice     shared.Decimal    `json:"base_price"`
+	UnitPrice     shared.Decimal    `json:"unit_price"`
+	ExtendedPrice shared.Decimal    `json:"extended_price"`
+	Savings       shared.Decimal    `json:"savings"`
+	Tier          *PriceTierDTO     `json:"tier"`
+	PriceList     *AppliedPriceList `json:"price_list,omitempty"`
+	Tax           *shared.Tax       `json:"tax,omitempty"`
+}
+
+// PriceListRequestDTO creates or replaces a price list. Items are fixed
+// contract prices per variant, in Currency; Discounts take a percentage off
+// the list price of a category (and its subcategories) or a brand.
+type PriceListRequestDTO struct {
+	Name      string                 `json:"name" validate:"required,max=100"`
+	Currency  string                 `json:"currency" validate:"required,len=3,uppercase"`
+	ValidFrom *time.Time             `json:"valid_from"`
+	ValidTo   *time.Time             `json:"valid_to"`
+	IsActive  *bool                  `json:"is_active"`
+	Items     []PriceListItemDTO     `json:"items" validate:"dive"`
+	Discounts []PriceListDiscountDTO `json:"discounts" validate:"dive"`
+	Companies []string               `json:"companies" validate:"dive,uuid"`
+}
+
+type PriceListItemDTO struct {
+	VariantID string         `json:"variant_id" validate:"required,uuid"`
+	SKU       string         `json:"sku,omitempty"`
+	Price     shared.Decimal `json:"price" validate:"gte=0"`
+}
+
+// PriceListDiscountDTO targets exactly one of CategoryID and BrandID.
+type PriceListDiscountDTO struct {
+	CategoryID      *string        `json:"category_id,omitempty" validate:"omitempty,uuid"`
+	BrandID         *string        `json:"brand_id,omitempty" validate:"omitempty,uuid"`
+	DiscountPercent shared.Decimal `json:"discount_percent"`
+}
+
+type PriceListResponse struct {
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name"`
+	Currency  string                 `json:"currency"`
+	ValidFrom time.Time              `json:"valid_from"`
+	ValidTo   *time.Time             `json:"valid_to"`
+	IsActive  bool                   `json:"is_active"`
+	Items     []PriceListItemDTO     `json:"items,omitempty"`
+	Discounts []PriceListDiscountDTO `json:"discounts,omitempty"`
+	Companies []string               `json:"companies,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
+}
+
+// ExchangeRateDTO sets the rate of a currency: how many units of it one unit
+// of the store currency buys, from EffectiveAt (now when omitted).
+type ExchangeRateDTO struct {
+	Currency    string      `json:"currency" validate:"required,len=3,uppercase"`
+	Rate        json.Number `json:"rate" validate:"required"`
+	EffectiveAt *time.Time  `json:"effective_at"`
+}
+
+type ExchangeRatesRequestDTO struct {
+	Rates []ExchangeRateDTO `json:"rates" validate:"required,min=1,dive"`
+}
+
+type ExchangeRateResponse struct {
+	Currency          string         `json:"currency"`
+	Name              string         `json:"name"`
+	IsBase            bool           `json:"is_base"`
+	RoundingIncrement shared.Decimal `json:"rounding_increment"`
+	RoundingMode      string         `json:"rounding_mode"`
+	Rate              *json.Number   `json:"rate"`
+	EffectiveAt       *time.Time     `json:"effective_at"`
+	Source            *string        `json:"source"`
+}
+
+type GenericResponseDTO struct {
+	ID      *string `json:"id,omitempty"`
+	Status  string  `json:"success"`
+	Message string  `json:"message"`
+}
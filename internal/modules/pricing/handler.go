@@ -0,0 +1,313 @@
+package pricing
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+type RestHandler struct {
+	service   *PricingService
+	validator *validator.Validate
+}
+
+func NewRestHandler(service *PricingService, validator *validator.Validate) *RestHandler {
+	return &RestHandler{
+		service:   service,
+		validator: validator,
+	}
+}
+
+func (h *RestHandler) GetPriceTiers(w http.ResponseWriter, r *http.Request) {
+	variantID := chi.URLParam(r, "id")
+
+	if variantID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.GetPriceTiers(r.Context(), variantID)
+	if err != nil {
+		http.Error(w, err.Error(), pricingErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// SavePriceTiers replaces the price tiers of a variant; an empty list removes
+// them. Sellers may only change the tiers of their own products; catalog
+// reviewers may change any.
+func (h *RestHandler) SavePriceTiers(w http.ResponseWriter, r *http.Request) {
+	variantID := chi.URLParam(r, "id")
+
+	if variantID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var request PriceTiersRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.validator.Struct(request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.SavePriceTiers(r.Context(), variantID, request)
+	if err != nil {
+		http.Error(w, err.Error(), pricingErrorStatus(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// Quote prices a quantity of a variant:
+// ?variant=<id or sku>&quantity=<n>[&unit=<pack unit>][&currency=<code>][&ship_to=<state code>].
+func (h *RestHandler) Quote(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	variantID := query.Get("variant")
+
+	if variantID == "" {
+		http.Error(w, "variant is required", http.StatusBadRequest)
+		return
+	}
+
+	quantity, err := strconv.Atoi(query.Get("quantity"))
+	if err != nil {
+		http.Error(w, "quantity must be a number", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.Quote(r.Context(), variantID, quantity, query.Get("unit"), query.Get("currency"), query.Get("ship_to"))
+	if err != nil {
+		http.Error(w, err.Error(), pricingErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RestHandler) CreatePriceList(w http.ResponseWriter, r *http.Request) {
+	var request PriceListRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.validator.Struct(request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.CreatePriceList(r.Context(), request)
+	if err != nil {
+		http.Error(w, err.Error(), pricingErrorStatus(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// UpdatePriceList replaces a price list, including its prices, discounts and
+// companies.
+func (h *RestHandler) UpdatePriceList(w http.ResponseWriter, r *http.Request) {
+	priceListID := chi.URLParam(r, "id")
+
+	if priceListID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var request PriceListRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.validator.Struct(request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.UpdatePriceList(r.Context(), priceListID, request)
+	if err != nil {
+		http.Error(w, err.Error(), pricingErrorStatus(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RestHandler) GetPriceLists(w http.ResponseWriter, r *http.Request) {
+	response, err := h.service.GetPriceLists(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), pricingErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RestHandler) GetPriceList(w http.ResponseWriter, r *http.Request) {
+	priceListID := chi.URLParam(r, "id")
+
+	if priceListID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.GetPriceList(r.Context(), priceListID)
+	if err != nil {
+		http.Error(w, err.Error(), pricingErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RestHandler) DeletePriceList(w http.ResponseWriter, r *http.Request) {
+	priceListID := chi.URLParam(r, "id")
+
+	if priceListID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.DeletePriceList(r.Context(), priceListID)
+	if err != nil {
+		http.Error(w, err.Error(), pricingErrorStatus(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// maxRatesFileSize bounds exchange-rate file uploads.
+const maxRatesFileSize = 1 << 20
+
+// GetExchangeRates lists the currencies with the rate in force for each.
+func (h *RestHandler) GetExchangeRates(w http.ResponseWriter, r *http.Request) {
+	response, err := h.service.GetExchangeRates(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), pricingErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RestHandler) SaveExchangeRates(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(shared.UserClaimsKey).(*shared.UserClaims)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request ExchangeRatesRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.validator.Struct(request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.SaveExchangeRates(r.Context(), claims.UserID, RateSourceManual, request)
+	if err != nil {
+		http.Error(w, err.Error(), pricingErrorStatus(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// ImportExchangeRates loads rates from a CSV file, sent as the "file" field of
+// a multipart form or as the request body.
+func (h *RestHandler) ImportExchangeRates(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(shared.UserClaimsKey).(*shared.UserClaims)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRatesFileSize)
+	body := io.Reader(r.Body)
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, "file is required", http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		body = file
+	}
+
+	response, err := h.service.ImportExchangeRates(r.Context(), claims.UserID, body)
+	if err != nil {
+		status := pricingErrorStatus(err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func pricingErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrVariantNotFound), errors.Is(err, ErrPriceListNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrNotProductSeller):
+		return http.StatusForbidden
+	case errors.Is(err, ErrInvalidTiers), errors.Is(err, shared.ErrInvalidQuantity), errors.Is(err, ErrInvalidPriceList),
+		errors.Is(err, ErrInvalidRates), errors.Is(err, shared.ErrUnsupportedCurrency), errors.Is(err, shared.ErrInvalidStateCode), errors.Is(err, shared.ErrForeignKeyViolation):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,51 @@
+package pricing
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+// validatePriceList checks what the struct tags cannot: the validity window,
+// that every discount targets exactly one category or brand with a percentage
+// above 0 and below 100, and that no variant or target is listed twice.
+func validatePriceList(request PriceListRequestDTO, now time.Time) error {
+	validFrom := now
+	if request.ValidFrom != nil {
+		validFrom = *request.ValidFrom
+	}
+	if request.ValidTo != nil && !request.ValidTo.After(validFrom) {
+		return fmt.Errorf("%w: valid_to must be after valid_from", ErrInvalidPriceList)
+	}
+
+	variants := make(map[string]bool, len(request.Items))
+	for _, item := range request.Items {
+		if variants[item.VariantID] {
+			return fmt.Errorf("%w: variant %s is priced more than once", ErrInvalidPriceList, item.VariantID)
+		}
+		variants[item.VariantID] = true
+	}
+
+	targets := make(map[string]bool, len(request.Discounts))
+	for _, discount := range request.Discounts {
+		var target string
+		switch {
+		case discount.CategoryID != nil && discount.BrandID == nil:
+			target = "category " + *discount.CategoryID
+		case discount.BrandID != nil && discount.CategoryID == nil:
+			target = "brand " + *discount.BrandID
+		default:
+			return fmt.Errorf("%w: a discount needs exactly one of category_id and brand_id", ErrInvalidPriceList)
+		}
+		if discount.DiscountPercent <= 0 || discount.DiscountPercent >= shared.NewDecimal(100) {
+			return fmt.Errorf("%w: discount_percent of %s must be above 0 and below 100", ErrInvalidPriceList, target)
+		}
+		if targets[target] {
+			return fmt.Errorf("%w: %s is discounted more than once", ErrInvalidPriceList, target)
+		}
+		targets[target] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package pricing
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sources recorded with exchange rates.
+const (
+	RateSourceManual = "manual"
+	RateSourceFile   = "file"
+)
+
+// parseRatesCSV reads exchange rates from a CSV file with a header row naming
+// the currency and rate columns and, optionally, effective_at (RFC 3339 or
+// YYYY-MM-DD). Other columns are ignored.
+func parseRatesCSV(r io.Reader) ([]ExchangeRateDTO, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: the file is empty", ErrInvalidRates)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRates, err)
+	}
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(header[i]))
+	}
+
+	currencyColumn := slices.Index(header, "currency")
+	rateColumn := slices.Index(header, "rate")
+	effectiveColumn := slices.Index(header, "effective_at")
+	if currencyColumn < 0 || rateColumn < 0 {
+		return nil, fmt.Errorf("%w: the header needs currency and rate columns", ErrInvalidRates)
+	}
+
+	var rates []ExchangeRateDTO
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidRates, err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		field := func(column int) string {
+			if column < 0 || column >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[column])
+		}
+
+		rate := ExchangeRateDTO{
+			Currency: strings.ToUpper(field(currencyColumn)),
+			Rate:     json.Number(field(rateColumn)),
+		}
+		if rate.Currency == "" && rate.Rate == "" {
+			continue
+		}
+
+		if value := field(effectiveColumn); value != "" {
+			effectiveAt, err := parseEffectiveAt(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: effective_at %q must be RFC 3339 or YYYY-MM-DD", ErrInvalidRates, line, value)
+			}
+			rate.EffectiveAt = &effectiveAt
+		}
+
+		if len(rate.Currency) != 3 {
+			return nil, fmt.Errorf("%w: line %d: currency %q must be a three-letter code", ErrInvalidRates, line, rate.Currency)
+		}
+		rates = append(rates, rate)
+	}
+
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("%w: the file has no rates", ErrInvalidRates)
+	}
+	return rates, nil
+}
+
+func parseEffectiveAt(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
+
+// rateDigits and rateScale are the precision and scale of exchange_rates.rate.
+const (
+	rateDigits = 20
+	rateScale  = 10
+)
+
+// validateRates checks that every rate is a positive number that fits
+// exchange_rates.rate without rounding, that the store currency, whose rate is
+// always 1, is not given one, and that no currency has two rates for the same
+// moment.
+func validateRates(rates []ExchangeRateDTO, storeCurrency string, now time.Time) error {
+	seen := make(map[string]bool, len(rates))
+	for _, rate := range rates {
+		if rate.Currency == storeCurrency {
+			return fmt.Errorf("%w: %s is the store currency", ErrInvalidRates, rate.Currency)
+		}
+
+		// Plain decimals only: Postgres NUMERIC takes neither hex floats
+		// nor Inf and NaN.
+		value, err := strconv.ParseFloat(rate.Rate.String(), 64)
+		if err != nil || value <= 0 || strings.Trim(rate.Rate.String(), "0123456789.") != "" {
+			return fmt.Errorf("%w: rate %q of %s must be a positive number", ErrInvalidRates, rate.Rate, rate.Currency)
+		}
+
+		// Rates are stored as DECIMAL(20,10); more digits would be rounded,
+		// a tiny rate down to zero, or overflow
+		whole, fraction, _ := strings.Cut(rate.Rate.String(), ".")
+		if len(strings.TrimRight(fraction, "0")) > rateScale {
+			return fmt.Errorf("%w: rate %q of %s has more than %d decimal places", ErrInvalidRates, rate.Rate, rate.Currency, rateScale)
+		}
+		if len(strings.TrimLeft(whole, "0")) > rateDigits-rateScale {
+			return fmt.Errorf("%w: rate %q of %s has more than %d digits before the decimal point", ErrInvalidRates, rate.Rate, rate.Currency, rateDigits-rateScale)
+		}
+
+		effectiveAt := now
+		if rate.EffectiveAt != nil {
+			effectiveAt = *rate.EffectiveAt
+		}
+		key := rate.Currency + "@" + effectiveAt.UTC().Format(time.RFC3339Nano)
+		if seen[key] {
+			return fmt.Errorf("%w: %s has more than one rate for %s", ErrInvalidRates, rate.Currency, effectiveAt.Format(time.RFC3339))
+		}
+		seen[key] = true
+	}
+	return nil
+}
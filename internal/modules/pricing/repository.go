@@ -0,0 +1,337 @@
+package pricing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/smart-safety-hub/backend/shared"
+	"golang.org/x/sync/errgroup"
+)
+
+type PricingRepo struct {
+	db *sqlx.DB
+}
+
+func NewPricingRepo(db *sqlx.DB) *PricingRepo {
+	return &PricingRepo{
+		db: db,
+	}
+}
+
+// GetVariant looks a variant up by id or SKU, with the seller and status of
+// its product.
+func (r *PricingRepo) GetVariant(ctx context.Context, idOrSKU string) (*Variant, error) {
+	var variant Variant
+	query := `SELECT v.id, v.product_id, v.sku, v.price, v.currency, v.is_active, p.seller_id, p.status AS product_status
+	FROM product_variants v JOIN products p ON p.id = v.product_id WHERE v.id::text=$1 OR v.sku=$1`
+	if err := r.db.GetContext(ctx, &variant, query, idOrSKU); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrVariantNotFound
+		}
+		return nil, shared.PostgresError(err)
+	}
+	return &variant, nil
+}
+
+func (r *PricingRepo) GetPriceTiers(ctx context.Context, variantID string) ([]PriceTier, error) {
+	var tiers []PriceTier
+	query := "SELECT * FROM variant_price_tiers WHERE variant_id=$1 ORDER BY min_quantity"
+	if err := r.db.SelectContext(ctx, &tiers, query, variantID); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+	return tiers, nil
+}
+
+// ReplacePriceTiers swaps the tiers of a variant for the given, already
+// validated, tiers. Only the seller of the product and catalog reviewers may
+// do so, see shared.CanManageProduct.
+func (r *PricingRepo) ReplacePriceTiers(ctx context.Context, variantID string, tiers []PriceTierDTO) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return shared.PostgresError(err)
+	}
+
+	defer tx.Rollback()
+
+	var owner string
+	query := "SELECT p.seller_id FROM product_variants v JOIN products p ON p.id = v.product_id WHERE v.id=$1 FOR UPDATE OF v"
+	if err := tx.GetContext(ctx, &owner, query, variantID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrVariantNotFound
+		}
+		return shared.PostgresError(err)
+	}
+	if !shared.CanManageProduct(ctx, owner) {
+		return ErrNotProductSeller
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM variant_price_tiers WHERE variant_id=$1", variantID); err != nil {
+		return shared.PostgresError(err)
+	}
+
+	query = "INSERT INTO variant_price_tiers(variant_id, min_quantity, max_quantity, unit_price) VALUES ($1,$2,$3,$4)"
+	for _, tier := range tiers {
+		if _, err := tx.ExecContext(ctx, query, variantID, tier.MinQuantity, tier.MaxQuantity, tier.UnitPrice); err != nil {
+			return shared.PostgresError(err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetCompanyID returns the company of the signed-in user, see shared.CompanyID.
+func (r *PricingRepo) GetCompanyID(ctx context.Context) (*string, error) {
+	return shared.CompanyID(ctx, r.db)
+}
+
+// GetAppliedPriceList returns the price list in force for a company, or nil.
+func (r *PricingRepo) GetAppliedPriceList(ctx context.Context, companyID *string) (*AppliedPriceList, error) {
+	if companyID == nil {
+		return nil, nil
+	}
+
+	var priceList AppliedPriceList
+	query := "SELECT id, name, currency FROM price_lists WHERE id = active_price_list($1)"
+	if err := r.db.GetContext(ctx, &priceList, query, companyID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, shared.PostgresError(err)
+	}
+	return &priceList, nil
+}
+
+// ContractPrice resolves a list price of a variant for a company in currency,
+// see the contract_price SQL function.
+func (r *PricingRepo) ContractPrice(ctx context.Context, variantID string, companyID *string, listPrice shared.Decimal, currency string) (shared.Decimal, error) {
+	var price shared.Decimal
+	if err := r.db.GetContext(ctx, &price, "SELECT contract_price($1, $2::uuid, $3, $4)", variantID, companyID, listPrice, currency); err != nil {
+		return 0, shared.PostgresError(err)
+	}
+	return price, nil
+}
+
+// ConvertPrice converts an amount between currencies, see the convert_price
+// SQL function.
+func (r *PricingRepo) ConvertPrice(ctx context.Context, amount shared.Decimal, from, to string) (shared.Decimal, error) {
+	var price shared.Decimal
+	if err := r.db.GetContext(ctx, &price, "SELECT convert_price($1, $2, $3)", amount, from, to); err != nil {
+		return 0, shared.PostgresError(err)
+	}
+	return price, nil
+}
+
+// GetTaxRule returns the tax rule of a product today, see shared.ProductTaxRule.
+func (r *PricingRepo) GetTaxRule(ctx context.Context, productID string) (*shared.TaxRule, error) {
+	return shared.ProductTaxRule(ctx, r.db, productID, time.Now())
+}
+
+// GetOrderRules returns the unit and quantity rules of a variant, see
+// shared.VariantOrderRules.
+func (r *PricingRepo) GetOrderRules(ctx context.Context, variantID string) (*shared.OrderRules, error) {
+	return shared.VariantOrderRules(ctx, r.db, variantID)
+}
+
+// BuyerState resolves the GST state to tax for, see shared.BuyerState.
+func (r *PricingRepo) BuyerState(ctx context.Context, shipTo string) (string, error) {
+	return shared.BuyerState(ctx, r.db, shipTo)
+}
+
+// CurrencyPlaces returns the decimal places of a currency, see
+// shared.CurrencyPlaces.
+func (r *PricingRepo) CurrencyPlaces(ctx context.Context, currency string) (int, error) {
+	return shared.CurrencyPlaces(ctx, r.db, currency)
+}
+
+// ResolveCurrency checks a requested currency, see shared.ResolveCurrency.
+func (r *PricingRepo) ResolveCurrency(ctx context.Context, currency string) (string, error) {
+	return shared.ResolveCurrency(ctx, r.db, currency)
+}
+
+func (r *PricingRepo) SavePriceList(ctx context.Context, request PriceListRequestDTO) (string, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return "", shared.PostgresError(err)
+	}
+
+	defer tx.Rollback()
+
+	var id string
+	query := `INSERT INTO price_lists (name, currency, valid_from, valid_to, is_active)
+	VALUES ($1, $2, COALESCE($3, CURRENT_TIMESTAMP), $4, COALESCE($5, TRUE)) RETURNING id`
+	if err := tx.GetContext(ctx, &id, query, request.Name, request.Currency, request.ValidFrom, request.ValidTo, request.IsActive); err != nil {
+		return "", shared.PostgresError(err)
+	}
+
+	if err := replacePriceListEntries(ctx, tx, id, request); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", shared.PostgresError(err)
+	}
+
+	return id, nil
+}
+
+// UpdatePriceList replaces a price list with request. valid_from and
+// is_active keep their value when omitted.
+func (r *PricingRepo) UpdatePriceList(ctx context.Context, id string, request PriceListRequestDTO) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return shared.PostgresError(err)
+	}
+
+	defer tx.Rollback()
+
+	query := `UPDATE price_lists SET name = $2, currency = $3, valid_from = COALESCE($4, valid_from), valid_to = $5, is_active = COALESCE($6, is_active)
+	WHERE id = $1`
+	result, err := tx.ExecContext(ctx, query, id, request.Name, request.Currency, request.ValidFrom, request.ValidTo, request.IsActive)
+	if err != nil {
+		return shared.PostgresError(err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrPriceListNotFound
+	}
+
+	if err := replacePriceListEntries(ctx, tx, id, request); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// replacePriceListEntries swaps the prices, discounts and companies of a price
+// list for those of request.
+func replacePriceListEntries(ctx context.Context, tx *sqlx.Tx, id string, request PriceListRequestDTO) error {
+	for _, table := range []string{"price_list_items", "price_list_discounts", "company_price_lists"} {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM "+table+" WHERE price_list_id = $1", id); err != nil {
+			return shared.PostgresError(err)
+		}
+	}
+
+	for _, item := range request.Items {
+		query := "INSERT INTO price_list_items (price_list_id, variant_id, price) VALUES ($1, $2, $3)"
+		if _, err := tx.ExecContext(ctx, query, id, item.VariantID, item.Price); err != nil {
+			return shared.PostgresError(err)
+		}
+	}
+
+	for _, discount := range request.Discounts {
+		query := "INSERT INTO price_list_discounts (price_list_id, category_id, brand_id, discount_percent) VALUES ($1, $2, $3, $4)"
+		if _, err := tx.ExecContext(ctx, query, id, discount.CategoryID, discount.BrandID, discount.DiscountPercent); err != nil {
+			return shared.PostgresError(err)
+		}
+	}
+
+	for _, companyID := range request.Companies {
+		query := "INSERT INTO company_price_lists (company_id, price_list_id) VALUES ($1, $2) ON CONFLICT DO NOTHING"
+		if _, err := tx.ExecContext(ctx, query, companyID, id); err != nil {
+			return shared.PostgresError(err)
+		}
+	}
+
+	return nil
+}
+
+func (r *PricingRepo) GetPriceLists(ctx context.Context) ([]PriceList, error) {
+	var priceLists []PriceList
+	query := "SELECT id, name, currency, valid_from, valid_to, is_active, created_at, updated_at FROM price_lists ORDER BY name, created_at"
+	if err := r.db.SelectContext(ctx, &priceLists, query); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+	return priceLists, nil
+}
+
+func (r *PricingRepo) GetPriceList(ctx context.Context, id string) (*PriceListDetail, error) {
+	var detail PriceListDetail
+	query := "SELECT id, name, currency, valid_from, valid_to, is_active, created_at, updated_at FROM price_lists WHERE id = $1"
+	if err := r.db.GetContext(ctx, &detail.PriceList, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPriceListNotFound
+		}
+		return nil, shared.PostgresError(err)
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		query := `SELECT pli.variant_id, pv.sku, pli.price FROM price_list_items pli
+		JOIN product_variants pv ON pv.id = pli.variant_id WHERE pli.price_list_id = $1 ORDER BY pv.sku`
+		return r.db.SelectContext(gctx, &detail.Items, query, id)
+	})
+	g.Go(func() error {
+		query := "SELECT id, category_id, brand_id, discount_percent FROM price_list_discounts WHERE price_list_id = $1 ORDER BY discount_percent DESC"
+		return r.db.SelectContext(gctx, &detail.Discounts, query, id)
+	})
+	g.Go(func() error {
+		query := "SELECT company_id FROM company_price_lists WHERE price_list_id = $1 ORDER BY company_id"
+		return r.db.SelectContext(gctx, &detail.Companies, query, id)
+	})
+	if err := g.Wait(); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+
+	return &detail, nil
+}
+
+func (r *PricingRepo) DeletePriceList(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM price_lists WHERE id = $1", id)
+	if err != nil {
+		return shared.PostgresError(err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrPriceListNotFound
+	}
+	return nil
+}
+
+// GetExchangeRates returns every currency with the rate in force for it.
+func (r *PricingRepo) GetExchangeRates(ctx context.Context) ([]ExchangeRate, error) {
+	var rates []ExchangeRate
+	query := `SELECT c.code, c.name, c.rounding_increment, c.rounding_mode, c.is_base, er.rate, er.effective_at, er.source
+	FROM currencies c
+	LEFT JOIN LATERAL (
+		SELECT rate, effective_at, source FROM exchange_rates
+		WHERE currency = c.code AND effective_at <= CURRENT_TIMESTAMP
+		ORDER BY effective_at DESC LIMIT 1
+	) er ON TRUE
+	ORDER BY c.is_base DESC, c.code`
+	if err := r.db.SelectContext(ctx, &rates, query); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+	return rates, nil
+}
+
+func (r *PricingRepo) GetStoreCurrency(ctx context.Context) (string, error) {
+	var currency string
+	if err := r.db.GetContext(ctx, &currency, "SELECT store_currency()"); err != nil {
+		return "", shared.PostgresError(err)
+	}
+	return currency, nil
+}
+
+// SaveExchangeRates records rates, all or none. A rate for the same currency
+// and effective_at as an existing one replaces it. userID may be empty for
+// rates loaded outside a request.
+func (r *PricingRepo) SaveExchangeRates(ctx context.Context, userID, source string, rates []ExchangeRateDTO) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return shared.PostgresError(err)
+	}
+
+	defer tx.Rollback()
+
+	query := `INSERT INTO exchange_rates (currency, rate, effective_at, source, created_by)
+	VALUES ($1, $2, COALESCE($3, CURRENT_TIMESTAMP), $4, NULLIF($5, '')::uuid)
+	ON CONFLICT (currency, effective_at) DO UPDATE SET rate = EXCLUDED.rate, source = EXCLUDED.source, created_by = EXCLUDED.created_by`
+	for _, rate := range rates {
+		if _, err := tx.ExecContext(ctx, query, rate.Currency, rate.Rate.String(), rate.EffectiveAt, source, userID); err != nil {
+			return shared.PostgresError(err)
+		}
+	}
+
+	return tx.Commit()
+}
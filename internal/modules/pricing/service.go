@@ -0,0 +1,356 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/smart-safety-hub/backend/shared"
+	"go.uber.org/zap"
+)
+
+type PricingService struct {
+	logger *zap.Logger
+	repo   *PricingRepo
+}
+
+func NewPricingService(logger *zap.Logger, repo *PricingRepo) *PricingService {
+	return &PricingService{
+		logger: logger,
+		repo:   repo,
+	}
+}
+
+// GetPriceTiers returns the tiers of a variant of an ACTIVE product.
+func (b *PricingService) GetPriceTiers(ctx context.Context, variantIDOrSKU string) (*PriceTiersResponse, error) {
+	variant, err := b.activeVariant(ctx, variantIDOrSKU)
+	if err != nil {
+		return nil, err
+	}
+
+	tiers, err := b.variantTiers(ctx, variant.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PriceTiersResponse{
+		VariantID: variant.ID,
+		SKU:       variant.SKU,
+		BasePrice: variant.Price,
+		Currency:  variant.Currency,
+		Tiers:     tiers,
+	}, nil
+}
+
+// SavePriceTiers replaces the tiers of a variant, see
+// PricingRepo.ReplacePriceTiers.
+func (b *PricingService) SavePriceTiers(ctx context.Context, variantIDOrSKU string, request PriceTiersRequestDTO) (*GenericResponseDTO, error) {
+	tiers, err := validateTiers(request.Tiers)
+	if err != nil {
+		return nil, err
+	}
+
+	variant, err := b.repo.GetVariant(ctx, variantIDOrSKU)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	if err := b.repo.ReplacePriceTiers(ctx, variant.ID, tiers); err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return &GenericResponseDTO{
+		Status:  "success",
+		Message: "Price Tiers Saved Successfully",
+	}, nil
+}
+
+// activeVariant looks up a variant for the public price endpoints, which do
+// not show variants of products that are not ACTIVE.
+func (b *PricingService) activeVariant(ctx context.Context, variantIDOrSKU string) (*Variant, error) {
+	variant, err := b.repo.GetVariant(ctx, variantIDOrSKU)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+	if variant.ProductStatus != activeStatus {
+		return nil, ErrVariantNotFound
+	}
+	return variant, nil
+}
+
+// Quote prices quantity units of a variant using its price tiers, then the
+// price list of the signed-in buyer's company: a contract price replaces the
+// tier price, a discount applies to it. The quantity is in unit, one of the
+// variant's pack sizes, or in its base unit when unit is empty, and must meet
+// its order rules; prices and tiers are per base unit. Prices are in currency,
+// or in the variant's currency when it is empty, and taxed for shipTo, a GST
+// state code. Only variants of ACTIVE products are quoted.
+func (b *PricingService) Quote(ctx context.Context, variantIDOrSKU string, quantity int, unit, currency, shipTo string) (*QuoteResponse, error) {
+	variant, err := b.activeVariant(ctx, variantIDOrSKU)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := b.repo.GetOrderRules(ctx, variant.ID)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	baseQuantity, err := shared.ValidateOrderQuantity(*rules, quantity, unit)
+	if err != nil {
+		return nil, err
+	}
+	if unit == "" {
+		unit = rules.Normalized().Unit
+	}
+
+	target := variant.Currency
+	if currency != "" {
+		target, err = b.repo.ResolveCurrency(ctx, currency)
+		if err != nil {
+			return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+		}
+	}
+
+	tiers, err := b.variantTiers(ctx, variant.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	unitPrice, extended, tier := quote(variant.Price, tiers, baseQuantity)
+	basePrice := variant.Price
+
+	companyID, err := b.repo.GetCompanyID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	priceList, err := b.repo.GetAppliedPriceList(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	if priceList != nil || target != variant.Currency {
+		unitPrice, err = b.repo.ContractPrice(ctx, variant.ID, companyID, unitPrice, target)
+		if err != nil {
+			return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+		}
+		extended = unitPrice.MulInt(baseQuantity)
+
+		basePrice, err = b.repo.ConvertPrice(ctx, variant.Price, variant.Currency, target)
+		if err != nil {
+			return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+		}
+	}
+
+	if tier != nil && target != variant.Currency {
+		tier.UnitPrice, err = b.repo.ConvertPrice(ctx, tier.UnitPrice, variant.Currency, target)
+		if err != nil {
+			return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+		}
+	}
+
+	buyerState, err := b.repo.BuyerState(ctx, shipTo)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	rule, err := b.repo.GetTaxRule(ctx, variant.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	places, err := b.repo.CurrencyPlaces(ctx, target)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	return &QuoteResponse{
+		VariantID:     variant.ID,
+		SKU:           variant.SKU,
+		Quantity:      quantity,
+		Unit:          strings.ToUpper(strings.TrimSpace(unit)),
+		BaseQuantity:  baseQuantity,
+		Currency:      target,
+		BasePrice:     basePrice,
+		UnitPrice:     unitPrice,
+		ExtendedPrice: extended,
+		Savings:       basePrice.MulInt(baseQuantity).Sub(extended),
+		Tier:          tier,
+		PriceList:     priceList,
+		Tax:           shared.ComputeTax(extended, *rule, buyerState, places),
+	}, nil
+}
+
+func (b *PricingService) CreatePriceList(ctx context.Context, request PriceListRequestDTO) (*GenericResponseDTO, error) {
+	if err := validatePriceList(request, time.Now()); err != nil {
+		return nil, err
+	}
+
+	id, err := b.repo.SavePriceList(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return &GenericResponseDTO{
+		ID:      &id,
+		Status:  "success",
+		Message: "Price List Created Successfully",
+	}, nil
+}
+
+func (b *PricingService) UpdatePriceList(ctx context.Context, id string, request PriceListRequestDTO) (*GenericResponseDTO, error) {
+	if err := validatePriceList(request, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := b.repo.UpdatePriceList(ctx, id, request); err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return &GenericResponseDTO{
+		ID:      &id,
+		Status:  "success",
+		Message: "Price List Updated Successfully",
+	}, nil
+}
+
+func (b *PricingService) GetPriceLists(ctx context.Context) ([]PriceListResponse, error) {
+	resp, err := b.repo.GetPriceLists(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	priceLists := make([]PriceListResponse, 0, len(resp))
+	for _, data := range resp {
+		priceLists = append(priceLists, toPriceListResponse(data))
+	}
+	return priceLists, nil
+}
+
+func (b *PricingService) GetPriceList(ctx context.Context, id string) (*PriceListResponse, error) {
+	detail, err := b.repo.GetPriceList(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	response := toPriceListResponse(detail.PriceList)
+	response.Items = make([]PriceListItemDTO, 0, len(detail.Items))
+	for _, item := range detail.Items {
+		response.Items = append(response.Items, PriceListItemDTO{
+			VariantID: item.VariantID,
+			SKU:       item.SKU,
+			Price:     item.Price,
+		})
+	}
+	response.Discounts = make([]PriceListDiscountDTO, 0, len(detail.Discounts))
+	for _, discount := range detail.Discounts {
+		response.Discounts = append(response.Discounts, PriceListDiscountDTO{
+			CategoryID:      discount.CategoryID,
+			BrandID:         discount.BrandID,
+			DiscountPercent: discount.DiscountPercent,
+		})
+	}
+	response.Companies = detail.Companies
+
+	return &response, nil
+}
+
+func (b *PricingService) DeletePriceList(ctx context.Context, id string) (*GenericResponseDTO, error) {
+	if err := b.repo.DeletePriceList(ctx, id); err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return &GenericResponseDTO{
+		ID:      &id,
+		Status:  "success",
+		Message: "Price List Deleted Successfully",
+	}, nil
+}
+
+func (b *PricingService) GetExchangeRates(ctx context.Context) ([]ExchangeRateResponse, error) {
+	resp, err := b.repo.GetExchangeRates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	rates := make([]ExchangeRateResponse, 0, len(resp))
+	for _, data := range resp {
+		rates = append(rates, ExchangeRateResponse{
+			Currency:          data.Currency,
+			Name:              data.Name,
+			IsBase:            data.IsBase,
+			RoundingIncrement: data.RoundingIncrement,
+			RoundingMode:      data.RoundingMode,
+			Rate:              data.Rate,
+			EffectiveAt:       data.EffectiveAt,
+			Source:            data.Source,
+		})
+	}
+	return rates, nil
+}
+
+// SaveExchangeRates records rates against the store currency. userID is the
+// admin setting them, empty when they come from the command line.
+func (b *PricingService) SaveExchangeRates(ctx context.Context, userID, source string, request ExchangeRatesRequestDTO) (*GenericResponseDTO, error) {
+	storeCurrency, err := b.repo.GetStoreCurrency(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	if err := validateRates(request.Rates, storeCurrency, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := b.repo.SaveExchangeRates(ctx, userID, source, request.Rates); err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	b.logger.Info("exchange rates saved", zap.String("source", source), zap.Int("rates", len(request.Rates)))
+
+	return &GenericResponseDTO{
+		Status:  "success",
+		Message: fmt.Sprintf("%d Exchange Rates Saved Successfully", len(request.Rates)),
+	}, nil
+}
+
+// ImportExchangeRates loads rates from a CSV file, see parseRatesCSV.
+func (b *PricingService) ImportExchangeRates(ctx context.Context, userID string, file io.Reader) (*GenericResponseDTO, error) {
+	rates, err := parseRatesCSV(file)
+	if err != nil {
+		return nil, err
+	}
+	return b.SaveExchangeRates(ctx, userID, RateSourceFile, ExchangeRatesRequestDTO{Rates: rates})
+}
+
+func toPriceListResponse(priceList PriceList) PriceListResponse {
+	return PriceListResponse{
+		ID:        priceList.ID,
+		Name:      priceList.Name,
+		Currency:  priceList.Currency,
+		ValidFrom: priceList.ValidFrom,
+		ValidTo:   priceList.ValidTo,
+		IsActive:  priceList.IsActive,
+		CreatedAt: priceList.CreatedAt,
+		UpdatedAt: priceList.UpdatedAt,
+	}
+}
+
+func (b *PricingService) variantTiers(ctx context.Context, variantID string) ([]PriceTierDTO, error) {
+	resp, err := b.repo.GetPriceTiers(ctx, variantID)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	tiers := make([]PriceTierDTO, 0, len(resp))
+	for _, data := range resp {
+		tiers = append(tiers, PriceTierDTO{
+			MinQuantity: data.MinQuantity,
+			MaxQuantity: data.MaxQuantity,
+			UnitPrice:   data.UnitPrice,
+		})
+	}
+	return tiers, nil
+}
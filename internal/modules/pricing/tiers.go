@@ -0,0 +1,60 @@
+package pricing
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+// validateTiers sorts tiers by min_quantity and checks that they do not
+// overlap. Only the last tier may be open-ended; gaps between tiers are
+// allowed and charged at the base price.
+func validateTiers(tiers []PriceTierDTO) ([]PriceTierDTO, error) {
+	sorted := make([]PriceTierDTO, len(tiers))
+	copy(sorted, tiers)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].MinQuantity < sorted[j].MinQuantity })
+
+	for i, tier := range sorted {
+		if tier.MinQuantity < 1 {
+			return nil, fmt.Errorf("%w: min_quantity must be at least 1", ErrInvalidTiers)
+		}
+		if tier.MaxQuantity != nil && *tier.MaxQuantity < tier.MinQuantity {
+			return nil, fmt.Errorf("%w: tier from %d ends before it starts", ErrInvalidTiers, tier.MinQuantity)
+		}
+		if tier.UnitPrice < 0 {
+			return nil, fmt.Errorf("%w: tier from %d has a negative unit price", ErrInvalidTiers, tier.MinQuantity)
+		}
+		if i == 0 {
+			continue
+		}
+
+		previous := sorted[i-1]
+		if previous.MaxQuantity == nil || *previous.MaxQuantity >= tier.MinQuantity {
+			return nil, fmt.Errorf("%w: tier from %d overlaps tier from %d", ErrInvalidTiers, tier.MinQuantity, previous.MinQuantity)
+		}
+	}
+
+	return sorted, nil
+}
+
+// tierFor returns the tier covering quantity, or nil.
+func tierFor(tiers []PriceTierDTO, quantity int) *PriceTierDTO {
+	for i := range tiers {
+		tier := tiers[i]
+		if quantity >= tier.MinQuantity && (tier.MaxQuantity == nil || quantity <= *tier.MaxQuantity) {
+			return &tier
+		}
+	}
+	return nil
+}
+
+// quote prices quantity units at the covering tier, or at the base price.
+func quote(basePrice shared.Decimal, tiers []PriceTierDTO, quantity int) (shared.Decimal, shared.Decimal, *PriceTierDTO) {
+	unitPrice := basePrice
+	tier := tierFor(tiers, quantity)
+	if tier != nil {
+		unitPrice = tier.UnitPrice
+	}
+	return unitPrice, unitPrice.MulInt(quantity), tier
+}
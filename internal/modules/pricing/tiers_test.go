@@ -0,0 +1,132 @@
+package pricing
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func tier(minQuantity int, maxQuantity *int, price int64) PriceTierDTO {
+	return PriceTierDTO{MinQuantity: minQuantity, MaxQuantity: maxQuantity, UnitPrice: shared.NewDecimal(price)}
+}
+
+func TestValidateTiers(t *testing.T) {
+	tests := []struct {
+		name  string
+		tiers []PriceTierDTO
+		want  []int
+		err   bool
+	}{
+		{name: "none", tiers: nil, want: []int{}},
+		{name: "single open-ended", tiers: []PriceTierDTO{tier(10, nil, 90)}, want: []int{10}},
+		{name: "sorted", tiers: []PriceTierDTO{tier(100, nil, 80), tier(10, intPtr(49), 90), tier(50, intPtr(99), 85)}, want: []int{10, 50, 100}},
+		{name: "gap", tiers: []PriceTierDTO{tier(10, intPtr(19), 90), tier(50, nil, 80)}, want: []int{10, 50}},
+		{name: "single quantity", tiers: []PriceTierDTO{tier(5, intPtr(5), 95), tier(6, nil, 90)}, want: []int{5, 6}},
+		{name: "free tier", tiers: []PriceTierDTO{tier(1000, nil, 0)}, want: []int{1000}},
+		{name: "adjacent bounds overlap", tiers: []PriceTierDTO{tier(10, intPtr(50), 90), tier(50, nil, 80)}, err: true},
+		{name: "contained", tiers: []PriceTierDTO{tier(10, intPtr(100), 90), tier(20, intPtr(30), 80)}, err: true},
+		{name: "same start", tiers: []PriceTierDTO{tier(10, intPtr(19), 90), tier(10, nil, 80)}, err: true},
+		{name: "open-ended not last", tiers: []PriceTierDTO{tier(10, nil, 90), tier(50, nil, 80)}, err: true},
+		{name: "zero minimum", tiers: []PriceTierDTO{tier(0, intPtr(9), 90)}, err: true},
+		{name: "ends before start", tiers: []PriceTierDTO{tier(10, intPtr(5), 90)}, err: true},
+		{name: "negative price", tiers: []PriceTierDTO{tier(10, nil, -1)}, err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := validateTiers(test.tiers)
+			if test.err {
+				if !errors.Is(err, ErrInvalidTiers) {
+					t.Fatalf("error = %v, want ErrInvalidTiers", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			mins := make([]int, len(got))
+			for i, sorted := range got {
+				mins[i] = sorted.MinQuantity
+			}
+			if len(mins) != len(test.want) {
+				t.Fatalf("got tiers from %v, want %v", mins, test.want)
+			}
+			for i := range mins {
+				if mins[i] != test.want[i] {
+					t.Fatalf("got tiers from %v, want %v", mins, test.want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateTiersKeepsInput(t *testing.T) {
+	tiers := []PriceTierDTO{tier(50, nil, 80), tier(10, intPtr(49), 90)}
+	if _, err := validateTiers(tiers); err != nil {
+		t.Fatal(err)
+	}
+	if tiers[0].MinQuantity != 50 {
+		t.Errorf("validateTiers reordered its input")
+	}
+}
+
+func TestQuote(t *testing.T) {
+	base := shared.NewDecimal(100)
+	tiers, err := validateTiers([]PriceTierDTO{tier(10, intPtr(49), 90), tier(50, intPtr(99), 85), tier(200, nil, 70)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		quantity  int
+		unitPrice int64
+		tierMin   int
+	}{
+		{quantity: 1, unitPrice: 100},
+		{quantity: 9, unitPrice: 100},
+		{quantity: 10, unitPrice: 90, tierMin: 10},
+		{quantity: 49, unitPrice: 90, tierMin: 10},
+		{quantity: 50, unitPrice: 85, tierMin: 50},
+		{quantity: 99, unitPrice: 85, tierMin: 50},
+		// 100 to 199 falls in the gap and is charged the base price.
+		{quantity: 100, unitPrice: 100},
+		{quantity: 199, unitPrice: 100},
+		{quantity: 200, unitPrice: 70, tierMin: 200},
+		{quantity: 100000, unitPrice: 70, tierMin: 200},
+	}
+
+	for _, test := range tests {
+		unitPrice, total, matched := quote(base, tiers, test.quantity)
+		if want := shared.NewDecimal(test.unitPrice); unitPrice != want {
+			t.Errorf("quote(%d) unit price = %s, want %s", test.quantity, unitPrice, want)
+		}
+		if want := shared.NewDecimal(test.unitPrice * int64(test.quantity)); total != want {
+			t.Errorf("quote(%d) total = %s, want %s", test.quantity, total, want)
+		}
+
+		switch {
+		case test.tierMin == 0 && matched != nil:
+			t.Errorf("quote(%d) matched tier from %d, want none", test.quantity, matched.MinQuantity)
+		case test.tierMin != 0 && (matched == nil || matched.MinQuantity != test.tierMin):
+			t.Errorf("quote(%d) matched %+v, want tier from %d", test.quantity, matched, test.tierMin)
+		}
+	}
+}
+
+func TestQuoteFractionalPrice(t *testing.T) {
+	price, err := shared.ParseDecimal("0.3333")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unitPrice, total, matched := quote(price, nil, 3)
+	if unitPrice != price || total.String() != "0.9999" || matched != nil {
+		t.Errorf("quote = %s, %s, %+v, want 0.3333, 0.9999, nil", unitPrice, total, matched)
+	}
+}
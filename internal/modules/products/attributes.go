@@ -0,0 +1,125 @@
+package products
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+var ErrInvalidAttributes = errors.New("invalid product attributes")
+
+// validateAttributes checks attributes against the category schema and
+// returns them with canonical keys and normalized values. Products whose
+// category has no schema keep free-form attributes.
+func validateAttributes(definitions []AttributeDefinition, attributes []ProductAttributeArray) ([]ProductAttributeArray, error) {
+	if len(definitions) == 0 {
+		return attributes, nil
+	}
+
+	byKey := make(map[string]AttributeDefinition, len(definitions))
+	for _, definition := range definitions {
+		byKey[definition.AttributeKey] = definition
+		byKey[shared.AttributeKey(definition.Label)] = definition
+	}
+
+	var problems []string
+	seen := make(map[string]bool, len(attributes))
+	normalized := make([]ProductAttributeArray, 0, len(attributes))
+
+	for _, attribute := range attributes {
+		definition, ok := byKey[shared.AttributeKey(attribute.AttributeKey)]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%q is not defined for this category", attribute.AttributeKey))
+			continue
+		}
+
+		if seen[definition.AttributeKey] {
+			problems = append(problems, fmt.Sprintf("%q is given more than once", definition.AttributeKey))
+			continue
+		}
+		seen[definition.AttributeKey] = true
+
+		value, err := normalizeAttributeValue(definition, attribute.AttributeValue)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%q %v", definition.AttributeKey, err))
+			continue
+		}
+
+		normalized = append(normalized, ProductAttributeArray{
+			AttributeKey:   definition.AttributeKey,
+			AttributeValue: value,
+		})
+	}
+
+	for _, definition := range definitions {
+		if definition.Required && !seen[definition.AttributeKey] {
+			problems = append(problems, fmt.Sprintf("%q is required", definition.AttributeKey))
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAttributes, strings.Join(problems, "; "))
+	}
+
+	return normalized, nil
+}
+
+func normalizeAttributeValue(definition AttributeDefinition, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", errors.New("must not be empty")
+	}
+
+	switch definition.Type {
+	case "ENUM":
+		i := slices.IndexFunc(definition.AllowedValues, func(allowed string) bool {
+			return strings.EqualFold(allowed, value)
+		})
+		if i < 0 {
+			return "", fmt.Errorf("must be one of %s", strings.Join(definition.AllowedValues, ", "))
+		}
+		return definition.AllowedValues[i], nil
+
+	case "NUMBER":
+		// Accept the value with or without the definition's unit, e.g. "1.5 mm"
+		if definition.Unit != nil && *definition.Unit != "" {
+			if trimmed, ok := cutSuffixFold(value, *definition.Unit); ok {
+				value = strings.TrimSpace(trimmed)
+			}
+		}
+
+		// ParseFloat also reads "NaN" and "Inf", which are not measurements
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+			return "", errors.New("must be a number")
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+
+	case "BOOLEAN":
+		switch strings.ToLower(value) {
+		case "true", "yes", "1":
+			return "true", nil
+		case "false", "no", "0":
+			return "false", nil
+		}
+		return "", errors.New("must be true or false")
+
+	default:
+		if len(definition.AllowedValues) > 0 && !slices.Contains(definition.AllowedValues, value) {
+			return "", fmt.Errorf("must be one of %s", strings.Join(definition.AllowedValues, ", "))
+		}
+		return value, nil
+	}
+}
+
+func cutSuffixFold(s, suffix string) (string, bool) {
+	if len(s) >= len(suffix) && strings.EqualFold(s[len(s)-len(suffix):], suffix) {
+		return s[:len(s)-len(suffix)], true
+	}
+	return s, false
+}
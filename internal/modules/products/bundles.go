@@ -0,0 +1,142 @@
+package products
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+var ErrInvalidBundle = errors.New("invalid bundle")
+
+// Bundle pricing: FIXED bundles are sold at the price of their own variants;
+// COMPONENTS bundles at the sum of their components less a discount, which
+// migrations/015_bundles.sql keeps on their variants.
+const (
+	BundlePricingFixed      = "FIXED"
+	BundlePricingComponents = "COMPONENTS"
+)
+
+// maxBundleComponents caps the components of one bundle.
+const maxBundleComponents = 50
+
+// validateBundle checks a composition without the database: a discount only
+// for COMPONENTS pricing and below 100%, unique component names, and SKUs
+// listed once per component with the default among them.
+func validateBundle(request BundleRequestDTO) error {
+	if len(request.Components) > maxBundleComponents {
+		return fmt.Errorf("%w: a bundle has at most %d components", ErrInvalidBundle, maxBundleComponents)
+	}
+
+	if request.DiscountPercent < 0 || request.DiscountPercent >= shared.NewDecimal(100) {
+		return fmt.Errorf("%w: discount_percent must be at least 0 and below 100", ErrInvalidBundle)
+	}
+	if request.Pricing == BundlePricingFixed && !request.DiscountPercent.IsZero() {
+		return fmt.Errorf("%w: discount_percent only applies to COMPONENTS pricing", ErrInvalidBundle)
+	}
+
+	names := make(map[string]bool, len(request.Components))
+	for _, component := range request.Components {
+		if names[component.Name] {
+			return fmt.Errorf("%w: component %q is listed more than once", ErrInvalidBundle, component.Name)
+		}
+		names[component.Name] = true
+
+		for i, sku := range component.SKUs {
+			if slices.Contains(component.SKUs[:i], sku) {
+				return fmt.Errorf("%w: component %q lists sku %q more than once", ErrInvalidBundle, component.Name, sku)
+			}
+		}
+		if component.DefaultSKU != "" && !slices.Contains(component.SKUs, component.DefaultSKU) {
+			return fmt.Errorf("%w: default sku %q of component %q is not one of its skus", ErrInvalidBundle, component.DefaultSKU, component.Name)
+		}
+	}
+
+	return nil
+}
+
+// toBundleDTO groups the allowed variants of a bundle by component, in the
+// order of rows.
+func toBundleDTO(bundle Bundle, rows []BundleComponentVariant) *BundleDTO {
+	response := &BundleDTO{
+		Pricing:         bundle.Pricing,
+		DiscountPercent: bundle.DiscountPercent,
+		Price:           bundle.Price,
+		Currency:        bundle.Currency,
+		Available:       true,
+		Components:      []BundleComponentDTO{},
+	}
+
+	for _, row := range rows {
+		n := len(response.Components)
+		if n == 0 || response.Components[n-1].ID != row.ComponentID {
+			response.Components = append(response.Components, BundleComponentDTO{
+				ID:       row.ComponentID,
+				Name:     row.Name,
+				Quantity: row.Quantity,
+				Variants: []BundleVariantDTO{},
+			})
+			n++
+		}
+
+		component := &response.Components[n-1]
+		component.Available = component.Available || row.Available
+		component.Variants = append(component.Variants, BundleVariantDTO{
+			VariantID:    row.VariantID,
+			ProductID:    row.ProductID,
+			ProductName:  row.ProductName,
+			SKU:          row.SKU,
+			Price:        row.Price,
+			Currency:     row.Currency,
+			OptionValues: row.OptionValues,
+			IsDefault:    row.IsDefault,
+			Available:    row.Available,
+		})
+	}
+
+	for _, component := range response.Components {
+		response.Available = response.Available && component.Available
+	}
+	if len(response.Components) == 0 {
+		response.Available = false
+	}
+
+	return response
+}
+
+// chooseBundleVariants resolves the selections of a kit, component name to
+// SKU, against the allowed variants and returns the chosen variant of every
+// component, the default where nothing was selected.
+func chooseBundleVariants(bundle *BundleDTO, selections map[string]string) ([]BundleChosenComponent, error) {
+	for name := range selections {
+		if !slices.ContainsFunc(bundle.Components, func(c BundleComponentDTO) bool { return c.Name == name }) {
+			return nil, fmt.Errorf("%w: the bundle has no component %q", ErrInvalidBundle, name)
+		}
+	}
+
+	chosen := make([]BundleChosenComponent, 0, len(bundle.Components))
+	for _, component := range bundle.Components {
+		sku, selected := selections[component.Name]
+		i := slices.IndexFunc(component.Variants, func(v BundleVariantDTO) bool {
+			if selected {
+				return v.SKU == sku
+			}
+			return v.IsDefault
+		})
+		if i < 0 {
+			return nil, fmt.Errorf("%w: sku %q is not allowed for component %q", ErrInvalidBundle, sku, component.Name)
+		}
+
+		variant := component.Variants[i]
+		chosen = append(chosen, BundleChosenComponent{
+			Name:      component.Name,
+			Quantity:  component.Quantity,
+			VariantID: variant.VariantID,
+			SKU:       variant.SKU,
+			Available: variant.Available,
+		})
+	}
+
+	return chosen, nil
+}
@@ -2,17 +2,27 @@ package products
 
 import (
 	"encoding/json"
+	"errors"
 	"time"
+
+	"github.com/lib/pq"
+	"github.com/smart-safety-hub/backend/shared"
 )
 
 type ProductStatus string
 
 type ProductType string
 
+// ProductKind tells standard products from bundles, see bundles.go.
+type ProductKind string
+
 const (
-	DRAFT    ProductStatus = "DRAFT"
-	ACTIVE   ProductStatus = "ACTIVE"
-	ARCHIVED ProductStatus = "ARCHIVED"
+	DRAFT          ProductStatus = "DRAFT"
+	PENDING_REVIEW ProductStatus = "PENDING_REVIEW"
+	REJECTED       ProductStatus = "REJECTED"
+	SCHEDULED      ProductStatus = "SCHEDULED"
+	ACTIVE         ProductStatus = "ACTIVE"
+	ARCHIVED       ProductStatus = "ARCHIVED"
 )
 
 const (
@@ -21,29 +31,58 @@ const (
 	PDF   ProductType = "pdf"
 )
 
+const (
+	STANDARD ProductKind = "STANDARD"
+	BUNDLE   ProductKind = "BUNDLE"
+)
+
 type Product struct {
-	ID          string        `db:"id"`
-	Name        string        `db:"name"`
-	Slug        string        `db:"slug"`
-	Description *string       `db:"description"`
-	SellerID    string        `db:"seller_id"`
-	BrandID     string        `db:"brand_id"`
-	CategoryID  string        `db:"category_id"`
-	Status      ProductStatus `db:"status"`
-	CreatedAt   time.Time     `db:"created_at"`
-	UpdatedAt   time.Time     `db:"updated_at"`
+	ID              string        `db:"id"`
+	Name            string        `db:"name"`
+	Slug            string        `db:"slug"`
+	Description     *string       `db:"description"`
+	SellerID        string        `db:"seller_id"`
+	BrandID         string        `db:"brand_id"`
+	CategoryID      string        `db:"category_id"`
+	Status          ProductStatus `db:"status"`
+	Type            ProductKind   `db:"product_type"`
+	RejectionReason *string       `db:"rejection_reason"`
+	PublishAt       *time.Time    `db:"publish_at"`
+	UnpublishAt     *time.Time    `db:"unpublish_at"`
+	// ComplianceFlaggedAt is set while a mandatory certificate has lapsed.
+	ComplianceFlaggedAt *time.Time `db:"compliance_flagged_at"`
+	// Average and count of the approved reviews.
+	RatingAverage shared.Decimal `db:"rating_average"`
+	RatingCount   int            `db:"rating_count"`
+	CreatedAt     time.Time      `db:"created_at"`
+	UpdatedAt     time.Time      `db:"updated_at"`
 }
 
 type GetProducts struct {
-	ID           string        `db:"id"`
-	Name         string        `db:"name"`
-	Slug         string        `db:"slug"`
-	Description  *string       `db:"description"`
-	BrandName    string        `db:"brand_name"`
-	CategoryName string        `db:"category_name"`
-	Status       ProductStatus `db:"status"`
-	ImageURL     *string       `db:"image_url"`
-	TotalCount   int           `db:"total_count"`
+	ID           string          `db:"id"`
+	Name         string          `db:"name"`
+	Slug         string          `db:"slug"`
+	Description  *string         `db:"description"`
+	BrandName    string          `db:"brand_name"`
+	CategoryName string          `db:"category_name"`
+	Status       ProductStatus   `db:"status"`
+	ImageURL     *string         `db:"image_url"`
+	MinPrice     *shared.Decimal `db:"min_price"`
+	MaxPrice     *shared.Decimal `db:"max_price"`
+	// Standards the product holds a valid certificate for.
+	Certifications pq.StringArray `db:"certifications"`
+	RatingAverage  shared.Decimal `db:"rating_average"`
+	RatingCount    int            `db:"rating_count"`
+	shared.TaxRule
+	CursorKey []byte `db:"cursor_key"`
+}
+
+type ProductPage struct {
+	Products   []GetProducts
+	TotalCount *int
+	Estimated  bool
+	NextCursor *string
+	PrevCursor *string
 }
 
 type ProductAttribute struct {
@@ -55,6 +94,17 @@ type ProductAttribute struct {
 	UpdatedAt      time.Time `db:"updated_at"`
 }
 
+// AttributeDefinition is a typed attribute definition from the schema of a
+// product's category, see migrations/004_category_attributes.sql.
+type AttributeDefinition struct {
+	AttributeKey  string         `db:"attribute_key"`
+	Label         string         `db:"label"`
+	Type          string         `db:"type"`
+	Unit          *string        `db:"unit"`
+	Required      bool           `db:"required"`
+	AllowedValues pq.StringArray `db:"allowed_values"`
+}
+
 type ProductOptions struct {
 	ID        string    `db:"id"`
 	ProductID string    `db:"product_id"`
@@ -93,3 +143,190 @@ type ProductSEO struct {
 	OgImageUrl      string          `db:"og_image_url"`
 	Keywords        json.RawMessage `db:"keywords"`
 }
+
+type ProductBrand struct {
+	ID      string  `db:"id"`
+	Name    string  `db:"name"`
+	Slug    string  `db:"slug"`
+	LogoUrl *string `db:"logo_url"`
+}
+
+type CategoryBreadcrumb struct {
+	ID   string `db:"id"`
+	Name string `db:"name"`
+	Slug string `db:"slug"`
+}
+
+type FacetCount struct {
+	Key   string `db:"key"`
+	Value string `db:"value"`
+	Label string `db:"label"`
+	Count int    `db:"count"`
+}
+
+type PriceBucketCount struct {
+	Bucket int `db:"bucket"`
+	Count  int `db:"count"`
+}
+
+type ProductFacetCounts struct {
+	Brands      []FacetCount
+	Categories  []FacetCount
+	PriceRanges []PriceBucketCount
+	// PriceBounds are PriceFacetBounds in the currency of the listing.
+	PriceBounds    []shared.Decimal
+	Attributes     []FacetCount
+	Certifications []FacetCount
+}
+
+var (
+	ErrImportJobNotFound = errors.New("import job not found")
+	// ErrImportConflict is returned when the product of an import row changed
+	// owner or status after the row was checked.
+	ErrImportConflict = errors.New("product can no longer be updated by this import")
+)
+
+type ImportStatus string
+
+const (
+	ImportPending   ImportStatus = "PENDING"
+	ImportRunning   ImportStatus = "RUNNING"
+	ImportCompleted ImportStatus = "COMPLETED"
+	ImportFailed    ImportStatus = "FAILED"
+)
+
+type ImportJob struct {
+	ID            string          `db:"id"`
+	Format        string          `db:"format"`
+	DryRun        bool            `db:"dry_run"`
+	Status        ImportStatus    `db:"status"`
+	TotalRows     int             `db:"total_rows"`
+	ProcessedRows int             `db:"processed_rows"`
+	SucceededRows int             `db:"succeeded_rows"`
+	FailedRows    int             `db:"failed_rows"`
+	Report        json.RawMessage `db:"report"`
+	Error         *string         `db:"error"`
+	CreatedBy     *string         `db:"created_by"`
+	CreatedAt     time.Time       `db:"created_at"`
+	UpdatedAt     time.Time       `db:"updated_at"`
+	FinishedAt    *time.Time      `db:"finished_at"`
+}
+
+// ImportTarget is the existing product an import row updates.
+type ImportTarget struct {
+	ID       string        `db:"id"`
+	Slug     string        `db:"slug"`
+	SellerID string        `db:"seller_id"`
+	Status   ProductStatus `db:"status"`
+}
+
+// ImportProduct is an import row with brand and category resolved, ready to
+// be upserted. Nil or empty sections leave the stored ones untouched.
+type ImportProduct struct {
+	Slug        string
+	Name        string
+	Description *string
+	SellerID    string
+	BrandID     string
+	CategoryID  string
+	Status      ProductStatus
+	Attributes  []ProductAttributeArray
+	Variants    *VariantRequestDTO
+	Media       []ProductMediaDTO
+	SEO         *ProductSEO
+}
+
+// ExportProduct is a product row of a catalog export, with the sections
+// loaded in batches alongside it.
+type ExportProduct struct {
+	ID           string        `db:"id"`
+	Slug         string        `db:"slug"`
+	Name         string        `db:"name"`
+	Description  *string       `db:"description"`
+	Status       ProductStatus `db:"status"`
+	BrandSlug    *string       `db:"brand_slug"`
+	CategorySlug *string       `db:"category_slug"`
+	Attributes   []ProductAttribute
+	Options      []ProductOptionValue
+	Variants     []ExportVariant
+	Media        []ProductMedia
+	SEO          *ProductSEO
+}
+
+type ExportVariant struct {
+	ProductID    string         `db:"product_id"`
+	SKU          string         `db:"sku"`
+	Price        shared.Decimal `db:"price"`
+	Currency     string         `db:"currency"`
+	Weight       *float64       `db:"weight"`
+	IsActive     bool           `db:"is_active"`
+	OptionNames  pq.StringArray `db:"option_names"`
+	OptionValues pq.StringArray `db:"option_values"`
+	shared.OrderRules
+}
+
+// variantOptions maps the option names of a variant to its values.
+func (v ExportVariant) variantOptions() map[string]string {
+	options := make(map[string]string, len(v.OptionNames))
+	for i, name := range v.OptionNames {
+		if i < len(v.OptionValues) {
+			options[name] = v.OptionValues[i]
+		}
+	}
+	return options
+}
+
+type ExportOption struct {
+	ProductID string         `db:"product_id"`
+	Name      string         `db:"name"`
+	Values    pq.StringArray `db:"values"`
+}
+
+// ExportColumns lists the attribute keys and option names used by the
+// exported products, which become attr:<key> and option:<name> columns.
+type ExportColumns struct {
+	AttributeKeys []string
+	OptionNames   []string
+}
+
+var (
+	ErrProductNotFound = errors.New("product not found")
+	ErrVersionNotFound = errors.New("product version not found")
+)
+
+type ProductVersion struct {
+	ID           string          `db:"id"`
+	ProductID    string          `db:"product_id"`
+	Version      int             `db:"version"`
+	ChangeType   string          `db:"change_type"`
+	ChangedBy    *string         `db:"changed_by"`
+	RestoredFrom *int            `db:"restored_from"`
+	Snapshot     json.RawMessage `db:"snapshot"`
+	Diff         json.RawMessage `db:"diff"`
+	CreatedAt    time.Time       `db:"created_at"`
+}
+
+type Bundle struct {
+	ProductID       string          `db:"product_id"`
+	Pricing         string          `db:"pricing"`
+	DiscountPercent shared.Decimal  `db:"discount_percent"`
+	Price           *shared.Decimal `db:"price"`
+	Currency        string          `db:"currency"`
+}
+
+// BundleComponentVariant is one allowed variant of a bundle component, with
+// the component it belongs to repeated on every row.
+type BundleComponentVariant struct {
+	ComponentID  string         `db:"component_id"`
+	Name         string         `db:"name"`
+	Quantity     int            `db:"quantity"`
+	VariantID    string         `db:"variant_id"`
+	ProductID    string         `db:"product_id"`
+	ProductName  string         `db:"product_name"`
+	SKU          string         `db:"sku"`
+	Price        shared.Decimal `db:"price"`
+	Currency     string         `db:"currency"`
+	OptionValues pq.StringArray `db:"option_values"`
+	IsDefault    bool           `db:"is_default"`
+	Available    bool           `db:"available"`
+}
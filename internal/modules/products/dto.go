@@ -1,55 +1,154 @@
 package products
 
-import "time"
+import (
+	"time"
+
+	"github.com/smart-safety-hub/backend/shared"
+)
 
 type ProductRequestDTO struct {
 	Name        string        `json:"name" validate:"required"`
-	Slug        string        `json:"slug" validate:"required"`
+	Slug        string        `json:"slug"`
 	Description *string       `json:"description"`
-	SellerID    string        `json:"seller_id" validate:"required"`
 	BrandID     string        `json:"brand_id" validate:"required"`
 	CategoryID  string        `json:"category_id" validate:"required"`
-	Status      ProductStatus `json:"status" validate:"required"`
+	Status      ProductStatus `json:"status" validate:"omitempty,oneof=DRAFT PENDING_REVIEW"`
+	Type        ProductKind   `json:"type" validate:"omitempty,oneof=STANDARD BUNDLE"`
 }
 
 type ProductResponseDTO struct {
-	ID          string        `json:"id"`
-	Name        string        `json:"name"`
-	Slug        string        `json:"slug"`
-	Description *string       `json:"description"`
-	SellerID    string        `json:"seller_id"`
-	BrandID     string        `json:"brand_id"`
-	CategoryID  string        `json:"category_id"`
-	Status      ProductStatus `json:"status"`
-	CreatedAt   time.Time     `json:"created_at"`
-	UpdatedAt   time.Time     `json:"updated_at"`
+	ID              string        `json:"id"`
+	Name            string        `json:"name"`
+	Slug            string        `json:"slug"`
+	Description     *string       `json:"description"`
+	SellerID        string        `json:"seller_id"`
+	BrandID         string        `json:"brand_id"`
+	CategoryID      string        `json:"category_id"`
+	Status          ProductStatus `json:"status"`
+	Type            ProductKind   `json:"type"`
+	RejectionReason *string       `json:"rejection_reason,omitempty"`
+	PublishAt       *time.Time    `json:"publish_at,omitempty"`
+	UnpublishAt     *time.Time    `json:"unpublish_at,omitempty"`
+	// ComplianceFlaggedAt is set while a mandatory certificate has lapsed.
+	ComplianceFlaggedAt *time.Time `json:"compliance_flagged_at,omitempty"`
+	// Average and count of the approved reviews.
+	RatingAverage shared.Decimal `json:"rating_average"`
+	RatingCount   int            `json:"rating_count"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	// Composition of BUNDLE products.
+	Bundle *BundleDTO `json:"bundle,omitempty"`
 }
 
 type ProductListResponse struct {
-	Products   []GetProductsDTO `json:"products"`
-	TotalCount int              `json:"total_count"`
-	Page       int              `json:"page"`
-	Limit      int              `json:"limit"`
+	Products       []GetProductsDTO `json:"products"`
+	TotalCount     *int             `json:"total_count"`
+	TotalEstimated bool             `json:"total_estimated,omitempty"`
+	Page           int              `json:"page,omitempty"`
+	Limit          int              `json:"limit"`
+	NextCursor     *string          `json:"next_cursor"`
+	PrevCursor     *string          `json:"prev_cursor"`
+	Facets         *ProductFacets   `json:"facets,omitempty"`
+	// Currency of the prices and price facets.
+	Currency string `json:"currency"`
+}
+
+// Facet names used to exclude a facet's own selection from its counts.
+// Attribute facets are named "attr:<key>".
+const (
+	FacetBrand    = "brand"
+	FacetCategory = "category"
+	FacetPrice    = "price"
+	// FacetCertification counts the standards products hold a valid
+	// certificate for.
+	FacetCertification = "certification"
+)
+
+func attributeFacet(key string) string {
+	return "attr:" + key
+}
+
+// PriceFacetBounds are the upper bounds of the price range buckets in the
+// store currency, applied to the lowest active variant price of each product.
+// They are converted into the currency of the listing.
+var PriceFacetBounds = []shared.Decimal{
+	shared.NewDecimal(500), shared.NewDecimal(1000), shared.NewDecimal(2500), shared.NewDecimal(5000), shared.NewDecimal(10000),
+}
+
+type FacetBucket struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+type PriceRangeBucket struct {
+	Min   shared.Decimal  `json:"min"`
+	Max   *shared.Decimal `json:"max"`
+	Count int             `json:"count"`
+}
+
+type ProductFacets struct {
+	Brands      []FacetBucket            `json:"brands"`
+	Categories  []FacetBucket            `json:"categories"`
+	PriceRanges []PriceRangeBucket       `json:"price_ranges"`
+	Attributes  map[string][]FacetBucket `json:"attributes"`
+	// Certifications are keyed by the upper-cased standard.
+	Certifications []FacetBucket `json:"certifications"`
 }
 
 type GetProductsDTO struct {
-	ID           string        `json:"id"`
-	Name         string        `json:"name"`
-	Slug         string        `json:"slug"`
-	Description  *string       `json:"description"`
-	BrandName    string        `json:"brand_name"`
-	CategoryName string        `json:"category_name"`
-	Status       ProductStatus `json:"status"`
-	ImageURL     *string       `json:"image_url"`
+	ID           string          `json:"id"`
+	Name         string          `json:"name"`
+	Slug         string          `json:"slug"`
+	Description  *string         `json:"description"`
+	BrandName    string          `json:"brand_name"`
+	CategoryName string          `json:"category_name"`
+	Status       ProductStatus   `json:"status"`
+	ImageURL     *string         `json:"image_url"`
+	MinPrice     *shared.Decimal `json:"min_price"`
+	MaxPrice     *shared.Decimal `json:"max_price"`
+	// Tax-inclusive bounds and the GST rate; null without a tax class.
+	MinPriceInclTax *shared.Decimal `json:"min_price_incl_tax"`
+	MaxPriceInclTax *shared.Decimal `json:"max_price_incl_tax"`
+	TaxRate         *shared.Decimal `json:"tax_rate"`
+	// Standards the product holds a valid certificate for.
+	Certifications []string `json:"certifications"`
+	// Average and count of the approved reviews.
+	RatingAverage shared.Decimal `json:"rating_average"`
+	RatingCount   int            `json:"rating_count"`
 }
 
+// ProductVariant names its option values either by option, in Options, or as
+// a plain list in OptionValues. Options is needed when two options share a
+// value and the list would be ambiguous.
 type ProductVariant struct {
-	ID           *string  `json:"id"`
-	SKU          string   `json:"sku" validate:"required,max=100"`
-	Price        float64  `json:"price" validate:"required,gte=0"`
-	Weight       float64  `json:"weight" validate:"gte=0"`
-	IsActive     bool     `json:"is_active"`
-	OptionValues []string `json:"option_values" validate:"required,dive"`
+	ID    *string        `json:"id"`
+	SKU   string         `json:"sku" validate:"required,max=100"`
+	Price shared.Decimal `json:"price" validate:"required,gte=0"`
+	// Currency of Price and ListPrice; the store currency when omitted on save.
+	Currency string `json:"currency,omitempty" validate:"omitempty,len=3,uppercase"`
+	// ListPrice is read-only: the catalog price when Price is a contract price.
+	ListPrice    *shared.Decimal   `json:"list_price,omitempty"`
+	Weight       float64           `json:"weight" validate:"gte=0"`
+	IsActive     bool              `json:"is_active"`
+	Options      map[string]string `json:"options,omitempty"`
+	OptionValues []string          `json:"option_values" validate:"required_without=Options,dive"`
+	// Unit, minimum order quantity, order multiple and pack sizes; Price is
+	// per unit.
+	shared.OrderRules
+	// PriceTiers is read-only here; tiers are managed by the pricing module.
+	PriceTiers []VariantPriceTier `json:"price_tiers,omitempty"`
+	// PriceInclTax and Tax are read-only: Price is tax-exclusive, these add
+	// the GST of the product's tax class for the buyer's state.
+	PriceInclTax *shared.Decimal `json:"price_incl_tax,omitempty"`
+	Tax          *shared.Tax     `json:"tax,omitempty"`
+}
+
+// VariantPriceTier is a quantity break of a variant.
+type VariantPriceTier struct {
+	MinQuantity int            `json:"min_quantity"`
+	MaxQuantity *int           `json:"max_quantity"`
+	UnitPrice   shared.Decimal `json:"unit_price"`
 }
 
 type ProductOptionValue struct {
@@ -63,6 +162,52 @@ type VariantRequestDTO struct {
 	Variants  []ProductVariant     `json:"variants" validate:"required,dive"`
 }
 
+// VariantMatrixRequestDTO generates one variant per combination of option
+// values. SKUTemplate placeholders are {BRAND}, {PRODUCT} and one per option
+// named after it, e.g. {SIZE} or {COLOUR}; option values are written in upper
+// case unless Codes (option name to value to code) gives a shorter code.
+type VariantMatrixRequestDTO struct {
+	Options     []ProductOptionValue `json:"options" validate:"required,min=1,dive"`
+	SKUTemplate string               `json:"sku_template" validate:"required,max=200"`
+	Price       shared.Decimal       `json:"price" validate:"gt=0"`
+	// Currency of Price for new variants; the store currency when omitted.
+	Currency string                       `json:"currency" validate:"omitempty,len=3,uppercase"`
+	Weight   float64                      `json:"weight" validate:"gte=0"`
+	Codes    map[string]map[string]string `json:"codes"`
+	// Order rules of new variants.
+	shared.OrderRules
+}
+
+// What a matrix does to each variant.
+const (
+	MatrixCreate     = "create"
+	MatrixKeep       = "keep"
+	MatrixDeactivate = "deactivate"
+)
+
+type MatrixVariant struct {
+	ID           *string           `json:"id,omitempty"`
+	SKU          string            `json:"sku"`
+	Price        shared.Decimal    `json:"price"`
+	Currency     string            `json:"currency,omitempty"`
+	Weight       float64           `json:"weight"`
+	IsActive     bool              `json:"is_active"`
+	Options      map[string]string `json:"options"`
+	OptionValues []string          `json:"option_values"`
+	shared.OrderRules
+	Action string `json:"action"`
+}
+
+type VariantMatrixResponse struct {
+	ProductID   string               `json:"product_id"`
+	DryRun      bool                 `json:"dry_run"`
+	Options     []ProductOptionValue `json:"options"`
+	Variants    []MatrixVariant      `json:"variants"`
+	Created     int                  `json:"created"`
+	Kept        int                  `json:"kept"`
+	Deactivated int                  `json:"deactivated"`
+}
+
 type ProductAttributeDTO struct {
 	ProductID  string                  `json:"product_id" validate:"required"`
 	Attributes []ProductAttributeArray `json:"attributes" validate:"required"`
@@ -96,15 +241,84 @@ type ProductSEODTO struct {
 	Keywords        []string `json:"keywords"`
 }
 
+// Sort orders accepted by GetAllProducts.
+const (
+	SortNewest    = "newest"
+	SortRelevance = "relevance"
+	SortPriceAsc  = "price_asc"
+	SortPriceDesc = "price_desc"
+	SortName      = "name"
+	// SortRating orders by average rating, then by review count.
+	SortRating = "rating"
+)
+
+var ProductSortOrders = []string{SortNewest, SortRelevance, SortPriceAsc, SortPriceDesc, SortName, SortRating}
+
+// Page sizes of GetAllProducts: DefaultProductLimit when the caller gives
+// none, and never more than MaxProductLimit.
+const (
+	DefaultProductLimit = 40
+	MaxProductLimit     = 100
+)
+
 type ProductFilters struct {
 	Category []string `query:"category"`
-	Brand    []string `query:"brand"`
-	Search   string   `query:"search"`
-	Status   string   `query:"status"`
-	MinPrice float64  `query:"min_price"`
-	MaxPrice float64  `query:"max_price"`
-	Page     int      `query:"page"`
-	Limit    int      `query:"limit"`
+	// IncludeDescendants also matches products in subcategories of Category.
+	IncludeDescendants bool     `query:"include_descendants"`
+	Brand              []string `query:"brand"`
+	// Certification matches products holding a valid certificate for any of
+	// the standards, case-insensitively.
+	Certification []string `query:"certification"`
+	Search        string   `query:"search"`
+	Status        string   `query:"status"`
+	Sort          string   `query:"sort"`
+	// Attributes holds attr[<key>]=<value> filters; values of one key are ORed.
+	Attributes map[string][]string `query:"attr"`
+	// Facets requests facet counts alongside the page of products.
+	Facets   bool           `query:"facets"`
+	MinPrice shared.Decimal `query:"min_price"`
+	MaxPrice shared.Decimal `query:"max_price"`
+	// MinRating matches products whose average rating is at least this.
+	MinRating shared.Decimal `query:"min_rating"`
+	// Currency prices are shown and filtered in; the store currency when empty.
+	Currency string `query:"currency"`
+	// Page > 0 selects LIMIT/OFFSET paging, otherwise the page starts at Cursor.
+	Page   int    `query:"page"`
+	Limit  int    `query:"limit"`
+	Cursor string `query:"cursor"`
+	// Count is one of shared.CountModes, exact by default.
+	Count string `query:"count"`
+	// ShipTo is the GST state code tax-inclusive prices are computed for; the
+	// signed-in buyer's state when empty.
+	ShipTo string `query:"ship_to"`
+	// CompanyID is the company of the signed-in buyer; prices are resolved
+	// through its price list. Set by the service, never from the query.
+	CompanyID *string `query:"-"`
+	// SellerID restricts the listing to one seller's products. Set by the
+	// service, never from the query.
+	SellerID *string `query:"-"`
+}
+
+// PriceOptions are the pricing parameters of the variant and detail
+// endpoints: the currency to price in and the GST state code to ship to.
+type PriceOptions struct {
+	Currency string
+	ShipTo   string
+}
+
+// EffectiveSort is the sort order applied to the listing: relevance when
+// searching without an explicit sort, newest otherwise.
+func (f ProductFilters) EffectiveSort() string {
+	switch {
+	case f.Sort == SortRelevance && f.Search == "":
+		return SortNewest
+	case f.Sort != "":
+		return f.Sort
+	case f.Search != "":
+		return SortRelevance
+	default:
+		return SortNewest
+	}
 }
 
 type GetProductByID struct {
@@ -121,6 +335,289 @@ type GetProductAttributeByProductID struct {
 
 type GenericResponseDTO struct {
 	ID      *string `json:"product_id"`
+	Slug    string  `json:"slug,omitempty"`
 	Status  string  `json:"success"`
 	Message string  `json:"message"`
 }
+
+// Sections of the product detail document that can be requested through
+// the include= (or fields=) query parameter.
+const (
+	DetailBrand      = "brand"
+	DetailBreadcrumb = "breadcrumb"
+	DetailAttributes = "attributes"
+	DetailVariants   = "variants"
+	DetailMedia      = "media"
+	DetailSEO        = "seo"
+)
+
+var ProductDetailSections = []string{DetailBrand, DetailBreadcrumb, DetailAttributes, DetailVariants, DetailMedia, DetailSEO}
+
+type ProductBrandDTO struct {
+	ID      string  `json:"id"`
+	Name    string  `json:"name"`
+	Slug    string  `json:"slug"`
+	LogoUrl *string `json:"logo_url"`
+}
+
+type CategoryBreadcrumbDTO struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type ProductDetailDTO struct {
+	ProductResponseDTO
+	Brand      *ProductBrandDTO        `json:"brand,omitempty"`
+	Breadcrumb []CategoryBreadcrumbDTO `json:"breadcrumb,omitempty"`
+	Attributes []ProductAttributeArray `json:"attributes,omitempty"`
+	Options    []ProductOptionValue    `json:"options,omitempty"`
+	Variants   []ProductVariant        `json:"variants,omitempty"`
+	Media      []ProductMediaDTO       `json:"media,omitempty"`
+	SEO        *ProductSEODTO          `json:"seo,omitempty"`
+}
+
+// Import file formats accepted by ImportProducts.
+const (
+	ImportCSV   = "csv"
+	ImportJSONL = "jsonl"
+)
+
+// ImportSyncRowLimit is the largest import, in products, processed within the
+// request; larger files run as a background job.
+const ImportSyncRowLimit = 50
+
+// ImportRow is one product of an import file. In JSON Lines each line is one
+// ImportRow; in CSV each line is one variant and lines sharing a slug are
+// grouped into one product.
+type ImportRow struct {
+	Line        int                  `json:"-"`
+	Slug        string               `json:"slug"`
+	Name        string               `json:"name"`
+	Description *string              `json:"description"`
+	Brand       string               `json:"brand"`
+	Category    string               `json:"category"`
+	Status      ProductStatus        `json:"status"`
+	Attributes  map[string]string    `json:"attributes"`
+	Options     []ProductOptionValue `json:"options"`
+	Variants    []ImportVariant      `json:"variants"`
+	Media       []ImportMedia        `json:"media"`
+	SEO         *ImportSEO           `json:"seo"`
+	// Err is set when the row could not be parsed.
+	Err error `json:"-"`
+}
+
+type ImportVariant struct {
+	SKU          string            `json:"sku"`
+	Price        shared.Decimal    `json:"price"`
+	Currency     string            `json:"currency,omitempty"`
+	Weight       float64           `json:"weight"`
+	IsActive     *bool             `json:"is_active"`
+	Options      map[string]string `json:"options,omitempty"`
+	OptionValues []string          `json:"option_values"`
+	shared.OrderRules
+}
+
+type ImportMedia struct {
+	Url          string      `json:"url"`
+	Type         ProductType `json:"type"`
+	DisplayOrder int         `json:"display_order"`
+}
+
+type ImportSEO struct {
+	MetaTitle       string   `json:"meta_title"`
+	MetaDescription string   `json:"meta_description"`
+	OgImageUrl      string   `json:"og_image_url"`
+	Keywords        []string `json:"keywords"`
+}
+
+// Actions reported for each import row.
+const (
+	ImportActionCreate = "create"
+	ImportActionUpdate = "update"
+)
+
+type ImportRowResult struct {
+	Line      int      `json:"line"`
+	Slug      string   `json:"slug"`
+	Action    string   `json:"action,omitempty"`
+	Success   bool     `json:"success"`
+	ProductID *string  `json:"product_id,omitempty"`
+	Errors    []string `json:"errors,omitempty"`
+}
+
+type ImportJobDTO struct {
+	ID            string            `json:"id"`
+	Format        string            `json:"format"`
+	DryRun        bool              `json:"dry_run"`
+	Status        ImportStatus      `json:"status"`
+	TotalRows     int               `json:"total_rows"`
+	ProcessedRows int               `json:"processed_rows"`
+	SucceededRows int               `json:"succeeded_rows"`
+	FailedRows    int               `json:"failed_rows"`
+	Report        []ImportRowResult `json:"report"`
+	Error         *string           `json:"error"`
+	CreatedAt     time.Time         `json:"created_at"`
+	FinishedAt    *time.Time        `json:"finished_at"`
+}
+
+// Change types recorded on product versions.
+const (
+	ChangeCreate     = "create"
+	ChangeUpdate     = "update"
+	ChangeStatus     = "status"
+	ChangeAttributes = "attributes"
+	ChangeVariants   = "variants"
+	ChangeMedia      = "media"
+	ChangeSEO        = "seo"
+	ChangeImport     = "import"
+	ChangeRestore    = "restore"
+	ChangeWorkflow   = "workflow"
+)
+
+// ProductSnapshot is the full product aggregate as stored in a version.
+// Variants and media refer to variants by SKU so a snapshot stays valid when
+// variant ids change.
+type ProductSnapshot struct {
+	Name        string                  `json:"name"`
+	Slug        string                  `json:"slug"`
+	Description *string                 `json:"description"`
+	SellerID    string                  `json:"seller_id"`
+	BrandID     *string                 `json:"brand_id"`
+	CategoryID  *string                 `json:"category_id"`
+	Status      ProductStatus           `json:"status"`
+	Attributes  []ProductAttributeArray `json:"attributes"`
+	Options     []ProductOptionValue    `json:"options"`
+	Variants    []SnapshotVariant       `json:"variants"`
+	Media       []SnapshotMedia         `json:"media"`
+	SEO         *ProductSEODTO          `json:"seo"`
+}
+
+type SnapshotVariant struct {
+	SKU          string            `json:"sku"`
+	Price        shared.Decimal    `json:"price"`
+	Currency     string            `json:"currency,omitempty"`
+	Weight       *float64          `json:"weight"`
+	IsActive     bool              `json:"is_active"`
+	Options      map[string]string `json:"options,omitempty"`
+	OptionValues []string          `json:"option_values"`
+	shared.OrderRules
+}
+
+type SnapshotMedia struct {
+	Url          string      `json:"url"`
+	Type         ProductType `json:"type"`
+	DisplayOrder int         `json:"display_order"`
+	VariantSKU   *string     `json:"variant_sku"`
+}
+
+// DiffEntry is one changed field. Old is null for added fields and New is
+// null for removed ones.
+type DiffEntry struct {
+	Path string  `json:"path"`
+	Old  *string `json:"old"`
+	New  *string `json:"new"`
+}
+
+type ProductVersionDTO struct {
+	Version      int              `json:"version"`
+	ChangeType   string           `json:"change_type"`
+	ChangedBy    *string          `json:"changed_by"`
+	RestoredFrom *int             `json:"restored_from,omitempty"`
+	CreatedAt    time.Time        `json:"created_at"`
+	Diff         []DiffEntry      `json:"diff"`
+	Snapshot     *ProductSnapshot `json:"snapshot,omitempty"`
+}
+
+type ProductVersionListResponse struct {
+	ProductID string              `json:"product_id"`
+	Versions  []ProductVersionDTO `json:"versions"`
+}
+
+type ProductVersionDiffResponse struct {
+	ProductID string      `json:"product_id"`
+	From      int         `json:"from"`
+	To        int         `json:"to"`
+	Diff      []DiffEntry `json:"diff"`
+}
+
+// WorkflowRequestDTO carries the optional inputs of a workflow action. Reason
+// is required to reject; PublishAt and UnpublishAt are read on approve.
+type WorkflowRequestDTO struct {
+	Reason      string     `json:"reason" validate:"max=1000"`
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+}
+
+// BundleRequestDTO sets the composition of a bundle. Each component lists the
+// SKUs the buyer may choose from; DefaultSKU, the first SKU when empty, is
+// the one the bundle is priced and shown with.
+type BundleRequestDTO struct {
+	Pricing         string                      `json:"pricing" validate:"required,oneof=FIXED COMPONENTS"`
+	DiscountPercent shared.Decimal              `json:"discount_percent"`
+	Components      []BundleComponentRequestDTO `json:"components" validate:"required,min=1,dive"`
+}
+
+type BundleComponentRequestDTO struct {
+	Name       string   `json:"name" validate:"required,max=100"`
+	Quantity   int      `json:"quantity" validate:"gte=1"`
+	SKUs       []string `json:"skus" validate:"required,min=1,dive,required"`
+	DefaultSKU string   `json:"default_sku"`
+}
+
+// BundleDTO is the composition of a bundle. Price is the bundle price at the
+// default variants for COMPONENTS pricing and nil for FIXED pricing, where
+// the bundle's own variants carry the price. A bundle is available while
+// every component has an available variant.
+type BundleDTO struct {
+	Pricing         string               `json:"pricing"`
+	DiscountPercent shared.Decimal       `json:"discount_percent"`
+	Price           *shared.Decimal      `json:"price"`
+	Currency        string               `json:"currency"`
+	Available       bool                 `json:"available"`
+	Components      []BundleComponentDTO `json:"components"`
+}
+
+type BundleComponentDTO struct {
+	ID        string             `json:"id"`
+	Name      string             `json:"name"`
+	Quantity  int                `json:"quantity"`
+	Available bool               `json:"available"`
+	Variants  []BundleVariantDTO `json:"variants"`
+}
+
+type BundleVariantDTO struct {
+	VariantID    string         `json:"variant_id"`
+	ProductID    string         `json:"product_id"`
+	ProductName  string         `json:"product_name"`
+	SKU          string         `json:"sku"`
+	Price        shared.Decimal `json:"price"`
+	Currency     string         `json:"currency"`
+	OptionValues []string       `json:"option_values"`
+	IsDefault    bool           `json:"is_default"`
+	Available    bool           `json:"available"`
+}
+
+// BundleSelectionDTO picks a variant, by SKU, for components of a kit by
+// component name; components left out get their default.
+type BundleSelectionDTO struct {
+	Selections map[string]string `json:"selections"`
+}
+
+// BundleConfigurationDTO is a kit as the buyer configured it. Price is nil for
+// FIXED pricing.
+type BundleConfigurationDTO struct {
+	ProductID  string                  `json:"product_id"`
+	Price      *shared.Decimal         `json:"price"`
+	Currency   string                  `json:"currency"`
+	Available  bool                    `json:"available"`
+	Components []BundleChosenComponent `json:"components"`
+}
+
+type BundleChosenComponent struct {
+	Name      string `json:"name"`
+	Quantity  int    `json:"quantity"`
+	VariantID string `json:"variant_id"`
+	SKU       string `json:"sku"`
+	Available bool   `json:"available"`
+}
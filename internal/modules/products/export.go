@@ -0,0 +1,211 @@
+package products
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+// Export file formats. CSV and XLSX use the flat layout of exportHeader, one
+// line per variant, which ImportProducts reads back; JSON Lines writes one
+// ImportRow per product.
+const (
+	ExportCSV   = ImportCSV
+	ExportJSONL = ImportJSONL
+	ExportXLSX  = "xlsx"
+)
+
+var ExportFormats = []string{ExportCSV, ExportJSONL, ExportXLSX}
+
+// exportBatchSize is how many products are loaded and written at a time.
+const exportBatchSize = 200
+
+// ExportContentType returns the Content-Type and file extension of a format.
+func ExportContentType(format string) (string, string) {
+	switch format {
+	case ExportXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "xlsx"
+	case ExportJSONL:
+		return "application/x-ndjson", "jsonl"
+	default:
+		return "text/csv; charset=utf-8", "csv"
+	}
+}
+
+// exportWriter is the row sink shared by csv.Writer and shared.XLSXWriter.
+type exportWriter interface {
+	Write(record []string) error
+}
+
+// exportHeader is the flat column layout: the fixed csvColumns followed by one
+// attr:<key> column per attribute and one option:<name> column per option.
+// Product fields are repeated on every variant line; products without
+// variants get a single line with empty variant columns.
+func exportHeader(columns *ExportColumns) []string {
+	header := slices.Clone(csvColumns)
+	for _, key := range columns.AttributeKeys {
+		header = append(header, "attr:"+key)
+	}
+	for _, name := range columns.OptionNames {
+		header = append(header, "option:"+name)
+	}
+	return header
+}
+
+func exportRecords(columns *ExportColumns, product ExportProduct) [][]string {
+	base := make([]string, len(csvColumns), len(csvColumns)+len(columns.AttributeKeys)+len(columns.OptionNames))
+	base[csvColumn("slug")] = product.Slug
+	base[csvColumn("name")] = product.Name
+	base[csvColumn("description")] = stringValue(product.Description)
+	base[csvColumn("brand")] = stringValue(product.BrandSlug)
+	base[csvColumn("category")] = stringValue(product.CategorySlug)
+	base[csvColumn("status")] = string(product.Status)
+
+	urls := make([]string, 0, len(product.Media))
+	for _, media := range product.Media {
+		urls = append(urls, media.Url)
+	}
+	base[csvColumn("media")] = strings.Join(urls, "|")
+
+	if product.SEO != nil {
+		var keywords []string
+		_ = json.Unmarshal(product.SEO.Keywords, &keywords)
+		base[csvColumn("meta_title")] = product.SEO.MetaTitle
+		base[csvColumn("meta_description")] = product.SEO.MetaDescription
+		base[csvColumn("og_image_url")] = product.SEO.OgImageUrl
+		base[csvColumn("keywords")] = strings.Join(keywords, "|")
+	}
+
+	attributes := make(map[string]string, len(product.Attributes))
+	for _, attribute := range product.Attributes {
+		attributes[attribute.AttributeKey] = attribute.AttributeValue
+	}
+	for _, key := range columns.AttributeKeys {
+		base = append(base, attributes[key])
+	}
+
+	if len(product.Variants) == 0 {
+		return [][]string{append(base, make([]string, len(columns.OptionNames))...)}
+	}
+
+	records := make([][]string, 0, len(product.Variants))
+	for _, variant := range product.Variants {
+		record := slices.Clone(base)
+		record[csvColumn("sku")] = variant.SKU
+		record[csvColumn("price")] = variant.Price.String()
+		record[csvColumn("currency")] = variant.Currency
+		if variant.Weight != nil {
+			record[csvColumn("weight")] = strconv.FormatFloat(*variant.Weight, 'f', -1, 64)
+		}
+		record[csvColumn("is_active")] = strconv.FormatBool(variant.IsActive)
+		rules := variant.OrderRules.Normalized()
+		record[csvColumn("unit")] = rules.Unit
+		record[csvColumn("min_order_quantity")] = strconv.Itoa(rules.MinOrderQuantity)
+		record[csvColumn("order_multiple")] = strconv.Itoa(rules.OrderMultiple)
+		record[csvColumn("pack_sizes")] = rules.PackSizes.String()
+
+		options := variant.variantOptions()
+		for _, name := range columns.OptionNames {
+			record = append(record, options[name])
+		}
+		records = append(records, record)
+	}
+
+	return records
+}
+
+// exportRow turns a product into the JSON Lines shape accepted by the importer.
+func exportRow(product ExportProduct) ImportRow {
+	row := ImportRow{
+		Slug:        product.Slug,
+		Name:        product.Name,
+		Description: product.Description,
+		Brand:       stringValue(product.BrandSlug),
+		Category:    stringValue(product.CategorySlug),
+		Status:      product.Status,
+		Attributes:  make(map[string]string, len(product.Attributes)),
+		Options:     product.Options,
+	}
+
+	for _, attribute := range product.Attributes {
+		row.Attributes[attribute.AttributeKey] = attribute.AttributeValue
+	}
+
+	for _, variant := range product.Variants {
+		isActive := variant.IsActive
+		weight := 0.0
+		if variant.Weight != nil {
+			weight = *variant.Weight
+		}
+		row.Variants = append(row.Variants, ImportVariant{
+			SKU:          variant.SKU,
+			Price:        variant.Price,
+			Currency:     variant.Currency,
+			Weight:       weight,
+			IsActive:     &isActive,
+			Options:      variant.variantOptions(),
+			OptionValues: variant.OptionValues,
+			OrderRules:   variant.OrderRules,
+		})
+	}
+
+	for _, media := range product.Media {
+		row.Media = append(row.Media, ImportMedia{
+			Url:          media.Url,
+			Type:         media.Type,
+			DisplayOrder: media.DisplayOrder,
+		})
+	}
+
+	if product.SEO != nil {
+		var keywords []string
+		_ = json.Unmarshal(product.SEO.Keywords, &keywords)
+		row.SEO = &ImportSEO{
+			MetaTitle:       product.SEO.MetaTitle,
+			MetaDescription: product.SEO.MetaDescription,
+			OgImageUrl:      product.SEO.OgImageUrl,
+			Keywords:        keywords,
+		}
+	}
+
+	return row
+}
+
+// newExportWriter wraps w for a tabular format. The returned flush function
+// pushes buffered rows to w and the close function finishes the file.
+func newExportWriter(format string, w io.Writer) (exportWriter, func() error, func() error, error) {
+	switch format {
+	case ExportCSV:
+		cw := csv.NewWriter(w)
+		flush := func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+		return cw, flush, flush, nil
+	case ExportXLSX:
+		xw, err := shared.NewXLSXWriter(w, "Products", csvColumn("price"), csvColumn("weight"))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return xw, xw.Flush, xw.Close, nil
+	default:
+		return nil, nil, nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+func csvColumn(name string) int {
+	return slices.Index(csvColumns, name)
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,412 @@
+package products
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+
+	catalogv1 "github.com/smart-safety-hub/backend/proto/catalog/v1"
+	"github.com/smart-safety-hub/backend/shared"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type GrpcHandler struct {
+	catalogv1.UnimplementedProductServiceServer
+	service *ProductService
+}
+
+func NewGrpcHandler(service *ProductService) *GrpcHandler {
+	return &GrpcHandler{
+		service: service,
+	}
+}
+
+func (h *GrpcHandler) GetProduct(ctx context.Context, request *catalogv1.GetProductRequest) (*catalogv1.Product, error) {
+	if request.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "ID is required")
+	}
+
+	response, err := h.service.GetProductByID(ctx, request.GetId())
+	if err != nil {
+		return nil, status.Error(productErrorCode(err), err.Error())
+	}
+
+	return toProtoProduct(*response), nil
+}
+
+func (h *GrpcHandler) GetProductBySlug(ctx context.Context, request *catalogv1.GetProductBySlugRequest) (*catalogv1.Product, error) {
+	if request.GetSlug() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Slug is required")
+	}
+
+	response, err := h.service.GetProductBySlug(ctx, request.GetSlug())
+
+	// gRPC has no redirects: an old slug resolves to the product under its
+	// current slug, which the caller can see in the response
+	var moved *shared.SlugMovedError
+	if errors.As(err, &moved) {
+		response, err = h.service.GetProductBySlug(ctx, moved.Slug)
+	}
+	if err != nil {
+		return nil, status.Error(productErrorCode(err), err.Error())
+	}
+
+	return toProtoProduct(*response), nil
+}
+
+func (h *GrpcHandler) ListProducts(ctx context.Context, request *catalogv1.ListProductsRequest) (*catalogv1.ListProductsResponse, error) {
+	filters := ProductFilters{
+		Category: request.GetCategory(),
+		// Subcategories are matched unless include_descendants is set to false
+		IncludeDescendants: request.IncludeDescendants == nil || request.GetIncludeDescendants(),
+		Brand:              request.GetBrand(),
+		Certification:      request.GetCertification(),
+		Search:             request.GetSearch(),
+		Status:             request.GetStatus(),
+		Sort:               request.GetSort(),
+		MinRating:          shared.DecimalFromFloat(request.GetMinRating()),
+		Currency:           request.GetCurrency(),
+		ShipTo:             request.GetShipTo(),
+		Attributes:         make(map[string][]string),
+		Facets:             request.GetFacets(),
+		Cursor:             request.GetCursor(),
+		Count:              request.GetCount(),
+		Page:               int(request.GetPage()),
+		Limit:              DefaultProductLimit,
+	}
+
+	if minPrice := request.GetMinPrice(); minPrice != "" {
+		price, err := shared.ParseDecimal(minPrice)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "Invalid min_price")
+		}
+		filters.MinPrice = price
+	}
+
+	if maxPrice := request.GetMaxPrice(); maxPrice != "" {
+		price, err := shared.ParseDecimal(maxPrice)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "Invalid max_price")
+		}
+		filters.MaxPrice = price
+	}
+
+	if filters.MinPrice < 0 || filters.MaxPrice < 0 || (filters.MaxPrice > 0 && filters.MinPrice > filters.MaxPrice) {
+		return nil, status.Error(codes.InvalidArgument, "Invalid price range")
+	}
+
+	if filters.MinRating < 0 || filters.MinRating > shared.NewDecimal(5) {
+		return nil, status.Error(codes.InvalidArgument, "min_rating must be between 0 and 5")
+	}
+
+	for _, attribute := range request.GetAttributes() {
+		filters.Attributes[attribute.GetKey()] = append(filters.Attributes[attribute.GetKey()], attribute.GetValues()...)
+	}
+
+	if filters.Sort != "" && !slices.Contains(ProductSortOrders, filters.Sort) {
+		return nil, status.Error(codes.InvalidArgument, "Invalid sort, allowed: "+strings.Join(ProductSortOrders, ","))
+	}
+
+	if request.GetLimit() > 0 {
+		filters.Limit = min(int(request.GetLimit()), MaxProductLimit)
+	}
+
+	if filters.Count != "" && !slices.Contains(shared.CountModes, filters.Count) {
+		return nil, status.Error(codes.InvalidArgument, "Invalid count, allowed: "+strings.Join(shared.CountModes, ","))
+	}
+
+	if filters.Cursor != "" && filters.Page <= 0 {
+		cursor, err := shared.DecodeCursor(filters.Cursor)
+		if err != nil || cursor.Sort != filters.EffectiveSort() {
+			return nil, status.Error(codes.InvalidArgument, "Invalid cursor")
+		}
+	}
+
+	response, err := h.service.GetAllProducts(ctx, filters)
+	if err != nil {
+		return nil, status.Error(productErrorCode(err), err.Error())
+	}
+
+	products := make([]*catalogv1.ProductSummary, 0, len(response.Products))
+	for _, data := range response.Products {
+		products = append(products, &catalogv1.ProductSummary{
+			Id:              data.ID,
+			Name:            data.Name,
+			Slug:            data.Slug,
+			Description:     data.Description,
+			BrandName:       data.BrandName,
+			CategoryName:    data.CategoryName,
+			Status:          string(data.Status),
+			ImageUrl:        data.ImageURL,
+			MinPrice:        decimalString(data.MinPrice),
+			MaxPrice:        decimalString(data.MaxPrice),
+			Currency:        response.Currency,
+			MinPriceInclTax: decimalString(data.MinPriceInclTax),
+			MaxPriceInclTax: decimalString(data.MaxPriceInclTax),
+			Certifications:  data.Certifications,
+			RatingAverage:   data.RatingAverage.Float64(),
+			RatingCount:     int32(data.RatingCount),
+		})
+	}
+
+	listResponse := &catalogv1.ListProductsResponse{
+		Products:       products,
+		TotalEstimated: response.TotalEstimated,
+		Page:           int32(response.Page),
+		Limit:          int32(response.Limit),
+		NextCursor:     response.NextCursor,
+		PrevCursor:     response.PrevCursor,
+		Facets:         toProtoFacets(response.Facets),
+		Currency:       response.Currency,
+	}
+
+	if response.TotalCount != nil {
+		total := int32(*response.TotalCount)
+		listResponse.TotalCount = &total
+	}
+
+	return listResponse, nil
+}
+
+func (h *GrpcHandler) GetProductAttributes(ctx context.Context, request *catalogv1.GetProductAttributesRequest) (*catalogv1.GetProductAttributesResponse, error) {
+	if request.GetProductId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "ID is required")
+	}
+
+	response, err := h.service.GetProductAttributeByID(ctx, request.GetProductId())
+	if err != nil {
+		return nil, status.Error(productErrorCode(err), err.Error())
+	}
+
+	attributes := make([]*catalogv1.ProductAttribute, 0, len(response.Attributes))
+	for _, data := range response.Attributes {
+		attributes = append(attributes, &catalogv1.ProductAttribute{
+			Key:   data.AttributeKey,
+			Value: data.AttributeValue,
+		})
+	}
+
+	return &catalogv1.GetProductAttributesResponse{
+		ProductId:  response.ProductID,
+		Attributes: attributes,
+	}, nil
+}
+
+func (h *GrpcHandler) GetProductVariants(ctx context.Context, request *catalogv1.GetProductVariantsRequest) (*catalogv1.GetProductVariantsResponse, error) {
+	if request.GetProductId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "ID is required")
+	}
+
+	priceOptions := PriceOptions{Currency: request.GetCurrency(), ShipTo: request.GetShipTo()}
+	response, err := h.service.GetProductVariants(ctx, request.GetProductId(), priceOptions)
+	if err != nil {
+		return nil, status.Error(productErrorCode(err), err.Error())
+	}
+
+	options := make([]*catalogv1.ProductOption, 0, len(response.Options))
+	for _, data := range response.Options {
+		options = append(options, &catalogv1.ProductOption{
+			Name:   data.Name,
+			Values: data.Values,
+		})
+	}
+
+	variants := make([]*catalogv1.ProductVariant, 0, len(response.Variants))
+	for _, data := range response.Variants {
+		rules := data.OrderRules.Normalized()
+		packSizes := make([]*catalogv1.PackSize, 0, len(rules.PackSizes))
+		for _, pack := range rules.PackSizes {
+			packSizes = append(packSizes, &catalogv1.PackSize{Unit: pack.Unit, Quantity: int32(pack.Quantity)})
+		}
+		variants = append(variants, &catalogv1.ProductVariant{
+			Id:               data.ID,
+			Sku:              data.SKU,
+			Price:            data.Price.String(),
+			Currency:         data.Currency,
+			PriceInclTax:     decimalString(data.PriceInclTax),
+			Weight:           data.Weight,
+			IsActive:         data.IsActive,
+			OptionValues:     data.OptionValues,
+			Unit:             rules.Unit,
+			MinOrderQuantity: int32(rules.MinOrderQuantity),
+			OrderMultiple:    int32(rules.OrderMultiple),
+			PackSizes:        packSizes,
+		})
+	}
+
+	return &catalogv1.GetProductVariantsResponse{
+		ProductId: response.ProductID,
+		Options:   options,
+		Variants:  variants,
+	}, nil
+}
+
+func (h *GrpcHandler) GetProductMedia(ctx context.Context, request *catalogv1.GetProductMediaRequest) (*catalogv1.GetProductMediaResponse, error) {
+	if request.GetProductId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "ID is required")
+	}
+
+	response, err := h.service.GetProductMedia(ctx, request.GetProductId())
+	if err != nil {
+		return nil, status.Error(productErrorCode(err), err.Error())
+	}
+
+	media := make([]*catalogv1.ProductMedia, 0, len(*response))
+	for _, data := range *response {
+		media = append(media, &catalogv1.ProductMedia{
+			Id:           *data.ID,
+			ProductId:    data.ProductID,
+			VariantId:    data.VariantID,
+			Url:          data.Url,
+			Type:         string(data.MediaType),
+			DisplayOrder: int32(data.DisplayOrder),
+		})
+	}
+
+	return &catalogv1.GetProductMediaResponse{
+		Media: media,
+	}, nil
+}
+
+func (h *GrpcHandler) GetProductSEO(ctx context.Context, request *catalogv1.GetProductSEORequest) (*catalogv1.ProductSEO, error) {
+	if request.GetProductId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Product ID is required")
+	}
+
+	response, err := h.service.GetProductSEO(ctx, request.GetProductId())
+	if err != nil {
+		return nil, status.Error(productErrorCode(err), err.Error())
+	}
+
+	return &catalogv1.ProductSEO{
+		ProductId:       response.ProductID,
+		MetaTitle:       response.MetaTitle,
+		MetaDescription: response.MetaDescription,
+		OgImageUrl:      response.OgImageUrl,
+		Keywords:        response.Keywords,
+	}, nil
+}
+
+// productErrorCode is the gRPC counterpart of productErrorStatus.
+func productErrorCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, ErrProductNotFound):
+		return codes.NotFound
+	case errors.Is(err, ErrInvalidVariants), errors.Is(err, ErrInvalidBundle), errors.Is(err, shared.ErrUnsupportedCurrency),
+		errors.Is(err, shared.ErrInvalidStateCode), errors.Is(err, shared.ErrInvalidCursor), errors.Is(err, shared.ErrInvalidSlug):
+		return codes.InvalidArgument
+	}
+	return codes.Internal
+}
+
+func toProtoProduct(data ProductResponseDTO) *catalogv1.Product {
+	return &catalogv1.Product{
+		Id:            data.ID,
+		Name:          data.Name,
+		Slug:          data.Slug,
+		Description:   data.Description,
+		SellerId:      data.SellerID,
+		BrandId:       data.BrandID,
+		CategoryId:    data.CategoryID,
+		Status:        string(data.Status),
+		CreatedAt:     timestamppb.New(data.CreatedAt),
+		UpdatedAt:     timestamppb.New(data.UpdatedAt),
+		Type:          string(data.Type),
+		Bundle:        toProtoBundle(data.Bundle),
+		RatingAverage: data.RatingAverage.Float64(),
+		RatingCount:   int32(data.RatingCount),
+	}
+}
+
+func toProtoBundle(data *BundleDTO) *catalogv1.Bundle {
+	if data == nil {
+		return nil
+	}
+
+	bundle := &catalogv1.Bundle{
+		Pricing:         data.Pricing,
+		DiscountPercent: data.DiscountPercent.String(),
+		Price:           decimalString(data.Price),
+		Currency:        data.Currency,
+		Available:       data.Available,
+	}
+	for _, component := range data.Components {
+		variants := make([]*catalogv1.BundleVariant, 0, len(component.Variants))
+		for _, variant := range component.Variants {
+			variants = append(variants, &catalogv1.BundleVariant{
+				VariantId:    variant.VariantID,
+				ProductId:    variant.ProductID,
+				ProductName:  variant.ProductName,
+				Sku:          variant.SKU,
+				Price:        variant.Price.String(),
+				Currency:     variant.Currency,
+				OptionValues: variant.OptionValues,
+				IsDefault:    variant.IsDefault,
+				Available:    variant.Available,
+			})
+		}
+		bundle.Components = append(bundle.Components, &catalogv1.BundleComponent{
+			Id:        component.ID,
+			Name:      component.Name,
+			Quantity:  int32(component.Quantity),
+			Available: component.Available,
+			Variants:  variants,
+		})
+	}
+	return bundle
+}
+
+func toProtoFacets(data *ProductFacets) *catalogv1.ProductFacets {
+	if data == nil {
+		return nil
+	}
+
+	facets := &catalogv1.ProductFacets{
+		Brands:         toProtoFacetBuckets(data.Brands),
+		Categories:     toProtoFacetBuckets(data.Categories),
+		Certifications: toProtoFacetBuckets(data.Certifications),
+	}
+
+	for _, bucket := range data.PriceRanges {
+		facets.PriceRanges = append(facets.PriceRanges, &catalogv1.PriceRangeBucket{
+			Min:   bucket.Min.String(),
+			Max:   decimalString(bucket.Max),
+			Count: int32(bucket.Count),
+		})
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(data.Attributes)) {
+		facets.Attributes = append(facets.Attributes, &catalogv1.AttributeFacet{
+			Key:     key,
+			Buckets: toProtoFacetBuckets(data.Attributes[key]),
+		})
+	}
+
+	return facets
+}
+
+func toProtoFacetBuckets(data []FacetBucket) []*catalogv1.FacetBucket {
+	buckets := make([]*catalogv1.FacetBucket, 0, len(data))
+	for _, bucket := range data {
+		buckets = append(buckets, &catalogv1.FacetBucket{
+			Value: bucket.Value,
+			Label: bucket.Label,
+			Count: int32(bucket.Count),
+		})
+	}
+	return buckets
+}
+
+// decimalString converts an optional amount for the decimal string fields of
+// the API, so prices are never rounded through floating point.
+func decimalString(value *shared.Decimal) *string {
+	if value == nil {
+		return nil
+	}
+	s := value.String()
+	return &s
+}
@@ -2,12 +2,18 @@ package products
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
+	"github.com/smart-safety-hub/backend/shared"
 )
 
 type RestHandler struct {
@@ -22,7 +28,14 @@ func NewRestHandler(service *ProductService, validator *validator.Validate) *Res
 	}
 }
 
+// CreateProduct creates a product sold by the user making the request.
 func (h *RestHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(shared.UserClaimsKey).(*shared.UserClaims)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
 	var request ProductRequestDTO
 
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
@@ -35,9 +48,9 @@ func (h *RestHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response, err := h.service.CreateProduct(r.Context(), request)
+	response, err := h.service.CreateProduct(r.Context(), claims.UserID, request)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), shared.SlugErrorStatus(err, http.StatusInternalServerError))
 		return
 	}
 
@@ -68,7 +81,7 @@ func (h *RestHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 
 	response, err := h.service.UpdateProduct(r.Context(), productID, request)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), productErrorStatus(err))
 		return
 	}
 
@@ -85,17 +98,42 @@ func (h *RestHandler) DeleteProduct(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	query := r.URL.Query()
-	status := query.Get("status")
+	response, err := h.service.DeleteProduct(r.Context(), productID)
+	if err != nil {
+		http.Error(w, err.Error(), productErrorStatus(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// TransitionProduct performs a workflow action (submit, withdraw, approve,
+// reject, publish, archive, restore). Each action checks its own permission.
+func (h *RestHandler) TransitionProduct(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "id")
+	action := chi.URLParam(r, "action")
+
+	if productID == "" || action == "" {
+		http.Error(w, "ID and action are required", http.StatusBadRequest)
+		return
+	}
+
+	var request WorkflowRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
-	if status == "" {
-		http.Error(w, "Status is required", http.StatusBadRequest)
+	if err := h.validator.Struct(request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	response, err := h.service.DeleteProduct(r.Context(), productID, status)
+	response, err := h.service.TransitionProduct(r.Context(), productID, action, request)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), productErrorStatus(err))
 		return
 	}
 
@@ -114,7 +152,7 @@ func (h *RestHandler) GetProductByID(w http.ResponseWriter, r *http.Request) {
 
 	response, err := h.service.GetProductByID(r.Context(), productID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), productErrorStatus(err))
 		return
 	}
 
@@ -133,7 +171,12 @@ func (h *RestHandler) GetProductBySlug(w http.ResponseWriter, r *http.Request) {
 
 	response, err := h.service.GetProductBySlug(r.Context(), slug)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		var moved *shared.SlugMovedError
+		if errors.As(err, &moved) {
+			shared.WriteMoved(w, r, moved)
+			return
+		}
+		http.Error(w, err.Error(), productErrorStatus(err))
 		return
 	}
 
@@ -147,24 +190,85 @@ func (h *RestHandler) GetAllProducts(w http.ResponseWriter, r *http.Request) {
 
 	request := ProductFilters{
 		Category: query["category"],
-		Brand:    query["brand"],
-		Search:   query.Get("search"),
-		Status:   query.Get("status"),
-		Page:     1,
-		Limit:    40,
+		// Subcategories are matched unless include_descendants=false
+		IncludeDescendants: query.Get("include_descendants") != "false",
+		Brand:              query["brand"],
+		Certification:      query["certification"],
+		Search:             query.Get("search"),
+		Status:             query.Get("status"),
+		Sort:               query.Get("sort"),
+		Attributes:         parseAttributeFilters(query),
+		Facets:             query.Get("facets") == "true",
+		Cursor:             query.Get("cursor"),
+		Count:              query.Get("count"),
+		Currency:           query.Get("currency"),
+		ShipTo:             query.Get("ship_to"),
+		Limit:              DefaultProductLimit,
+	}
+
+	if request.Sort != "" && !slices.Contains(ProductSortOrders, request.Sort) {
+		http.Error(w, "Invalid sort, allowed: "+strings.Join(ProductSortOrders, ","), http.StatusBadRequest)
+		return
 	}
 
+	if minPrice := query.Get("min_price"); minPrice != "" {
+		price, err := shared.ParseDecimal(minPrice)
+		if err != nil || price < 0 {
+			http.Error(w, "Invalid min_price", http.StatusBadRequest)
+			return
+		}
+		request.MinPrice = price
+	}
+
+	if maxPrice := query.Get("max_price"); maxPrice != "" {
+		price, err := shared.ParseDecimal(maxPrice)
+		if err != nil || price < 0 {
+			http.Error(w, "Invalid max_price", http.StatusBadRequest)
+			return
+		}
+		request.MaxPrice = price
+	}
+
+	if request.MaxPrice > 0 && request.MinPrice > request.MaxPrice {
+		http.Error(w, "min_price cannot be greater than max_price", http.StatusBadRequest)
+		return
+	}
+
+	if minRating := query.Get("min_rating"); minRating != "" {
+		rating, err := shared.ParseDecimal(minRating)
+		if err != nil || rating < 0 || rating > shared.NewDecimal(5) {
+			http.Error(w, "min_rating must be between 0 and 5", http.StatusBadRequest)
+			return
+		}
+		request.MinRating = rating
+	}
+
+	// page keeps the LIMIT/OFFSET behaviour for existing clients; without it
+	// the listing is paged with next_cursor/prev_cursor.
 	if p, err := strconv.Atoi(query.Get("page")); err == nil && p > 0 {
 		request.Page = p
 	}
 
 	if l, err := strconv.Atoi(query.Get("limit")); err == nil && l > 0 {
-		request.Limit = l
+		request.Limit = min(l, MaxProductLimit)
+	}
+
+	if request.Count != "" && !slices.Contains(shared.CountModes, request.Count) {
+		http.Error(w, "Invalid count, allowed: "+strings.Join(shared.CountModes, ","), http.StatusBadRequest)
+		return
+	}
+
+	if request.Cursor != "" && request.Page == 0 {
+		cursor, err := shared.DecodeCursor(request.Cursor)
+		if err != nil || cursor.Sort != request.EffectiveSort() {
+			http.Error(w, "Invalid cursor", http.StatusBadRequest)
+			return
+		}
 	}
 
 	response, err := h.service.GetAllProducts(r.Context(), request)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), productErrorStatus(err))
 		return
 	}
 
@@ -188,7 +292,11 @@ func (h *RestHandler) AddProductAttribute(w http.ResponseWriter, r *http.Request
 
 	response, err := h.service.AddProductAttribute(r.Context(), request)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		status := productErrorStatus(err)
+		if errors.Is(err, ErrInvalidAttributes) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
 		return
 	}
 
@@ -207,7 +315,7 @@ func (h *RestHandler) GetProductAttributeByID(w http.ResponseWriter, r *http.Req
 
 	response, err := h.service.GetProductAttributeByID(r.Context(), productID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), productErrorStatus(err))
 		return
 	}
 
@@ -237,7 +345,7 @@ func (h *RestHandler) SyncProductVariants(w http.ResponseWriter, r *http.Request
 
 	response, err := h.service.SyncProductVariants(r.Context(), productID, request)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), productErrorStatus(err))
 		return
 	}
 
@@ -246,6 +354,95 @@ func (h *RestHandler) SyncProductVariants(w http.ResponseWriter, r *http.Request
 	}
 }
 
+// SaveBundle sets the components of a bundle product.
+func (h *RestHandler) SaveBundle(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "id")
+	var request BundleRequestDTO
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.validator.Struct(request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.SaveBundle(r.Context(), productID, request)
+	if err != nil {
+		http.Error(w, err.Error(), productErrorStatus(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// ConfigureBundle prices a kit with the buyer's choice of variants for its
+// components, e.g. {"selections": {"Boots": "BOOT-S3-42"}}.
+func (h *RestHandler) ConfigureBundle(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "id")
+	var request BundleSelectionDTO
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.ConfigureBundle(r.Context(), productID, request)
+	if err != nil {
+		http.Error(w, err.Error(), productErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// GenerateVariantMatrix creates the variants of every option combination.
+// With dry_run=true nothing is saved and the planned variants are returned
+// for preview.
+func (h *RestHandler) GenerateVariantMatrix(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "id")
+
+	if productID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	dryRun, err := parseOptionalBool(r.URL.Query().Get("dry_run"))
+	if err != nil {
+		http.Error(w, "dry_run must be a boolean", http.StatusBadRequest)
+		return
+	}
+
+	var request VariantMatrixRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.validator.Struct(request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.GenerateVariantMatrix(r.Context(), productID, request, dryRun)
+	if err != nil {
+		http.Error(w, err.Error(), productErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
 func (h *RestHandler) AddProductMedia(w http.ResponseWriter, r *http.Request) {
 	productID := chi.URLParam(r, "id")
 
@@ -268,7 +465,7 @@ func (h *RestHandler) AddProductMedia(w http.ResponseWriter, r *http.Request) {
 
 	response, err := h.service.AddProductMedia(r.Context(), productID, request)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), productErrorStatus(err))
 		return
 	}
 
@@ -290,7 +487,7 @@ func (h *RestHandler) GetProductMedia(w http.ResponseWriter, r *http.Request) {
 	response, err := h.service.GetProductMedia(r.Context(), productID)
 	if err != nil {
 		fmt.Println("err", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), productErrorStatus(err))
 		return
 	}
 
@@ -309,9 +506,9 @@ func (h *RestHandler) GetProductVariants(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	response, err := h.service.GetProductVariants(r.Context(), productID)
+	response, err := h.service.GetProductVariants(r.Context(), productID, priceOptions(r.URL.Query()))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), productErrorStatus(err))
 		return
 	}
 
@@ -340,7 +537,7 @@ func (h *RestHandler) SaveProductSEO(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.service.SaveProductSEO(r.Context(), productID, request); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), productErrorStatus(err))
 		return
 	}
 
@@ -356,7 +553,7 @@ func (h *RestHandler) GetProductSEO(w http.ResponseWriter, r *http.Request) {
 
 	response, err := h.service.GetProductSEO(r.Context(), productID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), productErrorStatus(err))
 		return
 	}
 
@@ -366,3 +563,392 @@ func (h *RestHandler) GetProductSEO(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
 	}
 }
+
+func (h *RestHandler) GetProductDetailByID(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "id")
+
+	if productID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	include, err := parseDetailInclude(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.GetProductDetailByID(r.Context(), productID, include, priceOptions(r.URL.Query()))
+	if err != nil {
+		http.Error(w, err.Error(), productErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RestHandler) GetProductDetailBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+
+	if slug == "" {
+		http.Error(w, "Slug is required", http.StatusBadRequest)
+		return
+	}
+
+	include, err := parseDetailInclude(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.GetProductDetailBySlug(r.Context(), slug, include, priceOptions(r.URL.Query()))
+	if err != nil {
+		var moved *shared.SlugMovedError
+		if errors.As(err, &moved) {
+			shared.WriteMoved(w, r, moved)
+			return
+		}
+		http.Error(w, err.Error(), productErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// parseDetailInclude reads include= (or its alias fields=) as a comma separated
+// list of detail sections. When neither is given every section is returned.
+func parseDetailInclude(query url.Values) (map[string]bool, error) {
+	raw := append(query["include"], query["fields"]...)
+
+	include := make(map[string]bool, len(ProductDetailSections))
+	if len(raw) == 0 {
+		for _, section := range ProductDetailSections {
+			include[section] = true
+		}
+		return include, nil
+	}
+
+	for _, value := range raw {
+		for _, section := range strings.Split(value, ",") {
+			section = strings.ToLower(strings.TrimSpace(section))
+			if section == "" {
+				continue
+			}
+			if !slices.Contains(ProductDetailSections, section) {
+				return nil, fmt.Errorf("Unknown section %q, allowed: %s", section, strings.Join(ProductDetailSections, ","))
+			}
+			include[section] = true
+		}
+	}
+
+	return include, nil
+}
+
+// parseAttributeFilters collects attr[<key>]=<value> query parameters.
+func parseAttributeFilters(query url.Values) map[string][]string {
+	attributes := make(map[string][]string)
+	for param, values := range query {
+		if !strings.HasPrefix(param, "attr[") || !strings.HasSuffix(param, "]") {
+			continue
+		}
+
+		key := strings.TrimSpace(param[len("attr[") : len(param)-1])
+		if key == "" {
+			continue
+		}
+
+		for _, value := range values {
+			if value != "" {
+				attributes[key] = append(attributes[key], value)
+			}
+		}
+	}
+	return attributes
+}
+
+// maxImportSize caps the size of an uploaded import file.
+const maxImportSize = 32 << 20
+
+// ImportProducts accepts a CSV or JSON Lines file either as the "file" field
+// of a multipart form or as the raw request body. The format comes from the
+// format query parameter, or else from the file name or content type.
+func (h *RestHandler) ImportProducts(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(shared.UserClaimsKey).(*shared.UserClaims)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+
+	format := strings.ToLower(query.Get("format"))
+	body := io.Reader(r.Body)
+	name := ""
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, "file is required", http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		body, name = file, header.Filename
+	}
+
+	if format == "" {
+		format = importFormat(name, r.Header.Get("Content-Type"))
+	}
+
+	dryRun, err := parseOptionalBool(query.Get("dry_run"))
+	if err != nil {
+		http.Error(w, "dry_run must be true or false", http.StatusBadRequest)
+		return
+	}
+
+	async, err := parseOptionalBool(query.Get("async"))
+	if err != nil {
+		http.Error(w, "async must be true or false", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.ImportProducts(r.Context(), claims.UserID, format, dryRun, async, body)
+	if err != nil {
+		status := http.StatusInternalServerError
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.Is(err, ErrInvalidImportFile):
+			status = http.StatusBadRequest
+		case errors.As(err, &maxBytesErr):
+			status = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if response.Status != ImportCompleted && response.Status != ImportFailed {
+		w.WriteHeader(http.StatusAccepted)
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// GetImportJob returns an import job started by the signed-in user.
+func (h *RestHandler) GetImportJob(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(shared.UserClaimsKey).(*shared.UserClaims)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	jobID := chi.URLParam(r, "id")
+
+	if jobID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.GetImportJob(r.Context(), claims.UserID, jobID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrImportJobNotFound) {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func importFormat(fileName, contentType string) string {
+	fileName = strings.ToLower(fileName)
+	switch {
+	case strings.HasSuffix(fileName, ".csv"), strings.HasPrefix(contentType, "text/csv"):
+		return ImportCSV
+	case strings.HasSuffix(fileName, ".jsonl"), strings.HasSuffix(fileName, ".ndjson"),
+		strings.HasPrefix(contentType, "application/x-ndjson"), strings.HasPrefix(contentType, "application/jsonl"):
+		return ImportJSONL
+	default:
+		return ""
+	}
+}
+
+func parseOptionalBool(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// ExportProducts streams the catalog as CSV, JSON Lines or XLSX, filtered by
+// category, brand and status like GetAllProducts. Only ACTIVE products are
+// exported unless status is given; other statuses are exported for catalog
+// reviewers, and for sellers over their own products only.
+func (h *RestHandler) ExportProducts(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	format := strings.ToLower(query.Get("format"))
+	if format == "" {
+		format = ExportCSV
+	}
+
+	if !slices.Contains(ExportFormats, format) {
+		http.Error(w, "format must be one of "+strings.Join(ExportFormats, ", "), http.StatusBadRequest)
+		return
+	}
+
+	filters := ProductFilters{
+		Category:           query["category"],
+		IncludeDescendants: query.Get("include_descendants") != "false",
+		Brand:              query["brand"],
+		Certification:      query["certification"],
+		Status:             query.Get("status"),
+	}
+
+	started := false
+	err := h.service.ExportProducts(r.Context(), filters, format, func() io.Writer {
+		started = true
+		contentType, extension := ExportContentType(format)
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="products.%s"`, extension))
+		w.Header().Set("Trailer", "X-Export-Error")
+		return w
+	})
+	if err == nil {
+		return
+	}
+
+	// Headers are sent with the first batch, so a later error cannot change
+	// the status; it cuts the file short and is reported in a trailer
+	if started {
+		w.Header().Set("X-Export-Error", err.Error())
+		return
+	}
+	http.Error(w, err.Error(), productErrorStatus(err))
+}
+
+func (h *RestHandler) GetProductVersions(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "id")
+
+	if productID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.GetProductVersions(r.Context(), productID)
+	if err != nil {
+		http.Error(w, err.Error(), versionErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RestHandler) GetProductVersion(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "id")
+	version, err := strconv.Atoi(chi.URLParam(r, "version"))
+
+	if productID == "" || err != nil || version < 1 {
+		http.Error(w, "ID and a positive version are required", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.GetProductVersion(r.Context(), productID, version)
+	if err != nil {
+		http.Error(w, err.Error(), versionErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RestHandler) DiffProductVersions(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "id")
+	query := r.URL.Query()
+
+	from, fromErr := strconv.Atoi(query.Get("from"))
+	to, toErr := strconv.Atoi(query.Get("to"))
+	if productID == "" || fromErr != nil || toErr != nil || from < 1 || to < 1 {
+		http.Error(w, "ID and positive from and to versions are required", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.DiffProductVersions(r.Context(), productID, from, to)
+	if err != nil {
+		http.Error(w, err.Error(), versionErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RestHandler) RestoreProductVersion(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "id")
+	version, err := strconv.Atoi(chi.URLParam(r, "version"))
+
+	if productID == "" || err != nil || version < 1 {
+		http.Error(w, "ID and a positive version are required", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.RestoreProductVersion(r.Context(), productID, version)
+	if err != nil {
+		http.Error(w, err.Error(), versionErrorStatus(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func versionErrorStatus(err error) int {
+	if errors.Is(err, ErrVersionNotFound) || errors.Is(err, ErrProductNotFound) {
+		return http.StatusNotFound
+	}
+	return shared.SlugErrorStatus(err, http.StatusInternalServerError)
+}
+
+// priceOptions reads the currency= and ship_to= parameters.
+func priceOptions(query url.Values) PriceOptions {
+	return PriceOptions{
+		Currency: query.Get("currency"),
+		ShipTo:   query.Get("ship_to"),
+	}
+}
+
+func productErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrTransitionDenied):
+		return http.StatusForbidden
+	case errors.Is(err, ErrInvalidTransition):
+		return http.StatusConflict
+	case errors.Is(err, ErrInvalidVariants), errors.Is(err, ErrInvalidBundle), errors.Is(err, shared.ErrUnsupportedCurrency), errors.Is(err, shared.ErrInvalidStateCode):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrProductNotFound):
+		return http.StatusNotFound
+	}
+	return shared.SlugErrorStatus(err, http.StatusInternalServerError)
+}
@@ -0,0 +1,383 @@
+package products
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+var ErrInvalidImportFile = errors.New("invalid import file")
+
+// importUpdatableStatuses are the statuses of the products an import may
+// update, the same ones a seller submits for review from.
+var importUpdatableStatuses = []ProductStatus{DRAFT, REJECTED}
+
+// csvColumns are the fixed CSV columns. Attributes go in "attr:<key>" columns
+// and variant options in "option:<name>" columns; media and keywords hold
+// "|"-separated lists.
+var csvColumns = []string{
+	"slug", "name", "description", "brand", "category", "status",
+	"sku", "price", "currency", "weight", "is_active",
+	"unit", "min_order_quantity", "order_multiple", "pack_sizes",
+	"media", "meta_title", "meta_description", "og_image_url", "keywords",
+}
+
+// parseImportFile reads the products of an import file. Rows that cannot be
+// parsed are returned with Err set so they show up in the report; an error is
+// returned only when the file as a whole is unreadable.
+func parseImportFile(format string, r io.Reader) ([]ImportRow, error) {
+	switch format {
+	case ImportCSV:
+		return parseImportCSV(r)
+	case ImportJSONL:
+		return parseImportJSONL(r)
+	default:
+		return nil, fmt.Errorf("%w: format must be %s or %s", ErrInvalidImportFile, ImportCSV, ImportJSONL)
+	}
+}
+
+func parseImportJSONL(r io.Reader) ([]ImportRow, error) {
+	var rows []ImportRow
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		var row ImportRow
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&row); err != nil {
+			row = ImportRow{Err: err}
+		}
+		row.Line = line
+		rows = append(rows, row)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+	}
+
+	return rows, nil
+}
+
+func parseImportCSV(r io.Reader) ([]ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: missing header: %v", ErrInvalidImportFile, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	var attributeColumns, optionColumns []string
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		switch {
+		case strings.HasPrefix(name, "attr:"):
+			attributeColumns = append(attributeColumns, name)
+		case strings.HasPrefix(name, "option:"):
+			optionColumns = append(optionColumns, name)
+		case !slices.Contains(csvColumns, name):
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidImportFile, name)
+		}
+		columns[name] = i
+	}
+
+	if _, ok := columns["slug"]; !ok {
+		return nil, fmt.Errorf("%w: slug column is required", ErrInvalidImportFile)
+	}
+
+	var rows []*ImportRow
+	bySlug := make(map[string]*ImportRow)
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// A malformed line is reported on its own, later lines are still read
+			rows = append(rows, &ImportRow{Line: line, Err: err})
+			continue
+		}
+
+		get := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		slug := get("slug")
+		row, ok := bySlug[slug]
+		if !ok || slug == "" {
+			row = &ImportRow{
+				Line:       line,
+				Slug:       slug,
+				Name:       get("name"),
+				Brand:      get("brand"),
+				Category:   get("category"),
+				Status:     ProductStatus(get("status")),
+				Attributes: map[string]string{},
+			}
+			if description := get("description"); description != "" {
+				row.Description = &description
+			}
+			if metaTitle, metaDescription, ogImage, keywords := get("meta_title"), get("meta_description"), get("og_image_url"), get("keywords"); metaTitle != "" || metaDescription != "" || ogImage != "" || keywords != "" {
+				row.SEO = &ImportSEO{
+					MetaTitle:       metaTitle,
+					MetaDescription: metaDescription,
+					OgImageUrl:      ogImage,
+					Keywords:        splitList(keywords),
+				}
+			}
+			rows = append(rows, row)
+			if slug != "" {
+				bySlug[slug] = row
+			}
+		}
+
+		for _, column := range attributeColumns {
+			if value := get(column); value != "" {
+				row.Attributes[strings.TrimPrefix(column, "attr:")] = value
+			}
+		}
+
+		for _, mediaURL := range splitList(get("media")) {
+			if !slices.ContainsFunc(row.Media, func(m ImportMedia) bool { return m.Url == mediaURL }) {
+				row.Media = append(row.Media, ImportMedia{
+					Url:          mediaURL,
+					Type:         mediaTypeFromURL(mediaURL),
+					DisplayOrder: len(row.Media),
+				})
+			}
+		}
+
+		sku := get("sku")
+		if sku == "" {
+			continue
+		}
+
+		variant := ImportVariant{SKU: sku, Currency: strings.ToUpper(get("currency"))}
+		if variant.Price, err = parseCSVDecimal(get("price")); err != nil {
+			row.Err = fmt.Errorf("line %d: price: %v", line, err)
+			continue
+		}
+		if variant.Weight, err = parseCSVFloat(get("weight")); err != nil {
+			row.Err = fmt.Errorf("line %d: weight: %v", line, err)
+			continue
+		}
+		if active := get("is_active"); active != "" {
+			isActive, err := strconv.ParseBool(active)
+			if err != nil {
+				row.Err = fmt.Errorf("line %d: is_active: %v", line, err)
+				continue
+			}
+			variant.IsActive = &isActive
+		}
+		variant.Unit = strings.ToUpper(get("unit"))
+		if variant.MinOrderQuantity, err = parseCSVInt(get("min_order_quantity")); err != nil {
+			row.Err = fmt.Errorf("line %d: min_order_quantity: %v", line, err)
+			continue
+		}
+		if variant.OrderMultiple, err = parseCSVInt(get("order_multiple")); err != nil {
+			row.Err = fmt.Errorf("line %d: order_multiple: %v", line, err)
+			continue
+		}
+		if variant.PackSizes, err = shared.ParsePackSizes(get("pack_sizes")); err != nil {
+			row.Err = fmt.Errorf("line %d: pack_sizes: %v", line, err)
+			continue
+		}
+
+		for _, column := range optionColumns {
+			value := get(column)
+			if value == "" {
+				continue
+			}
+			name := strings.TrimPrefix(column, "option:")
+			if variant.Options == nil {
+				variant.Options = make(map[string]string, len(optionColumns))
+			}
+			variant.Options[name] = value
+			variant.OptionValues = append(variant.OptionValues, value)
+
+			i := slices.IndexFunc(row.Options, func(o ProductOptionValue) bool { return o.Name == name })
+			if i < 0 {
+				row.Options = append(row.Options, ProductOptionValue{Name: name})
+				i = len(row.Options) - 1
+			}
+			if !slices.Contains(row.Options[i].Values, value) {
+				row.Options[i].Values = append(row.Options[i].Values, value)
+			}
+		}
+
+		row.Variants = append(row.Variants, variant)
+	}
+
+	result := make([]ImportRow, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *row)
+	}
+
+	return result, nil
+}
+
+// validateImportRow checks what can be checked without the database.
+func validateImportRow(row ImportRow) []string {
+	var problems []string
+
+	if row.Slug == "" {
+		problems = append(problems, "slug is required")
+	}
+	if row.Name == "" {
+		problems = append(problems, "name is required")
+	}
+	if row.Brand == "" {
+		problems = append(problems, "brand is required")
+	}
+	if row.Category == "" {
+		problems = append(problems, "category is required")
+	}
+	// Imports create drafts or submit them for review; publishing goes through the workflow
+	if row.Status != "" && !slices.Contains([]ProductStatus{DRAFT, PENDING_REVIEW}, row.Status) {
+		problems = append(problems, fmt.Sprintf("status %q is not one of DRAFT, PENDING_REVIEW", row.Status))
+	}
+
+	if len(row.Options) > 0 {
+		if err := validateOptions(row.Options); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	skus := make(map[string]bool, len(row.Variants))
+	for _, variant := range row.Variants {
+		switch {
+		case variant.SKU == "":
+			problems = append(problems, "variant sku is required")
+		case len(variant.SKU) > 100:
+			problems = append(problems, fmt.Sprintf("sku %q is longer than 100 characters", variant.SKU))
+		case skus[variant.SKU]:
+			problems = append(problems, fmt.Sprintf("sku %q is listed more than once", variant.SKU))
+		}
+		skus[variant.SKU] = true
+
+		if variant.Price <= 0 {
+			problems = append(problems, fmt.Sprintf("sku %q: price must be greater than 0", variant.SKU))
+		}
+		if variant.Currency != "" && len(variant.Currency) != 3 {
+			problems = append(problems, fmt.Sprintf("sku %q: currency must be a 3 letter code", variant.SKU))
+		}
+		if variant.Weight < 0 {
+			problems = append(problems, fmt.Sprintf("sku %q: weight must not be negative", variant.SKU))
+		}
+		if variant.MinOrderQuantity < 0 || variant.OrderMultiple < 0 {
+			problems = append(problems, fmt.Sprintf("sku %q: min_order_quantity and order_multiple must not be negative", variant.SKU))
+		}
+		if err := variant.OrderRules.Validate(); err != nil {
+			problems = append(problems, fmt.Sprintf("sku %q: %v", variant.SKU, err))
+		}
+		if _, err := resolveVariantOptions(row.Options, ProductVariant{SKU: variant.SKU, Options: variant.Options, OptionValues: variant.OptionValues}); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	for _, media := range row.Media {
+		if u, err := url.ParseRequestURI(media.Url); err != nil || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("media url %q is not a valid URL", media.Url))
+		}
+		if media.Type != "" && !slices.Contains([]ProductType{IMAGE, VIDEO, PDF}, media.Type) {
+			problems = append(problems, fmt.Sprintf("media type %q is not one of image, video, pdf", media.Type))
+		}
+	}
+
+	return problems
+}
+
+// importAttributes turns the attribute map of a row into a stable list.
+func importAttributes(attributes map[string]string) []ProductAttributeArray {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]ProductAttributeArray, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, ProductAttributeArray{
+			AttributeKey:   key,
+			AttributeValue: attributes[key],
+		})
+	}
+	return result
+}
+
+func mediaTypeFromURL(mediaURL string) ProductType {
+	path := strings.ToLower(mediaURL)
+	if u, err := url.Parse(mediaURL); err == nil {
+		path = strings.ToLower(u.Path)
+	}
+
+	switch {
+	case strings.HasSuffix(path, ".pdf"):
+		return PDF
+	case strings.HasSuffix(path, ".mp4"), strings.HasSuffix(path, ".webm"), strings.HasSuffix(path, ".mov"):
+		return VIDEO
+	default:
+		return IMAGE
+	}
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, "|") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func parseCSVDecimal(value string) (shared.Decimal, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return shared.ParseDecimal(value)
+}
+
+func parseCSVInt(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+func parseCSVFloat(value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err == nil && (math.IsNaN(number) || math.IsInf(number, 0)) {
+		return 0, fmt.Errorf("%q is not a number", value)
+	}
+	return number, err
+}
@@ -3,12 +3,18 @@ package products
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/smart-safety-hub/backend/shared"
+	"golang.org/x/sync/errgroup"
 )
 
 type ProductRepo struct {
@@ -21,40 +27,274 @@ func NewProductRepo(db *sqlx.DB) *ProductRepo {
 	}
 }
 
-func (r *ProductRepo) SaveProduct(ctx context.Context, request ProductRequestDTO) (*string, error) {
-	query := "INSERT INTO products(name, slug, description, seller_id, brand_id, category_id, status) VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING id"
+// SaveProduct inserts a product sold by sellerID and returns its id and slug,
+// generated from the name when the request has none.
+func (r *ProductRepo) SaveProduct(ctx context.Context, sellerID string, request ProductRequestDTO) (*string, string, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, "", shared.PostgresError(err)
+	}
+
+	defer tx.Rollback()
+
+	slug, err := shared.UniqueSlug(ctx, tx, "products", request.Name, request.Slug, "")
+	if err != nil {
+		return nil, "", err
+	}
+
+	query := "INSERT INTO products(name, slug, description, seller_id, brand_id, category_id, status, product_type) VALUES ($1,$2,$3,$4,$5,$6,COALESCE(NULLIF($7, '')::status_enum, 'DRAFT'),COALESCE(NULLIF($8, ''), 'STANDARD')) RETURNING id"
 	var lastInsertId string
-	if err := r.db.QueryRowContext(ctx, query, request.Name, request.Slug, request.Description, request.SellerID, request.BrandID, request.CategoryID, request.Status).Scan(&lastInsertId); err != nil {
-		return nil, shared.PostgresError(err)
+	if err := tx.QueryRowContext(ctx, query, request.Name, slug, request.Description, sellerID, request.BrandID, request.CategoryID, request.Status, request.Type).Scan(&lastInsertId); err != nil {
+		return nil, "", shared.PostgresError(err)
+	}
+
+	if err := recordProductVersion(ctx, tx, lastInsertId, ChangeCreate, changedBy(ctx), nil); err != nil {
+		return nil, "", err
 	}
-	return &lastInsertId, nil
+
+	if err := tx.Commit(); err != nil {
+		return nil, "", shared.PostgresError(err)
+	}
+	return &lastInsertId, slug, nil
 }
 
+// UpdateProduct edits the content of a product. Editing an approved product,
+// ACTIVE or SCHEDULED, takes it off the storefront and sends it back to review.
 func (r *ProductRepo) UpdateProduct(ctx context.Context, productID string, request ProductRequestDTO) error {
-	query := "UPDATE products SET name=COALESCE(NULLIF($1, ''), name), slug=COALESCE(NULLIF($2, ''), slug), description=COALESCE(NULLIF($3, ''), description), seller_id=COALESCE(NULLIF($4, '')::UUID, seller_id), brand_id=COALESCE(NULLIF($5, '')::UUID, brand_id), category_id=COALESCE(NULLIF($6, '')::UUID, category_id), status=COALESCE(NULLIF($7, '')::status_enum, status) WHERE id=$8"
-	if _, err := r.db.ExecContext(ctx, query, request.Name, request.Slug, request.Description, request.SellerID, request.BrandID, request.CategoryID, request.Status, productID); err != nil {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return shared.PostgresError(err)
+	}
+
+	defer tx.Rollback()
+
+	var id string
+	if err := tx.GetContext(ctx, &id, "SELECT id FROM products WHERE id=$1 FOR UPDATE", productID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrProductNotFound
+		}
+		return shared.PostgresError(err)
+	}
+
+	if request.Slug != "" {
+		if request.Slug, err = shared.UniqueSlug(ctx, tx, "products", "", request.Slug, productID); err != nil {
+			return err
+		}
+	}
+
+	// Bundles never nest, so a product used in a bundle stays a standard product
+	if request.Type == BUNDLE {
+		var used bool
+		query := "SELECT EXISTS (SELECT 1 FROM bundle_component_variants bcv JOIN product_variants pv ON pv.id = bcv.variant_id WHERE pv.product_id = $1)"
+		if err := tx.GetContext(ctx, &used, query, productID); err != nil {
+			return shared.PostgresError(err)
+		}
+		if used {
+			return fmt.Errorf("%w: the product is a component of a bundle", ErrInvalidBundle)
+		}
+	}
+
+	query := "UPDATE products SET name=COALESCE(NULLIF($1, ''), name), slug=COALESCE(NULLIF($2, ''), slug), description=COALESCE(NULLIF($3, ''), description), brand_id=COALESCE(NULLIF($4, '')::UUID, brand_id), category_id=COALESCE(NULLIF($5, '')::UUID, category_id), product_type=COALESCE(NULLIF($6, ''), product_type) WHERE id=$7"
+	if _, err := tx.ExecContext(ctx, query, request.Name, request.Slug, request.Description, request.BrandID, request.CategoryID, request.Type, productID); err != nil {
+		return shared.PostgresError(err)
+	}
+
+	if err := returnToReview(ctx, tx, productID); err != nil {
+		return err
+	}
+
+	if err := recordProductVersion(ctx, tx, productID, ChangeUpdate, changedBy(ctx), nil); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// TransitionProduct applies a workflow action to a product. The product row
+// is locked while the transition is resolved against its current status, so
+// concurrent actions cannot both succeed. Only the product's seller and
+// catalog reviewers may act on it.
+func (r *ProductRepo) TransitionProduct(ctx context.Context, productID string, action string, permissions []string, request WorkflowRequestDTO) (ProductStatus, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return "", shared.PostgresError(err)
+	}
+
+	defer tx.Rollback()
+
+	var current Product
+	query := "SELECT id, seller_id, status, rejection_reason, publish_at, unpublish_at FROM products WHERE id=$1 FOR UPDATE"
+	if err := tx.GetContext(ctx, &current, query, productID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrProductNotFound
+		}
+		return "", shared.PostgresError(err)
+	}
+
+	if !canManageProduct(ctx, &current) {
+		return "", fmt.Errorf("%w: only the seller of the product can %s it", ErrTransitionDenied, action)
+	}
+
+	now := time.Now()
+	status, err := resolveTransition(action, current.Status, permissions, request, now)
+	if err != nil {
+		return "", err
+	}
+
+	reason, publishAt, unpublishAt := (*string)(nil), current.PublishAt, current.UnpublishAt
+	var reviewedBy *string
+	switch action {
+	case ActionApprove:
+		publishAt, unpublishAt, reviewedBy = request.PublishAt, request.UnpublishAt, changedBy(ctx)
+		if status == ACTIVE {
+			publishAt = &now
+		}
+	case ActionPublish:
+		publishAt, reviewedBy = &now, changedBy(ctx)
+	case ActionReject:
+		reason, reviewedBy = &request.Reason, changedBy(ctx)
+		publishAt, unpublishAt = nil, nil
+	case ActionWithdraw, ActionArchive, ActionRestore:
+		publishAt, unpublishAt = nil, nil
+	}
+
+	query = `UPDATE products SET status=$1, rejection_reason=$2, publish_at=$3, unpublish_at=$4,
+		reviewed_by=COALESCE($5::uuid, reviewed_by),
+		reviewed_at=CASE WHEN $5::uuid IS NULL THEN reviewed_at ELSE CURRENT_TIMESTAMP END,
+		updated_at=CURRENT_TIMESTAMP
+		WHERE id=$6`
+	if _, err := tx.ExecContext(ctx, query, status, reason, publishAt, unpublishAt, reviewedBy, productID); err != nil {
+		return "", shared.PostgresError(err)
+	}
+
+	if err := recordProductVersion(ctx, tx, productID, ChangeWorkflow+":"+action, changedBy(ctx), nil); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", shared.PostgresError(err)
+	}
+
+	return status, nil
+}
+
+// RunScheduledTransitions publishes SCHEDULED products whose publish_at has
+// passed and archives ACTIVE products whose unpublish_at has passed. Rows
+// locked by another transaction are skipped and picked up on the next run.
+func (r *ProductRepo) RunScheduledTransitions(ctx context.Context) (int, int, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, 0, shared.PostgresError(err)
+	}
+
+	defer tx.Rollback()
+
+	var published []string
+	query := `SELECT id FROM products WHERE status='SCHEDULED' AND publish_at <= now() FOR UPDATE SKIP LOCKED`
+	if err := tx.SelectContext(ctx, &published, query); err != nil {
+		return 0, 0, shared.PostgresError(err)
+	}
+
+	var unpublished []string
+	query = `SELECT id FROM products WHERE status='ACTIVE' AND unpublish_at <= now() FOR UPDATE SKIP LOCKED`
+	if err := tx.SelectContext(ctx, &unpublished, query); err != nil {
+		return 0, 0, shared.PostgresError(err)
+	}
+
+	for _, productID := range published {
+		if _, err := tx.ExecContext(ctx, "UPDATE products SET status='ACTIVE', updated_at=CURRENT_TIMESTAMP WHERE id=$1", productID); err != nil {
+			return 0, 0, shared.PostgresError(err)
+		}
+		if err := recordProductVersion(ctx, tx, productID, ChangeWorkflow+":"+ActionPublish, nil, nil); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	for _, productID := range unpublished {
+		if err := archiveProduct(ctx, tx, productID, nil); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, shared.PostgresError(err)
+	}
+
+	return len(published), len(unpublished), nil
+}
+
+// archiveProduct archives a locked product outside of a user's workflow
+// action, recording it as a workflow:archive version like the action does.
+func archiveProduct(ctx context.Context, tx *sqlx.Tx, productID string, changedBy *string) error {
+	if _, err := tx.ExecContext(ctx, "UPDATE products SET status='ARCHIVED', publish_at=NULL, unpublish_at=NULL, updated_at=CURRENT_TIMESTAMP WHERE id=$1", productID); err != nil {
+		return shared.PostgresError(err)
+	}
+	return recordProductVersion(ctx, tx, productID, ChangeWorkflow+":"+ActionArchive, changedBy, nil)
+}
+
+// MoveCategoryProducts moves the products of one category to another inside
+// the caller's transaction, recording an update version for each. The
+// categories module uses it when merging or deleting categories.
+func MoveCategoryProducts(ctx context.Context, tx *sqlx.Tx, fromCategoryID, toCategoryID string) error {
+	var productIDs []string
+	query := "SELECT id FROM products WHERE category_id=$1 ORDER BY id FOR UPDATE"
+	if err := tx.SelectContext(ctx, &productIDs, query, fromCategoryID); err != nil {
 		return shared.PostgresError(err)
 	}
 
+	for _, productID := range productIDs {
+		if _, err := tx.ExecContext(ctx, "UPDATE products SET category_id=$1, updated_at=CURRENT_TIMESTAMP WHERE id=$2", toCategoryID, productID); err != nil {
+			return shared.PostgresError(err)
+		}
+		if err := recordProductVersion(ctx, tx, productID, ChangeUpdate, changedBy(ctx), nil); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func (r *ProductRepo) DeleteProduct(ctx context.Context, productID string, status string) error {
-	query := "UPDATE products SET status=$1 WHERE id=$2"
-	if _, err := r.db.ExecContext(ctx, query, status, productID); err != nil {
+// ArchiveCategoryProducts archives the products of a category and its
+// subcategories inside the caller's transaction, before the categories are
+// deleted. The products are taken out of the categories first so that their
+// version shows them as they are left; archived ones only record the change.
+func ArchiveCategoryProducts(ctx context.Context, tx *sqlx.Tx, categoryPath string) error {
+	var rows []struct {
+		ID     string        `db:"id"`
+		Status ProductStatus `db:"status"`
+	}
+	query := "SELECT id, status FROM products WHERE category_id IN (SELECT id FROM categories WHERE path LIKE $1 || '%') ORDER BY id FOR UPDATE"
+	if err := tx.SelectContext(ctx, &rows, query, categoryPath); err != nil {
 		return shared.PostgresError(err)
 	}
 
+	for _, row := range rows {
+		if _, err := tx.ExecContext(ctx, "UPDATE products SET category_id=NULL WHERE id=$1", row.ID); err != nil {
+			return shared.PostgresError(err)
+		}
+
+		var err error
+		if row.Status == ARCHIVED {
+			err = recordProductVersion(ctx, tx, row.ID, ChangeUpdate, changedBy(ctx), nil)
+		} else {
+			err = archiveProduct(ctx, tx, row.ID, changedBy(ctx))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// GetProductByID returns a product whatever its status; the service decides
+// who may read it, see canReadProduct.
 func (r *ProductRepo) GetProductByID(ctx context.Context, productID string) (*Product, error) {
 	var product Product
-	query := "SELECT * FROM products WHERE id=$1"
+	query := "SELECT id, name, slug, description, seller_id, brand_id, category_id, status, product_type, rejection_reason, publish_at, unpublish_at, compliance_flagged_at, rating_average, rating_count, created_at, updated_at FROM products WHERE id=$1"
 	if err := r.db.GetContext(ctx, &product, query, productID); err != nil {
-		fmt.Println("err", err)
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("product not found")
+			return nil, ErrProductNotFound
 		}
 		return nil, shared.PostgresError(err)
 	}
@@ -63,22 +303,36 @@ func (r *ProductRepo) GetProductByID(ctx context.Context, productID string) (*Pr
 
 func (r *ProductRepo) GetProductBySlug(ctx context.Context, slug string) (*Product, error) {
 	var product Product
-	query := `SELECT * FROM products WHERE slug = $1 AND status='ACTIVE' LIMIT 1`
+	query := `SELECT id, name, slug, description, seller_id, brand_id, category_id, status, product_type, rejection_reason, publish_at, unpublish_at, compliance_flagged_at, rating_average, rating_count, created_at, updated_at FROM products WHERE slug = $1 LIMIT 1`
 
 	err := r.db.GetContext(ctx, &product, query, slug)
 	if err != nil {
-		return nil, err
+		if errors.Is(err, sql.ErrNoRows) {
+			// An old slug is answered with where the product lives now
+			if err := shared.MovedSlug(ctx, r.db, "products", slug); !errors.Is(err, sql.ErrNoRows) {
+				return nil, err
+			}
+			return nil, ErrProductNotFound
+		}
+		return nil, shared.PostgresError(err)
 	}
 	return &product, nil
 }
 
-func (r *ProductRepo) GetAllProducts(ctx context.Context, request ProductFilters) ([]GetProducts, error) {
+func (r *ProductRepo) GetAllProducts(ctx context.Context, request ProductFilters) (*ProductPage, error) {
+	keys := productSortKeys(request)
+	cursorKey, args := shared.KeysetValues(keys)
+
 	query := `SELECT 
 		p.id, p.name, p.slug, p.description, p.status, 
 		b.name AS brand_name, 
 		c.name AS category_name,
 		media.url AS image_url,
-		COUNT(*) OVER() AS total_count
+		pr.min_price, pr.max_price,
+		tx.tax_class_id, tx.hsn_code, tx.tax_rate, tx.seller_state,
+		product_certifications(p.id) AS certifications,
+		p.rating_average, p.rating_count,
+		` + cursorKey + ` AS cursor_key
 		FROM products p 
 		LEFT JOIN brands b ON p.brand_id = b.id 
 		LEFT JOIN categories c ON p.category_id = c.id
@@ -89,61 +343,405 @@ func (r *ProductRepo) GetAllProducts(ctx context.Context, request ProductFilters
 		ORDER BY display_order ASC
 		LIMIT 1
 		) media ON true
+		LEFT JOIN LATERAL (
+		SELECT MIN(vp.price) AS min_price, MAX(vp.price) AS max_price
+		FROM product_variants pv, LATERAL (SELECT contract_price(pv.id, ?::uuid, pv.price, ?) AS price) vp
+		WHERE pv.product_id = p.id AND pv.is_active
+		) pr ON true
+		LEFT JOIN LATERAL product_tax(p.id, CURRENT_DATE) tx ON true
 		 WHERE 1=1
 		`
+	args = append(args, request.CompanyID, request.Currency)
+
+	where, whereArgs, err := productFilterClause(request, "")
+	if err != nil {
+		return nil, err
+	}
+	query += where
+	args = append(args, whereArgs...)
+
+	if request.Limit <= 0 {
+		request.Limit = DefaultProductLimit
+	}
+
+	// Page > 0 keeps the LIMIT/OFFSET behaviour, otherwise the page starts at
+	// the cursor (or at the beginning when there is none).
+	var cursor *shared.Cursor
+	if request.Page <= 0 && request.Cursor != "" {
+		cursor, err = shared.DecodeCursor(request.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if cursor.Sort != request.EffectiveSort() {
+			return nil, shared.ErrInvalidCursor
+		}
+
+		condition, conditionArgs, err := shared.KeysetCondition(keys, cursor.Values, cursor.Backward)
+		if err != nil {
+			return nil, err
+		}
+		query += condition
+		args = append(args, conditionArgs...)
+	}
+	backward := cursor != nil && cursor.Backward
+
+	order, orderArgs := shared.KeysetOrder(keys, backward)
+	query += order
+	args = append(args, orderArgs...)
+
+	if request.Page > 0 {
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, request.Limit, (request.Page-1)*request.Limit)
+	} else {
+		// One extra row tells whether there is another page in this direction
+		query += " LIMIT ?"
+		args = append(args, request.Limit+1)
+	}
+
+	query = r.db.Rebind(query)
+
+	var products []GetProducts
+	if err := r.db.SelectContext(ctx, &products, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrProductNotFound
+		}
+		return nil, shared.PostgresError(err)
+	}
+
+	page := &ProductPage{}
+	if request.Page <= 0 {
+		hasMore := len(products) > request.Limit
+		if hasMore {
+			products = products[:request.Limit]
+		}
+		if backward {
+			slices.Reverse(products)
+		}
+
+		if len(products) > 0 {
+			sort := request.EffectiveSort()
+			first, last := products[0], products[len(products)-1]
+			if (!backward && hasMore) || backward {
+				page.NextCursor, err = productCursor(sort, last, false)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if (backward && hasMore) || (!backward && cursor != nil) {
+				page.PrevCursor, err = productCursor(sort, first, true)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	page.Products = products
+
+	countQuery := r.db.Rebind("SELECT COUNT(*)" + productFacetFrom + " WHERE 1=1" + where)
+	switch request.Count {
+	case shared.CountNone:
+	case shared.CountEstimated:
+		total, err := shared.EstimateCount(ctx, r.db, countQuery, whereArgs...)
+		if err != nil {
+			return nil, err
+		}
+		page.TotalCount = &total
+		page.Estimated = true
+	default:
+		var total int
+		if err := r.db.GetContext(ctx, &total, countQuery, whereArgs...); err != nil {
+			return nil, shared.PostgresError(err)
+		}
+		page.TotalCount = &total
+	}
+
+	return page, nil
+}
+
+// productSortKeys returns the keyset ordering for the requested sort. Every
+// order ends with p.id so pages are stable when the sort key ties.
+func productSortKeys(request ProductFilters) []shared.SortKey {
+	id := shared.SortKey{Expr: "p.id", Type: "uuid"}
+	createdAt := shared.SortKey{Expr: "p.created_at", Type: "timestamp", Desc: true}
+	// Products without an active variant sort after the priced ones
+	noPrice := shared.SortKey{Expr: "pr.min_price IS NULL", Type: "boolean"}
+
+	switch request.EffectiveSort() {
+	case SortRelevance:
+		similarity := shared.SortKey{Expr: "similarity(p.name, ?)", Args: []interface{}{request.Search}, Type: "real", Desc: true}
+		id.Desc = true
+		if tsQuery := prefixTSQuery(request.Search); tsQuery != "" {
+			rank := shared.SortKey{Expr: "ts_rank(p.search_vector, to_tsquery('english', ?))", Args: []interface{}{tsQuery}, Type: "real", Desc: true}
+			return []shared.SortKey{rank, similarity, createdAt, id}
+		}
+		return []shared.SortKey{similarity, createdAt, id}
+	case SortPriceAsc:
+		return []shared.SortKey{noPrice, {Expr: "pr.min_price", Type: "numeric"}, id}
+	case SortPriceDesc:
+		id.Desc = true
+		return []shared.SortKey{noPrice, {Expr: "pr.min_price", Type: "numeric", Desc: true}, id}
+	case SortName:
+		return []shared.SortKey{{Expr: "p.name", Type: "text"}, id}
+	case SortRating:
+		id.Desc = true
+		return []shared.SortKey{{Expr: "p.rating_average", Type: "numeric", Desc: true}, {Expr: "p.rating_count", Type: "integer", Desc: true}, id}
+	default:
+		id.Desc = true
+		return []shared.SortKey{createdAt, id}
+	}
+}
+
+func productCursor(sort string, row GetProducts, backward bool) (*string, error) {
+	var values []*string
+	if err := json.Unmarshal(row.CursorKey, &values); err != nil {
+		return nil, err
+	}
 
+	cursor := shared.EncodeCursor(shared.Cursor{Sort: sort, Backward: backward, Values: values})
+	return &cursor, nil
+}
+
+// productFilterClause renders the listing filters as " AND ..." conditions over
+// the products p / brands b / categories c joins. The conditions that belong to
+// the excluded facet are left out so facet counts ignore their own selection.
+func productFilterClause(request ProductFilters, exclude string) (string, []interface{}, error) {
+	var clause strings.Builder
 	var args []interface{}
 
 	if request.Status != "" {
-		query += " AND p.status = ?"
+		clause.WriteString(" AND p.status = ?")
 		args = append(args, request.Status)
 	}
 
-	if len(request.Category) > 0 {
-		q, inArgs, err := sqlx.In(" AND c.slug IN (?)", request.Category)
+	if request.SellerID != nil {
+		clause.WriteString(" AND p.seller_id = ?")
+		args = append(args, *request.SellerID)
+	}
+
+	if len(request.Category) > 0 && exclude != FacetCategory {
+		categoryFilter := " AND c.slug IN (?)"
+		if request.IncludeDescendants {
+			categoryFilter = " AND EXISTS (SELECT 1 FROM categories fc WHERE fc.slug IN (?) AND c.path LIKE fc.path || '%')"
+		}
+
+		q, inArgs, err := sqlx.In(categoryFilter, request.Category)
 		if err != nil {
-			return nil, err
+			return "", nil, err
 		}
 
-		query += q
+		clause.WriteString(q)
 		args = append(args, inArgs...)
 	}
 
-	if len(request.Brand) > 0 {
+	if len(request.Brand) > 0 && exclude != FacetBrand {
 		q, inArgs, err := sqlx.In(" AND b.slug IN (?)", request.Brand)
 		if err != nil {
-			return nil, err
+			return "", nil, err
+		}
+
+		clause.WriteString(q)
+		args = append(args, inArgs...)
+	}
+
+	if len(request.Certification) > 0 && exclude != FacetCertification {
+		standards := make([]string, 0, len(request.Certification))
+		for _, standard := range request.Certification {
+			standards = append(standards, strings.ToUpper(standard))
+		}
+
+		q, inArgs, err := sqlx.In(" AND EXISTS (SELECT 1 FROM product_certificates pcert WHERE pcert.product_id = p.id AND UPPER(pcert.standard) IN (?) AND certificate_valid(pcert.issued_on, pcert.expires_on, CURRENT_DATE))", standards)
+		if err != nil {
+			return "", nil, err
 		}
 
-		query += q
+		clause.WriteString(q)
 		args = append(args, inArgs...)
 	}
 
+	// Full-text match on the weighted search_vector with prefix terms for
+	// type-ahead, falling back to trigram similarity on the name for typos.
 	if request.Search != "" {
-		query += " AND (p.name ILIKE ? OR p.description ILIKE ?)"
-		searchTerm := "%" + request.Search + "%"
-		args = append(args, searchTerm, searchTerm)
+		if tsQuery := prefixTSQuery(request.Search); tsQuery != "" {
+			clause.WriteString(" AND (p.search_vector @@ to_tsquery('english', ?) OR p.name % ?)")
+			args = append(args, tsQuery, request.Search)
+		} else {
+			clause.WriteString(" AND p.name % ?")
+			args = append(args, request.Search)
+		}
+	}
+
+	// A product is in the price range when any of its active variants is, at
+	// the price the requesting company pays in the requested currency.
+	if (request.MinPrice > 0 || request.MaxPrice > 0) && exclude != FacetPrice {
+		clause.WriteString(" AND EXISTS (SELECT 1 FROM product_variants pv, LATERAL (SELECT contract_price(pv.id, ?::uuid, pv.price, ?) AS price) vp WHERE pv.product_id = p.id AND pv.is_active")
+		args = append(args, request.CompanyID, request.Currency)
+		if request.MinPrice > 0 {
+			clause.WriteString(" AND vp.price >= ?")
+			args = append(args, request.MinPrice)
+		}
+		if request.MaxPrice > 0 {
+			clause.WriteString(" AND vp.price <= ?")
+			args = append(args, request.MaxPrice)
+		}
+		clause.WriteString(")")
 	}
 
-	query += " ORDER BY p.created_at DESC LIMIT ? OFFSET ?"
+	if request.MinRating > 0 {
+		clause.WriteString(" AND p.rating_average >= ?")
+		args = append(args, request.MinRating)
+	}
 
-	if request.Limit <= 0 {
-		request.Limit = 40
+	for _, key := range slices.Sorted(maps.Keys(request.Attributes)) {
+		values := request.Attributes[key]
+		if len(values) == 0 || exclude == attributeFacet(key) {
+			continue
+		}
+
+		q, inArgs, err := sqlx.In(" AND EXISTS (SELECT 1 FROM products_attributes pa WHERE pa.product_id = p.id AND pa.attribute_key = ? AND pa.attribute_value IN (?))", key, values)
+		if err != nil {
+			return "", nil, err
+		}
+
+		clause.WriteString(q)
+		args = append(args, inArgs...)
 	}
-	offset := (request.Page - 1) * request.Limit
-	args = append(args, request.Limit, offset)
 
-	query = r.db.Rebind(query)
+	return clause.String(), args, nil
+}
 
-	var products []GetProducts
-	if err := r.db.SelectContext(ctx, &products, query, args...); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("product not found")
+const productFacetFrom = `
+		FROM products p
+		LEFT JOIN brands b ON p.brand_id = b.id
+		LEFT JOIN categories c ON p.category_id = c.id`
+
+// GetProductFacets counts the products matching the filters per brand,
+// category, price range and attribute value. Each facet is counted with its
+// own selection removed so the UI can offer the alternatives.
+func (r *ProductRepo) GetProductFacets(ctx context.Context, request ProductFilters) (*ProductFacetCounts, error) {
+	var facets ProductFacetCounts
+	g, ctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		var err error
+		facets.Brands, err = r.countFacet(ctx, request, FacetBrand, "b.slug", "b.name")
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		facets.Categories, err = r.countFacet(ctx, request, FacetCategory, "c.slug", "c.name")
+		return err
+	})
+
+	g.Go(func() error {
+		where, args, err := productFilterClause(request, FacetPrice)
+		if err != nil {
+			return err
+		}
+
+		query := "SELECT array_agg(convert_price(bound, store_currency(), $2) ORDER BY n) FROM unnest($1::numeric[]) WITH ORDINALITY AS b(bound, n)"
+		if err := r.db.GetContext(ctx, pq.Array(&facets.PriceBounds), query, pq.Array(PriceFacetBounds), request.Currency); err != nil {
+			return err
+		}
+
+		query = `SELECT width_bucket(pr.min_price, ?::numeric[]) AS bucket, COUNT(*) AS count` + productFacetFrom + `
+		JOIN LATERAL (
+		SELECT MIN(contract_price(pv.id, ?::uuid, pv.price, ?)) AS min_price FROM product_variants pv WHERE pv.product_id = p.id AND pv.is_active
+		) pr ON true
+		WHERE pr.min_price IS NOT NULL` + where + ` GROUP BY bucket ORDER BY bucket`
+		args = append([]interface{}{pq.Array(facets.PriceBounds), request.CompanyID, request.Currency}, args...)
+
+		return r.db.SelectContext(ctx, &facets.PriceRanges, r.db.Rebind(query), args...)
+	})
+
+	g.Go(func() error {
+		where, args, err := productFilterClause(request, FacetCertification)
+		if err != nil {
+			return err
+		}
+
+		query := `SELECT '` + FacetCertification + `' AS key, UPPER(pcert.standard) AS value, MIN(pcert.standard) AS label, COUNT(DISTINCT p.id) AS count` + productFacetFrom + `
+		JOIN product_certificates pcert ON pcert.product_id = p.id AND certificate_valid(pcert.issued_on, pcert.expires_on, CURRENT_DATE)
+		WHERE 1=1` + where + ` GROUP BY UPPER(pcert.standard) ORDER BY count DESC, label`
+
+		return r.db.SelectContext(ctx, &facets.Certifications, r.db.Rebind(query), args...)
+	})
+
+	// Attribute keys without an active filter share one query; every filtered
+	// key is counted separately with its own filter excluded.
+	var filteredKeys []string
+	for _, key := range slices.Sorted(maps.Keys(request.Attributes)) {
+		if len(request.Attributes[key]) > 0 {
+			filteredKeys = append(filteredKeys, key)
+		}
+	}
+
+	attributeCounts := make([][]FacetCount, len(filteredKeys)+1)
+
+	g.Go(func() error {
+		where, args, err := productFilterClause(request, "")
+		if err != nil {
+			return err
+		}
+
+		query := `SELECT pa.attribute_key AS key, pa.attribute_value AS value, pa.attribute_value AS label, COUNT(DISTINCT p.id) AS count` + productFacetFrom + `
+		JOIN products_attributes pa ON pa.product_id = p.id
+		WHERE 1=1` + where
+		if len(filteredKeys) > 0 {
+			q, inArgs, err := sqlx.In(" AND pa.attribute_key NOT IN (?)", filteredKeys)
+			if err != nil {
+				return err
+			}
+			query += q
+			args = append(args, inArgs...)
 		}
+		query += " GROUP BY pa.attribute_key, pa.attribute_value ORDER BY pa.attribute_key, count DESC, pa.attribute_value"
+
+		return r.db.SelectContext(ctx, &attributeCounts[0], r.db.Rebind(query), args...)
+	})
+
+	for i, key := range filteredKeys {
+		g.Go(func() error {
+			where, args, err := productFilterClause(request, attributeFacet(key))
+			if err != nil {
+				return err
+			}
+
+			query := `SELECT pa.attribute_key AS key, pa.attribute_value AS value, pa.attribute_value AS label, COUNT(DISTINCT p.id) AS count` + productFacetFrom + `
+			JOIN products_attributes pa ON pa.product_id = p.id
+			WHERE pa.attribute_key = ?` + where + ` GROUP BY pa.attribute_key, pa.attribute_value ORDER BY count DESC, pa.attribute_value`
+			args = append([]interface{}{key}, args...)
+
+			return r.db.SelectContext(ctx, &attributeCounts[i+1], r.db.Rebind(query), args...)
+		})
+	}
+
+	if err := g.Wait(); err != nil {
 		return nil, shared.PostgresError(err)
 	}
 
-	return products, nil
+	for _, counts := range attributeCounts {
+		facets.Attributes = append(facets.Attributes, counts...)
+	}
+
+	return &facets, nil
+}
+
+func (r *ProductRepo) countFacet(ctx context.Context, request ProductFilters, facet, valueColumn, labelColumn string) ([]FacetCount, error) {
+	where, args, err := productFilterClause(request, facet)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`SELECT '%[3]s' AS key, %[1]s AS value, %[2]s AS label, COUNT(DISTINCT p.id) AS count`, valueColumn, labelColumn, facet) + productFacetFrom + `
+		WHERE ` + valueColumn + ` IS NOT NULL` + where + `
+		GROUP BY ` + valueColumn + `, ` + labelColumn + ` ORDER BY count DESC, label`
+
+	var counts []FacetCount
+	if err := r.db.SelectContext(ctx, &counts, r.db.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	return counts, nil
 }
 
 func (r *ProductRepo) AddProductAttribute(ctx context.Context, productID string, request []ProductAttributeArrayDTO) error {
@@ -154,7 +752,22 @@ func (r *ProductRepo) AddProductAttribute(ctx context.Context, productID string,
 
 	defer tx.Rollback()
 
-	_, err = tx.ExecContext(ctx, "DELETE FROM products_attributes WHERE product_id=$1", productID)
+	if err := replaceProductAttributes(ctx, tx, productID, request); err != nil {
+		return err
+	}
+
+	if err := returnToReview(ctx, tx, productID); err != nil {
+		return err
+	}
+
+	if err := recordProductVersion(ctx, tx, productID, ChangeAttributes, changedBy(ctx), nil); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func replaceProductAttributes(ctx context.Context, tx *sqlx.Tx, productID string, request []ProductAttributeArrayDTO) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM products_attributes WHERE product_id=$1", productID)
 	if err != nil {
 		return shared.PostgresError(err)
 	}
@@ -178,16 +791,42 @@ func (r *ProductRepo) AddProductAttribute(ctx context.Context, productID string,
 			return shared.PostgresError(err)
 		}
 	}
-	return tx.Commit()
+	return nil
 }
 
-func (r *ProductRepo) GetProductAttributeByID(ctx context.Context, productID string) ([]ProductAttribute, error) {
-	var productAttribute []ProductAttribute
+// GetAttributeDefinitions returns the effective attribute schema of the
+// product's category; the nearest category wins when a key is defined twice.
+func (r *ProductRepo) GetAttributeDefinitions(ctx context.Context, productID string) ([]AttributeDefinition, error) {
+	return r.getAttributeDefinitions(ctx, "(SELECT category_id FROM products WHERE id = $1)", productID)
+}
+
+// GetCategoryAttributeDefinitions is GetAttributeDefinitions for a category.
+func (r *ProductRepo) GetCategoryAttributeDefinitions(ctx context.Context, categoryID string) ([]AttributeDefinition, error) {
+	return r.getAttributeDefinitions(ctx, "$1", categoryID)
+}
+
+func (r *ProductRepo) getAttributeDefinitions(ctx context.Context, categoryExpr string, arg string) ([]AttributeDefinition, error) {
+	var definitions []AttributeDefinition
+	query := `SELECT DISTINCT ON (ca.attribute_key) ca.attribute_key, ca.label, ca.type, ca.unit, ca.required, ca.allowed_values
+		FROM categories c
+		JOIN categories a ON c.path LIKE a.path || '%'
+		JOIN category_attributes ca ON ca.category_id = a.id
+		WHERE c.id = ` + categoryExpr + `
+		ORDER BY ca.attribute_key, a.level DESC`
+	if err := r.db.SelectContext(ctx, &definitions, query, arg); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+
+	return definitions, nil
+}
+
+func (r *ProductRepo) GetProductAttributeByID(ctx context.Context, productID string) ([]ProductAttribute, error) {
+	var productAttribute []ProductAttribute
 	query := "SELECT * FROM products_attributes WHERE product_id=$1"
 	if err := r.db.SelectContext(ctx, &productAttribute, query, productID); err != nil {
 		fmt.Println("err", err)
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("product not found")
+			return nil, ErrProductNotFound
 		}
 		return nil, shared.PostgresError(err)
 	}
@@ -202,6 +841,58 @@ func (r *ProductRepo) SyncProductVariants(ctx context.Context, productId string,
 
 	defer tx.Rollback()
 
+	if err := syncProductVariants(ctx, tx, productId, req); err != nil {
+		return err
+	}
+
+	if err := returnToReview(ctx, tx, productId); err != nil {
+		return err
+	}
+
+	if err := recordProductVersion(ctx, tx, productId, ChangeVariants, changedBy(ctx), nil); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func syncProductVariants(ctx context.Context, tx *sqlx.Tx, productId string, req VariantRequestDTO) error {
+	if err := validateOptions(req.Options); err != nil {
+		return err
+	}
+
+	// Resolve every variant before writing, values are identified by option and value
+	variantKeys := make([][]string, len(req.Variants))
+	for i, v := range req.Variants {
+		keys, err := resolveVariantOptions(req.Options, v)
+		if err != nil {
+			return err
+		}
+		variantKeys[i] = keys
+
+		if err := v.OrderRules.Validate(); err != nil {
+			return fmt.Errorf("%w: sku %q: %v", ErrInvalidVariants, v.SKU, err)
+		}
+	}
+
+	// SKUs are unique across the catalog, so one of another product is refused
+	// rather than taken over by the upsert below
+	skus := make([]string, 0, len(req.Variants))
+	for _, v := range req.Variants {
+		skus = append(skus, v.SKU)
+	}
+	var taken []struct {
+		SKU  string `db:"sku"`
+		Slug string `db:"slug"`
+	}
+	query := "SELECT pv.sku, p.slug FROM product_variants pv JOIN products p ON p.id = pv.product_id WHERE pv.sku = ANY($1) AND pv.product_id <> $2"
+	if err := tx.SelectContext(ctx, &taken, query, pq.Array(skus), productId); err != nil {
+		return shared.PostgresError(err)
+	}
+	if len(taken) > 0 {
+		return fmt.Errorf("%w: sku %q already belongs to product %q", ErrInvalidVariants, taken[0].SKU, taken[0].Slug)
+	}
+
 	// Delete on old data
 	if _, err := tx.ExecContext(ctx, "DELETE FROM product_options WHERE product_id = $1", productId); err != nil {
 		return shared.PostgresError(err)
@@ -214,18 +905,18 @@ func (r *ProductRepo) SyncProductVariants(ctx context.Context, productId string,
 
 	// Insert OPTIONs and VALUES (Capturing IDs for mapping)
 	valueMap := make(map[string]string)
-	for _, opt := range req.Options {
+	for i, opt := range req.Options {
 		var optionID string
-		if err := tx.QueryRowContext(ctx, "INSERT INTO product_options (product_id, name) VALUES ($1,$2) RETURNING id", productId, opt.Name).Scan(&optionID); err != nil {
+		if err := tx.QueryRowContext(ctx, "INSERT INTO product_options (product_id, name, position) VALUES ($1,$2,$3) RETURNING id", productId, opt.Name, i).Scan(&optionID); err != nil {
 			return shared.PostgresError(err)
 		}
 
-		for _, val := range opt.Values {
+		for j, val := range opt.Values {
 			var valID string
-			if err := tx.QueryRowContext(ctx, "INSERT INTO product_option_values (option_id, value) VALUES ($1, $2) RETURNING id", optionID, val).Scan(&valID); err != nil {
+			if err := tx.QueryRowContext(ctx, "INSERT INTO product_option_values (option_id, value, position) VALUES ($1, $2, $3) RETURNING id", optionID, val, j).Scan(&valID); err != nil {
 				return shared.PostgresError(err)
 			}
-			valueMap[val] = valID
+			valueMap[optionValueKey(opt.Name, val)] = valID
 		}
 	}
 
@@ -233,14 +924,16 @@ func (r *ProductRepo) SyncProductVariants(ctx context.Context, productId string,
 	if len(req.Variants) > 0 {
 		variantPlaceholders := []string{}
 		variantValues := []interface{}{}
-		numFields := 5
+		numFields := 9
 
+		// Variants without a currency are priced in the store currency
 		for i, v := range req.Variants {
 			offset := i * numFields
-			variantPlaceholders = append(variantPlaceholders, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d)", offset+1, offset+2, offset+3, offset+4, offset+5))
-			variantValues = append(variantValues, productId, v.SKU, v.Price, v.Weight, v.IsActive)
+			rules := v.OrderRules.Normalized()
+			variantPlaceholders = append(variantPlaceholders, fmt.Sprintf("($%d, $%d, $%d, COALESCE(NULLIF($%d, ''), store_currency()), $%d, $%d, $%d, $%d, $%d)", offset+1, offset+2, offset+3, offset+4, offset+5, offset+6, offset+7, offset+8, offset+9))
+			variantValues = append(variantValues, productId, v.SKU, v.Price, v.Currency, v.Weight, v.IsActive, rules.Unit, rules.MinOrderQuantity, rules.OrderMultiple)
 		}
-		variantQuery := fmt.Sprintf(`INSERT INTO product_variants (product_id, sku, price, weight, is_active) VALUES %s ON CONFLICT (sku) DO UPDATE SET price = EXCLUDED.price, weight = EXCLUDED.weight, is_active = EXCLUDED.is_active, updated_at = CURRENT_TIMESTAMP RETURNING id, sku`, strings.Join(variantPlaceholders, ","))
+		variantQuery := fmt.Sprintf(`INSERT INTO product_variants (product_id, sku, price, currency, weight, is_active, unit, min_order_quantity, order_multiple) VALUES %s ON CONFLICT (sku) DO UPDATE SET price = EXCLUDED.price, currency = EXCLUDED.currency, weight = EXCLUDED.weight, is_active = EXCLUDED.is_active, unit = EXCLUDED.unit, min_order_quantity = EXCLUDED.min_order_quantity, order_multiple = EXCLUDED.order_multiple, updated_at = CURRENT_TIMESTAMP WHERE product_variants.product_id = EXCLUDED.product_id RETURNING id, sku`, strings.Join(variantPlaceholders, ","))
 		rows, err := tx.QueryContext(ctx, variantQuery, variantValues...)
 		if err != nil {
 			return shared.PostgresError(err)
@@ -262,11 +955,21 @@ func (r *ProductRepo) SyncProductVariants(ctx context.Context, productId string,
 			}
 			skuToID[sku] = id
 		}
+		if err := rows.Err(); err != nil {
+			return shared.PostgresError(err)
+		}
 
+		// A SKU another product took since the check above is not returned
 		for _, v := range req.Variants {
+			if _, ok := skuToID[v.SKU]; !ok {
+				return fmt.Errorf("%w: sku %q already belongs to another product", ErrInvalidVariants, v.SKU)
+			}
+		}
+
+		for i, v := range req.Variants {
 			vID := skuToID[v.SKU]
-			for _, optValName := range v.OptionValues {
-				if valID, ok := valueMap[optValName]; ok {
+			for _, key := range variantKeys[i] {
+				if valID, ok := valueMap[key]; ok {
 					linkPlaceholders = append(linkPlaceholders, fmt.Sprintf("($%d, $%d)", linkCount, linkCount+1))
 					linkValues = append(linkValues, vID, valID)
 					linkCount += 2
@@ -280,28 +983,84 @@ func (r *ProductRepo) SyncProductVariants(ctx context.Context, productId string,
 				return shared.PostgresError(err)
 			}
 		}
+
+		// Pack sizes are replaced like the option links
+		variantIDs := make([]string, 0, len(skuToID))
+		for _, id := range skuToID {
+			variantIDs = append(variantIDs, id)
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM variant_pack_sizes WHERE variant_id = ANY($1)", pq.Array(variantIDs)); err != nil {
+			return shared.PostgresError(err)
+		}
+		for _, v := range req.Variants {
+			for _, pack := range v.PackSizes {
+				query := "INSERT INTO variant_pack_sizes (variant_id, unit, quantity) VALUES ($1, $2, $3)"
+				if _, err := tx.ExecContext(ctx, query, skuToID[v.SKU], pack.Unit, pack.Quantity); err != nil {
+					return shared.PostgresError(err)
+				}
+			}
+		}
 	}
 
-	return tx.Commit()
+	return nil
 }
 
-func (r *ProductRepo) GetProductVariants(ctx context.Context, productId string) (*ProductVariants, error) {
+// GetCompanyID returns the company of the signed-in user, see shared.CompanyID.
+func (r *ProductRepo) GetCompanyID(ctx context.Context) (*string, error) {
+	return shared.CompanyID(ctx, r.db)
+}
+
+// GetTaxRule returns the tax rule of a product today, see shared.ProductTaxRule.
+func (r *ProductRepo) GetTaxRule(ctx context.Context, productID string) (*shared.TaxRule, error) {
+	return shared.ProductTaxRule(ctx, r.db, productID, time.Now())
+}
+
+// BuyerState resolves the GST state to tax for, see shared.BuyerState.
+func (r *ProductRepo) BuyerState(ctx context.Context, shipTo string) (string, error) {
+	return shared.BuyerState(ctx, r.db, shipTo)
+}
+
+// CurrencyPlaces returns the decimal places of a currency, see
+// shared.CurrencyPlaces.
+func (r *ProductRepo) CurrencyPlaces(ctx context.Context, currency string) (int, error) {
+	return shared.CurrencyPlaces(ctx, r.db, currency)
+}
+
+// ResolveCurrency checks a requested currency, see shared.ResolveCurrency.
+func (r *ProductRepo) ResolveCurrency(ctx context.Context, currency string) (string, error) {
+	return shared.ResolveCurrency(ctx, r.db, currency)
+}
+
+// GetProductVariants loads the options and variants of a product. Prices are
+// resolved for companyID through contract_price; list_price keeps the
+// catalog price. A nil companyID returns list prices, a nil currency the
+// prices in each variant's own currency.
+func (r *ProductRepo) GetProductVariants(ctx context.Context, productId string, companyID, currency *string) (*ProductVariants, error) {
 	query := `
 	WITH product_options_data AS (
-	SELECT po.id, po.name, jsonb_agg(pov.value) AS values FROM product_options po JOIN product_option_values pov ON po.id = pov.option_id WHERE po.product_id = $1 GROUP BY po.id, po.name
+	SELECT po.id, po.name, po.position, jsonb_agg(pov.value ORDER BY pov.position) AS values FROM product_options po JOIN product_option_values pov ON po.id = pov.option_id WHERE po.product_id = $1 GROUP BY po.id, po.name, po.position
 	),
 	variants_data AS (
-	SELECT pv.id, pv.sku, pv.price, pv.weight, pv.is_active, jsonb_agg(pov.value) AS option_values FROM product_variants pv JOIN variant_option_values vov ON pv.id = vov.variant_id JOIN product_option_values pov ON vov.option_value_id = pov.id WHERE pv.product_id = $1 GROUP BY pv.id, pv.sku, pv.price, pv.weight, pv.is_active
+	SELECT pv.id, pv.sku, contract_price(pv.id, $2::uuid, pv.price, $3) AS price, convert_price(pv.price, pv.currency, $3) AS list_price,
+	COALESCE($3, pv.currency) AS currency, pv.weight, pv.is_active, pv.unit, pv.min_order_quantity, pv.order_multiple, pack_sizes(pv.id) AS pack_sizes,
+	COALESCE(jsonb_agg(pov.value ORDER BY po.position) FILTER (WHERE pov.id IS NOT NULL), '[]') AS option_values,
+	COALESCE(jsonb_object_agg(po.name, pov.value) FILTER (WHERE pov.id IS NOT NULL), '{}') AS options
+	FROM product_variants pv LEFT JOIN variant_option_values vov ON pv.id = vov.variant_id LEFT JOIN product_option_values pov ON vov.option_value_id = pov.id LEFT JOIN product_options po ON po.id = pov.option_id
+	WHERE pv.product_id = $1 GROUP BY pv.id
+	),
+	tiers_data AS (
+	SELECT vpt.variant_id, jsonb_agg(jsonb_build_object('min_quantity', vpt.min_quantity, 'max_quantity', vpt.max_quantity, 'unit_price', contract_price(vpt.variant_id, $2::uuid, vpt.unit_price, $3)) ORDER BY vpt.min_quantity) AS price_tiers
+	FROM variant_price_tiers vpt JOIN product_variants pv ON pv.id = vpt.variant_id WHERE pv.product_id = $1 GROUP BY vpt.variant_id
 	)
 	SELECT 
 	$1 AS product_id, 
-	(SELECT jsonb_agg(jsonb_build_object('name', name, 'values', values )) FROM product_options_data) AS options,
-	(SELECT jsonb_agg(jsonb_build_object('id', id, 'sku', sku, 'price', price, 'weight', weight, 'is_active', is_active, 'option_values', option_values)) FROM variants_data) AS variants;
+	COALESCE((SELECT jsonb_agg(jsonb_build_object('name', name, 'values', values) ORDER BY position) FROM product_options_data), '[]') AS options,
+	COALESCE((SELECT jsonb_agg(jsonb_build_object('id', vd.id, 'sku', vd.sku, 'price', vd.price, 'list_price', vd.list_price, 'currency', vd.currency, 'weight', vd.weight, 'is_active', vd.is_active, 'unit', vd.unit, 'min_order_quantity', vd.min_order_quantity, 'order_multiple', vd.order_multiple, 'pack_sizes', vd.pack_sizes, 'options', vd.options, 'option_values', vd.option_values, 'price_tiers', COALESCE(td.price_tiers, '[]')) ORDER BY vd.sku) FROM variants_data vd LEFT JOIN tiers_data td ON td.variant_id = vd.id), '[]') AS variants;
 	`
 
 	var result ProductVariants
 
-	if err := r.db.GetContext(ctx, &result, query, productId); err != nil {
+	if err := r.db.GetContext(ctx, &result, query, productId, companyID, currency); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
@@ -319,14 +1078,30 @@ func (r *ProductRepo) AddProductMedia(ctx context.Context, productId string, med
 
 	defer tx.Rollback()
 
+	if err := replaceProductMedia(ctx, tx, productId, media); err != nil {
+		return err
+	}
+
+	if err := returnToReview(ctx, tx, productId); err != nil {
+		return err
+	}
+
+	if err := recordProductVersion(ctx, tx, productId, ChangeMedia, changedBy(ctx), nil); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func replaceProductMedia(ctx context.Context, tx *sqlx.Tx, productId string, media []ProductMediaDTO) error {
 	// Delete old media to sync with current one
-	_, err = tx.ExecContext(ctx, "DELETE FROM product_media WHERE product_id = $1", productId)
+	_, err := tx.ExecContext(ctx, "DELETE FROM product_media WHERE product_id = $1", productId)
 	if err != nil {
 		return shared.PostgresError(err)
 	}
 
 	if len(media) == 0 {
-		return tx.Commit()
+		return nil
 	}
 
 	// Prepare Bulk Insert
@@ -346,7 +1121,7 @@ func (r *ProductRepo) AddProductMedia(ctx context.Context, productId string, med
 		return shared.PostgresError(err)
 	}
 
-	return tx.Commit()
+	return nil
 }
 
 func (r *ProductRepo) GetProductMedia(ctx context.Context, productId string) ([]ProductMedia, error) {
@@ -373,6 +1148,26 @@ func (r *ProductRepo) SaveProductSEO(ctx context.Context, seo ProductSEO) error
 
 	defer tx.Rollback()
 
+	if err := upsertProductSEO(ctx, tx, seo); err != nil {
+		return err
+	}
+
+	if err := returnToReview(ctx, tx, seo.ProductID); err != nil {
+		return err
+	}
+
+	if err := recordProductVersion(ctx, tx, seo.ProductID, ChangeSEO, changedBy(ctx), nil); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return shared.PostgresError(err)
+	}
+
+	return nil
+}
+
+func upsertProductSEO(ctx context.Context, tx *sqlx.Tx, seo ProductSEO) error {
 	query := `
 		INSERT INTO product_seo (product_id, meta_title, meta_description, og_image_url, keywords)
 		VALUES (:product_id, :meta_title, :meta_description, :og_image_url, :keywords)
@@ -382,18 +1177,7 @@ func (r *ProductRepo) SaveProductSEO(ctx context.Context, seo ProductSEO) error
 			og_image_url = EXCLUDED.og_image_url,
 			keywords = EXCLUDED.keywords`
 
-	_, err = tx.NamedExecContext(ctx, query, seo)
-	if err != nil {
-		return shared.PostgresError(err)
-	}
-
-	publishProduct := `UPDATE products SET status = 'ACTIVE' WHERE id = $1`
-	_, err = tx.ExecContext(ctx, publishProduct, seo.ProductID)
-	if err != nil {
-		return shared.PostgresError(err)
-	}
-
-	if err := tx.Commit(); err != nil {
+	if _, err := tx.NamedExecContext(ctx, query, seo); err != nil {
 		return shared.PostgresError(err)
 	}
 
@@ -414,3 +1198,826 @@ func (r *ProductRepo) GetProductSEO(ctx context.Context, productId string) (*Pro
 
 	return &seo, nil
 }
+
+func (r *ProductRepo) GetProductBrand(ctx context.Context, brandID string) (*ProductBrand, error) {
+	var brand ProductBrand
+	query := "SELECT id, name, slug, logo_url FROM brands WHERE id=$1"
+	if err := r.db.GetContext(ctx, &brand, query, brandID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, shared.PostgresError(err)
+	}
+	return &brand, nil
+}
+
+// GetCategoryBreadcrumb walks parent_id up from the given category and returns
+// the chain ordered from the root down to the category itself.
+func (r *ProductRepo) GetCategoryBreadcrumb(ctx context.Context, categoryID string) ([]CategoryBreadcrumb, error) {
+	var breadcrumb []CategoryBreadcrumb
+	query := `
+	WITH RECURSIVE ancestors AS (
+		SELECT id, name, slug, parent_id, 0 AS depth FROM categories WHERE id = $1
+		UNION ALL
+		SELECT c.id, c.name, c.slug, c.parent_id, a.depth + 1 FROM categories c JOIN ancestors a ON c.id = a.parent_id WHERE a.depth < 32
+	)
+	SELECT id, name, slug FROM ancestors ORDER BY depth DESC`
+
+	if err := r.db.SelectContext(ctx, &breadcrumb, query, categoryID); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+	return breadcrumb, nil
+}
+
+func (r *ProductRepo) GetBrandIDBySlug(ctx context.Context, slug string) (*string, error) {
+	var id string
+	if err := r.db.GetContext(ctx, &id, "SELECT id FROM brands WHERE slug=$1", slug); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, shared.PostgresError(err)
+	}
+	return &id, nil
+}
+
+// GetImportTarget looks up the product of any status that carries slug, or
+// carried it before being renamed. It returns nil when there is none.
+func (r *ProductRepo) GetImportTarget(ctx context.Context, slug string) (*ImportTarget, error) {
+	var target ImportTarget
+	query := "SELECT id, slug, seller_id, status FROM products WHERE slug=$1"
+	err := r.db.GetContext(ctx, &target, query, slug)
+	if errors.Is(err, sql.ErrNoRows) {
+		var moved *shared.SlugMovedError
+		if err := shared.MovedSlug(ctx, r.db, "products", slug); !errors.As(err, &moved) {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		err = r.db.GetContext(ctx, &target, query, moved.Slug)
+	}
+	if err != nil {
+		return nil, shared.PostgresError(err)
+	}
+	return &target, nil
+}
+
+func (r *ProductRepo) GetCategoryIDBySlug(ctx context.Context, slug string) (*string, error) {
+	var id string
+	if err := r.db.GetContext(ctx, &id, "SELECT id FROM categories WHERE slug=$1", slug); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, shared.PostgresError(err)
+	}
+	return &id, nil
+}
+
+// GetProductSKUParts returns the product and brand slugs used by SKU templates.
+func (r *ProductRepo) GetProductSKUParts(ctx context.Context, productID string) (string, string, error) {
+	var parts struct {
+		Slug      string  `db:"slug"`
+		BrandSlug *string `db:"brand_slug"`
+	}
+	query := "SELECT p.slug, b.slug AS brand_slug FROM products p LEFT JOIN brands b ON b.id = p.brand_id WHERE p.id=$1"
+	if err := r.db.GetContext(ctx, &parts, query, productID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", ErrProductNotFound
+		}
+		return "", "", shared.PostgresError(err)
+	}
+	return parts.Slug, stringValue(parts.BrandSlug), nil
+}
+
+// GetVariantOwners maps each of the given SKUs that already exists to the
+// slug of the product it belongs to.
+func (r *ProductRepo) GetVariantOwners(ctx context.Context, skus []string) (map[string]string, error) {
+	owners := make(map[string]string, len(skus))
+	if len(skus) == 0 {
+		return owners, nil
+	}
+
+	var rows []struct {
+		SKU  string `db:"sku"`
+		Slug string `db:"slug"`
+	}
+	query := "SELECT pv.sku, p.slug FROM product_variants pv JOIN products p ON p.id = pv.product_id WHERE pv.sku = ANY($1)"
+	if err := r.db.SelectContext(ctx, &rows, query, pq.Array(skus)); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+
+	for _, row := range rows {
+		owners[row.SKU] = row.Slug
+	}
+	return owners, nil
+}
+
+// ImportProduct upserts a product by slug together with the sections present
+// in the import, in one transaction. It reports whether the product was new.
+// Only the seller's own DRAFT or REJECTED products are updated, as checked
+// before by the import; a row changed since is reported as ErrImportConflict.
+func (r *ProductRepo) ImportProduct(ctx context.Context, product ImportProduct) (string, bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return "", false, shared.PostgresError(err)
+	}
+
+	defer tx.Rollback()
+
+	query := `INSERT INTO products(name, slug, description, seller_id, brand_id, category_id, status)
+		VALUES ($1, $2, $3, $4, $5, $6, COALESCE(NULLIF($7, '')::status_enum, 'DRAFT'))
+		ON CONFLICT (slug) DO UPDATE SET
+			name = EXCLUDED.name,
+			description = COALESCE(EXCLUDED.description, products.description),
+			brand_id = EXCLUDED.brand_id,
+			category_id = EXCLUDED.category_id,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE products.seller_id = EXCLUDED.seller_id AND products.status IN ('DRAFT', 'REJECTED')
+		RETURNING id, (xmax = 0) AS inserted`
+
+	var productID string
+	var inserted bool
+	if err := tx.QueryRowContext(ctx, query, product.Name, product.Slug, product.Description, product.SellerID, product.BrandID, product.CategoryID, product.Status).Scan(&productID, &inserted); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", false, ErrImportConflict
+		}
+		return "", false, shared.PostgresError(err)
+	}
+
+	if len(product.Attributes) > 0 {
+		attributes := make([]ProductAttributeArrayDTO, 0, len(product.Attributes))
+		for _, attribute := range product.Attributes {
+			attributes = append(attributes, ProductAttributeArrayDTO{
+				ProductID:      productID,
+				AttributeKey:   attribute.AttributeKey,
+				AttributeValue: attribute.AttributeValue,
+			})
+		}
+		if err := replaceProductAttributes(ctx, tx, productID, attributes); err != nil {
+			return "", false, err
+		}
+	}
+
+	if product.Variants != nil {
+		if err := syncProductVariants(ctx, tx, productID, *product.Variants); err != nil {
+			return "", false, err
+		}
+	}
+
+	if len(product.Media) > 0 {
+		if err := replaceProductMedia(ctx, tx, productID, product.Media); err != nil {
+			return "", false, err
+		}
+	}
+
+	if product.SEO != nil {
+		seo := *product.SEO
+		seo.ProductID = productID
+		if err := upsertProductSEO(ctx, tx, seo); err != nil {
+			return "", false, err
+		}
+	}
+
+	// The importing user is the seller of new products and the author of the version
+	if err := recordProductVersion(ctx, tx, productID, ChangeImport, &product.SellerID, nil); err != nil {
+		return "", false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", false, shared.PostgresError(err)
+	}
+
+	return productID, inserted, nil
+}
+
+func (r *ProductRepo) CreateImportJob(ctx context.Context, job ImportJob) (*ImportJob, error) {
+	var created ImportJob
+	query := `INSERT INTO product_imports(format, dry_run, status, total_rows, created_by)
+		VALUES ($1, $2, $3, $4, $5) RETURNING *`
+	if err := r.db.GetContext(ctx, &created, query, job.Format, job.DryRun, job.Status, job.TotalRows, job.CreatedBy); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+	return &created, nil
+}
+
+// UpdateImportJob stores the progress of a job; finished_at is set once the
+// job reaches COMPLETED or FAILED.
+func (r *ProductRepo) UpdateImportJob(ctx context.Context, job ImportJob) error {
+	query := `UPDATE product_imports SET
+		status = $1, processed_rows = $2, succeeded_rows = $3, failed_rows = $4, report = $5, error = $6,
+		updated_at = CURRENT_TIMESTAMP,
+		finished_at = CASE WHEN $1 IN ('COMPLETED', 'FAILED') THEN CURRENT_TIMESTAMP END
+		WHERE id = $7`
+	if _, err := r.db.ExecContext(ctx, query, job.Status, job.ProcessedRows, job.SucceededRows, job.FailedRows, job.Report, job.Error, job.ID); err != nil {
+		return shared.PostgresError(err)
+	}
+	return nil
+}
+
+// GetImportJob returns a job started by userID.
+func (r *ProductRepo) GetImportJob(ctx context.Context, userID string, jobID string) (*ImportJob, error) {
+	var job ImportJob
+	if err := r.db.GetContext(ctx, &job, "SELECT * FROM product_imports WHERE id=$1 AND created_by=$2", jobID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrImportJobNotFound
+		}
+		return nil, shared.PostgresError(err)
+	}
+	return &job, nil
+}
+
+// GetExportColumns returns the attribute keys and option names used by the
+// products matching the filters.
+func (r *ProductRepo) GetExportColumns(ctx context.Context, request ProductFilters) (*ExportColumns, error) {
+	where, args, err := productFilterClause(request, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var columns ExportColumns
+	filtered := "SELECT p.id" + productFacetFrom + " WHERE 1=1" + where
+
+	query := "SELECT DISTINCT attribute_key FROM products_attributes WHERE product_id IN (" + filtered + ") ORDER BY attribute_key"
+	if err := r.db.SelectContext(ctx, &columns.AttributeKeys, r.db.Rebind(query), args...); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+
+	query = "SELECT DISTINCT name FROM product_options WHERE product_id IN (" + filtered + ") ORDER BY name"
+	if err := r.db.SelectContext(ctx, &columns.OptionNames, r.db.Rebind(query), args...); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+
+	return &columns, nil
+}
+
+// productOptionsQuery loads the options, with their values, of the products in $1.
+const productOptionsQuery = `SELECT po.product_id, po.name, array_agg(pov.value ORDER BY pov.position, pov.created_at) AS values
+	FROM product_options po JOIN product_option_values pov ON pov.option_id = po.id
+	WHERE po.product_id = ANY($1)
+	GROUP BY po.id, po.product_id, po.name
+	ORDER BY po.position, po.created_at`
+
+// productVariantsQuery loads the variants, with their option values, of the products in $1.
+const productVariantsQuery = `SELECT pv.product_id, pv.sku, pv.price, pv.currency, pv.weight, pv.is_active,
+	pv.unit, pv.min_order_quantity, pv.order_multiple, pack_sizes(pv.id) AS pack_sizes,
+	COALESCE(array_agg(po.name ORDER BY po.position) FILTER (WHERE pov.id IS NOT NULL), '{}') AS option_names,
+	COALESCE(array_agg(pov.value ORDER BY po.position) FILTER (WHERE pov.id IS NOT NULL), '{}') AS option_values
+	FROM product_variants pv
+	LEFT JOIN variant_option_values vov ON vov.variant_id = pv.id
+	LEFT JOIN product_option_values pov ON pov.id = vov.option_value_id
+	LEFT JOIN product_options po ON po.id = pov.option_id
+	WHERE pv.product_id = ANY($1)
+	GROUP BY pv.id
+	ORDER BY pv.sku`
+
+// GetExportBatch returns up to limit products matching the filters with an id
+// greater than afterID, ordered by id, with all of their sections loaded.
+func (r *ProductRepo) GetExportBatch(ctx context.Context, request ProductFilters, afterID string, limit int) ([]ExportProduct, error) {
+	where, args, err := productFilterClause(request, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var batch []ExportProduct
+	query := "SELECT p.id, p.slug, p.name, p.description, p.status, b.slug AS brand_slug, c.slug AS category_slug" +
+		productFacetFrom + " WHERE 1=1" + where + " AND p.id::text > ? ORDER BY p.id::text LIMIT ?"
+	args = append(args, afterID, limit)
+	if err := r.db.SelectContext(ctx, &batch, r.db.Rebind(query), args...); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+
+	if len(batch) == 0 {
+		return batch, nil
+	}
+
+	ids := make([]string, 0, len(batch))
+	index := make(map[string]*ExportProduct, len(batch))
+	for i := range batch {
+		ids = append(ids, batch[i].ID)
+		index[batch[i].ID] = &batch[i]
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+
+	var attributes []ProductAttribute
+	g.Go(func() error {
+		query := "SELECT * FROM products_attributes WHERE product_id = ANY($1) ORDER BY attribute_key"
+		return r.db.SelectContext(gctx, &attributes, query, pq.Array(ids))
+	})
+
+	var options []ExportOption
+	g.Go(func() error {
+		return r.db.SelectContext(gctx, &options, productOptionsQuery, pq.Array(ids))
+	})
+
+	var variants []ExportVariant
+	g.Go(func() error {
+		return r.db.SelectContext(gctx, &variants, productVariantsQuery, pq.Array(ids))
+	})
+
+	var media []ProductMedia
+	g.Go(func() error {
+		query := "SELECT id, product_id, variant_id, url, type, display_order FROM product_media WHERE product_id = ANY($1) ORDER BY display_order"
+		return r.db.SelectContext(gctx, &media, query, pq.Array(ids))
+	})
+
+	var seo []ProductSEO
+	g.Go(func() error {
+		query := "SELECT product_id, meta_title, meta_description, og_image_url, keywords FROM product_seo WHERE product_id = ANY($1)"
+		return r.db.SelectContext(gctx, &seo, query, pq.Array(ids))
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+
+	for _, data := range attributes {
+		index[data.ProductID].Attributes = append(index[data.ProductID].Attributes, data)
+	}
+	for _, data := range options {
+		index[data.ProductID].Options = append(index[data.ProductID].Options, ProductOptionValue{Name: data.Name, Values: data.Values})
+	}
+	for _, data := range variants {
+		index[data.ProductID].Variants = append(index[data.ProductID].Variants, data)
+	}
+	for _, data := range media {
+		index[data.ProductID].Media = append(index[data.ProductID].Media, data)
+	}
+	for i := range seo {
+		index[seo[i].ProductID].SEO = &seo[i]
+	}
+
+	return batch, nil
+}
+
+// loadProductSnapshot reads the full product aggregate inside tx.
+func loadProductSnapshot(ctx context.Context, tx *sqlx.Tx, productID string) (*ProductSnapshot, error) {
+	var product struct {
+		Name        string        `db:"name"`
+		Slug        string        `db:"slug"`
+		Description *string       `db:"description"`
+		SellerID    string        `db:"seller_id"`
+		BrandID     *string       `db:"brand_id"`
+		CategoryID  *string       `db:"category_id"`
+		Status      ProductStatus `db:"status"`
+	}
+	query := "SELECT name, slug, description, seller_id, brand_id, category_id, status FROM products WHERE id=$1"
+	if err := tx.GetContext(ctx, &product, query, productID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrProductNotFound
+		}
+		return nil, shared.PostgresError(err)
+	}
+
+	snapshot := &ProductSnapshot{
+		Name:        product.Name,
+		Slug:        product.Slug,
+		Description: product.Description,
+		SellerID:    product.SellerID,
+		BrandID:     product.BrandID,
+		CategoryID:  product.CategoryID,
+		Status:      product.Status,
+		Attributes:  []ProductAttributeArray{},
+		Options:     []ProductOptionValue{},
+		Variants:    []SnapshotVariant{},
+		Media:       []SnapshotMedia{},
+	}
+	ids := pq.Array([]string{productID})
+
+	var attributes []ProductAttribute
+	query = "SELECT * FROM products_attributes WHERE product_id=$1 ORDER BY attribute_key"
+	if err := tx.SelectContext(ctx, &attributes, query, productID); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+	for _, attribute := range attributes {
+		snapshot.Attributes = append(snapshot.Attributes, ProductAttributeArray{
+			AttributeKey:   attribute.AttributeKey,
+			AttributeValue: attribute.AttributeValue,
+		})
+	}
+
+	var options []ExportOption
+	if err := tx.SelectContext(ctx, &options, productOptionsQuery, ids); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+	for _, option := range options {
+		snapshot.Options = append(snapshot.Options, ProductOptionValue{Name: option.Name, Values: option.Values})
+	}
+
+	var variants []ExportVariant
+	if err := tx.SelectContext(ctx, &variants, productVariantsQuery, ids); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+	for _, variant := range variants {
+		snapshot.Variants = append(snapshot.Variants, SnapshotVariant{
+			SKU:          variant.SKU,
+			Price:        variant.Price,
+			Currency:     variant.Currency,
+			Weight:       variant.Weight,
+			IsActive:     variant.IsActive,
+			Options:      variant.variantOptions(),
+			OptionValues: variant.OptionValues,
+			OrderRules:   variant.OrderRules,
+		})
+	}
+
+	query = `SELECT pm.url, pm.type, pm.display_order, pv.sku AS variant_sku
+		FROM product_media pm LEFT JOIN product_variants pv ON pv.id = pm.variant_id
+		WHERE pm.product_id=$1 ORDER BY pm.display_order, pm.url`
+	var media []struct {
+		Url          string      `db:"url"`
+		Type         ProductType `db:"type"`
+		DisplayOrder int         `db:"display_order"`
+		VariantSKU   *string     `db:"variant_sku"`
+	}
+	if err := tx.SelectContext(ctx, &media, query, productID); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+	for _, m := range media {
+		snapshot.Media = append(snapshot.Media, SnapshotMedia(m))
+	}
+
+	var seo ProductSEO
+	query = "SELECT product_id, meta_title, meta_description, og_image_url, keywords FROM product_seo WHERE product_id=$1"
+	if err := tx.GetContext(ctx, &seo, query, productID); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, shared.PostgresError(err)
+		}
+	} else {
+		seoDTO, err := toProductSEODTO(&seo)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.SEO = seoDTO
+	}
+
+	return snapshot, nil
+}
+
+// returnToReview sends an approved product, ACTIVE or SCHEDULED, back to
+// PENDING_REVIEW from inside the transaction that changes its content, so
+// edits never reach the storefront unreviewed. It is called before
+// recordProductVersion, which then records the return to review along with
+// the edit.
+func returnToReview(ctx context.Context, tx *sqlx.Tx, productID string) error {
+	query := "UPDATE products SET status=$1, rejection_reason=NULL, publish_at=NULL, unpublish_at=NULL, updated_at=CURRENT_TIMESTAMP WHERE id=$2 AND status = ANY($3::status_enum[])"
+	if _, err := tx.ExecContext(ctx, query, PENDING_REVIEW, productID, pq.Array(approvedStatuses)); err != nil {
+		return shared.PostgresError(err)
+	}
+	return nil
+}
+
+// recordProductVersion snapshots the product as changed by tx and stores it
+// as the next version, with the diff against the previous one. The product
+// row is locked so concurrent changes get consecutive versions.
+func recordProductVersion(ctx context.Context, tx *sqlx.Tx, productID, changeType string, changedBy *string, restoredFrom *int) error {
+	if _, err := tx.ExecContext(ctx, "SELECT 1 FROM products WHERE id=$1 FOR UPDATE", productID); err != nil {
+		return shared.PostgresError(err)
+	}
+
+	snapshot, err := loadProductSnapshot(ctx, tx, productID)
+	if err != nil {
+		return err
+	}
+
+	var previous struct {
+		Version  int             `db:"version"`
+		Snapshot json.RawMessage `db:"snapshot"`
+	}
+	var previousSnapshot *ProductSnapshot
+	query := "SELECT version, snapshot FROM product_versions WHERE product_id=$1 ORDER BY version DESC LIMIT 1"
+	if err := tx.GetContext(ctx, &previous, query, productID); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return shared.PostgresError(err)
+		}
+	} else {
+		previousSnapshot = &ProductSnapshot{}
+		if err := json.Unmarshal(previous.Snapshot, previousSnapshot); err != nil {
+			return err
+		}
+	}
+
+	snapshotJSON, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	diffJSON, err := json.Marshal(diffSnapshots(previousSnapshot, snapshot))
+	if err != nil {
+		return err
+	}
+
+	query = `INSERT INTO product_versions(product_id, version, change_type, changed_by, restored_from, snapshot, diff)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	if _, err := tx.ExecContext(ctx, query, productID, previous.Version+1, changeType, changedBy, restoredFrom, snapshotJSON, diffJSON); err != nil {
+		return shared.PostgresError(err)
+	}
+
+	return nil
+}
+
+func (r *ProductRepo) GetProductVersions(ctx context.Context, productID string) ([]ProductVersion, error) {
+	var versions []ProductVersion
+	query := `SELECT id, product_id, version, change_type, changed_by, restored_from, '{}'::jsonb AS snapshot, diff, created_at
+		FROM product_versions WHERE product_id=$1 ORDER BY version DESC`
+	if err := r.db.SelectContext(ctx, &versions, query, productID); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+
+	return versions, nil
+}
+
+func (r *ProductRepo) GetProductVersion(ctx context.Context, productID string, version int) (*ProductVersion, error) {
+	var productVersion ProductVersion
+	query := "SELECT * FROM product_versions WHERE product_id=$1 AND version=$2"
+	if err := r.db.GetContext(ctx, &productVersion, query, productID, version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrVersionNotFound
+		}
+		return nil, shared.PostgresError(err)
+	}
+
+	return &productVersion, nil
+}
+
+// RestoreProductVersion puts the product back to the state stored in version
+// and records the result as a new version. Variants created after that
+// version are deactivated rather than deleted. It fails with
+// shared.ErrSlugTaken when another product now has the version's slug.
+func (r *ProductRepo) RestoreProductVersion(ctx context.Context, productID string, version int, changedBy *string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return shared.PostgresError(err)
+	}
+
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "SELECT 1 FROM products WHERE id=$1 FOR UPDATE", productID); err != nil {
+		return shared.PostgresError(err)
+	}
+
+	var stored json.RawMessage
+	if err := tx.GetContext(ctx, &stored, "SELECT snapshot FROM product_versions WHERE product_id=$1 AND version=$2", productID, version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrVersionNotFound
+		}
+		return shared.PostgresError(err)
+	}
+
+	var snapshot ProductSnapshot
+	if err := json.Unmarshal(stored, &snapshot); err != nil {
+		return err
+	}
+
+	// The old slug may have been taken by another product since
+	if _, err := shared.UniqueSlug(ctx, tx, "products", "", snapshot.Slug, productID); err != nil {
+		return err
+	}
+
+	// The status is left alone, it only changes through the workflow
+	query := "UPDATE products SET name=$1, slug=$2, description=$3, seller_id=$4, brand_id=$5, category_id=$6, updated_at=CURRENT_TIMESTAMP WHERE id=$7"
+	if _, err := tx.ExecContext(ctx, query, snapshot.Name, snapshot.Slug, snapshot.Description, snapshot.SellerID, snapshot.BrandID, snapshot.CategoryID, productID); err != nil {
+		return shared.PostgresError(err)
+	}
+
+	attributes := make([]ProductAttributeArrayDTO, 0, len(snapshot.Attributes))
+	for _, attribute := range snapshot.Attributes {
+		attributes = append(attributes, ProductAttributeArrayDTO{
+			ProductID:      productID,
+			AttributeKey:   attribute.AttributeKey,
+			AttributeValue: attribute.AttributeValue,
+		})
+	}
+	if err := replaceProductAttributes(ctx, tx, productID, attributes); err != nil {
+		return err
+	}
+
+	variants := VariantRequestDTO{ProductID: productID, Options: snapshot.Options}
+	skus := make([]string, 0, len(snapshot.Variants))
+	for _, variant := range snapshot.Variants {
+		weight := 0.0
+		if variant.Weight != nil {
+			weight = *variant.Weight
+		}
+		variants.Variants = append(variants.Variants, ProductVariant{
+			SKU:          variant.SKU,
+			Price:        variant.Price,
+			Currency:     variant.Currency,
+			Weight:       weight,
+			IsActive:     variant.IsActive,
+			Options:      variant.Options,
+			OptionValues: variant.OptionValues,
+			OrderRules:   variant.OrderRules,
+		})
+		skus = append(skus, variant.SKU)
+	}
+	if err := syncProductVariants(ctx, tx, productID, variants); err != nil {
+		return err
+	}
+
+	query = "UPDATE product_variants SET is_active=false, updated_at=CURRENT_TIMESTAMP WHERE product_id=$1 AND NOT (sku = ANY($2))"
+	if _, err := tx.ExecContext(ctx, query, productID, pq.Array(skus)); err != nil {
+		return shared.PostgresError(err)
+	}
+
+	var variantIDs []struct {
+		ID  string `db:"id"`
+		SKU string `db:"sku"`
+	}
+	if err := tx.SelectContext(ctx, &variantIDs, "SELECT id, sku FROM product_variants WHERE product_id=$1", productID); err != nil {
+		return shared.PostgresError(err)
+	}
+	skuToID := make(map[string]string, len(variantIDs))
+	for _, v := range variantIDs {
+		skuToID[v.SKU] = v.ID
+	}
+
+	media := make([]ProductMediaDTO, 0, len(snapshot.Media))
+	for _, m := range snapshot.Media {
+		var variantID *string
+		if m.VariantSKU != nil {
+			if id, ok := skuToID[*m.VariantSKU]; ok {
+				variantID = &id
+			}
+		}
+		media = append(media, ProductMediaDTO{
+			ProductID:    productID,
+			VariantID:    variantID,
+			Url:          m.Url,
+			MediaType:    m.Type,
+			DisplayOrder: m.DisplayOrder,
+		})
+	}
+	if err := replaceProductMedia(ctx, tx, productID, media); err != nil {
+		return err
+	}
+
+	if snapshot.SEO == nil {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM product_seo WHERE product_id=$1", productID); err != nil {
+			return shared.PostgresError(err)
+		}
+	} else {
+		keywords, err := json.Marshal(snapshot.SEO.Keywords)
+		if err != nil {
+			return err
+		}
+		seo := ProductSEO{
+			ProductID:       productID,
+			MetaTitle:       snapshot.SEO.MetaTitle,
+			MetaDescription: snapshot.SEO.MetaDescription,
+			OgImageUrl:      snapshot.SEO.OgImageUrl,
+			Keywords:        keywords,
+		}
+		if err := upsertProductSEO(ctx, tx, seo); err != nil {
+			return err
+		}
+	}
+
+	if err := returnToReview(ctx, tx, productID); err != nil {
+		return err
+	}
+
+	if err := recordProductVersion(ctx, tx, productID, ChangeRestore, changedBy, &version); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SaveBundle replaces the composition of a BUNDLE product. Components must be
+// variants of standard products, so bundles never nest. The bundle's variants
+// are touched so that COMPONENTS pricing is applied to them right away.
+func (r *ProductRepo) SaveBundle(ctx context.Context, productID string, request BundleRequestDTO) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return shared.PostgresError(err)
+	}
+
+	defer tx.Rollback()
+
+	var productType ProductKind
+	if err := tx.GetContext(ctx, &productType, "SELECT product_type FROM products WHERE id=$1 FOR UPDATE", productID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrProductNotFound
+		}
+		return shared.PostgresError(err)
+	}
+	if productType != BUNDLE {
+		return fmt.Errorf("%w: the product type is %s, set it to %s first", ErrInvalidBundle, productType, BUNDLE)
+	}
+
+	var skus []string
+	for _, component := range request.Components {
+		skus = append(skus, component.SKUs...)
+	}
+
+	var variants []struct {
+		ID          string      `db:"id"`
+		SKU         string      `db:"sku"`
+		ProductType ProductKind `db:"product_type"`
+	}
+	query := "SELECT pv.id, pv.sku, p.product_type FROM product_variants pv JOIN products p ON p.id = pv.product_id WHERE pv.sku = ANY($1)"
+	if err := tx.SelectContext(ctx, &variants, query, pq.Array(skus)); err != nil {
+		return shared.PostgresError(err)
+	}
+
+	skuToID := make(map[string]string, len(variants))
+	for _, variant := range variants {
+		if variant.ProductType == BUNDLE {
+			return fmt.Errorf("%w: sku %q belongs to a bundle, bundles cannot contain bundles", ErrInvalidBundle, variant.SKU)
+		}
+		skuToID[variant.SKU] = variant.ID
+	}
+	for _, sku := range skus {
+		if skuToID[sku] == "" {
+			return fmt.Errorf("%w: sku %q not found", ErrInvalidBundle, sku)
+		}
+	}
+
+	query = `INSERT INTO product_bundles (product_id, pricing, discount_percent) VALUES ($1, $2, $3)
+		ON CONFLICT (product_id) DO UPDATE SET pricing = EXCLUDED.pricing, discount_percent = EXCLUDED.discount_percent`
+	if _, err := tx.ExecContext(ctx, query, productID, request.Pricing, request.DiscountPercent); err != nil {
+		return shared.PostgresError(err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM bundle_components WHERE bundle_id=$1", productID); err != nil {
+		return shared.PostgresError(err)
+	}
+
+	for i, component := range request.Components {
+		var componentID string
+		query := "INSERT INTO bundle_components (bundle_id, name, quantity, position) VALUES ($1, $2, $3, $4) RETURNING id"
+		if err := tx.QueryRowContext(ctx, query, productID, component.Name, component.Quantity, i).Scan(&componentID); err != nil {
+			return shared.PostgresError(err)
+		}
+
+		defaultSKU := component.DefaultSKU
+		if defaultSKU == "" {
+			defaultSKU = component.SKUs[0]
+		}
+		for _, sku := range component.SKUs {
+			query := "INSERT INTO bundle_component_variants (component_id, variant_id, is_default) VALUES ($1, $2, $3)"
+			if _, err := tx.ExecContext(ctx, query, componentID, skuToID[sku], sku == defaultSKU); err != nil {
+				return shared.PostgresError(err)
+			}
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE product_variants SET updated_at=CURRENT_TIMESTAMP WHERE product_id=$1", productID); err != nil {
+		return shared.PostgresError(err)
+	}
+
+	if err := returnToReview(ctx, tx, productID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetBundle returns the composition of a bundle with its allowed variants,
+// or nil when none has been saved. The bundle is priced in the currency of
+// its variants.
+func (r *ProductRepo) GetBundle(ctx context.Context, productID string) (*Bundle, []BundleComponentVariant, error) {
+	var bundle Bundle
+	query := `SELECT b.product_id, b.pricing, b.discount_percent, cur.currency, bundle_price(b.product_id, cur.currency) AS price
+		FROM product_bundles b JOIN products p ON p.id = b.product_id AND p.product_type = 'BUNDLE', LATERAL (
+			SELECT COALESCE((SELECT currency FROM product_variants WHERE product_id = b.product_id ORDER BY is_active DESC, sku LIMIT 1), store_currency()) AS currency
+		) cur
+		WHERE b.product_id = $1`
+	if err := r.db.GetContext(ctx, &bundle, query, productID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, nil
+		}
+		return nil, nil, shared.PostgresError(err)
+	}
+
+	var rows []BundleComponentVariant
+	query = `SELECT bc.id AS component_id, bc.name, bc.quantity, pv.id AS variant_id, p.id AS product_id, p.name AS product_name,
+		pv.sku, pv.price, pv.currency, bcv.is_default, variant_available(pv.id) AS available,
+		COALESCE(array_agg(pov.value ORDER BY po.position) FILTER (WHERE pov.id IS NOT NULL), '{}') AS option_values
+		FROM bundle_components bc
+		JOIN bundle_component_variants bcv ON bcv.component_id = bc.id
+		JOIN product_variants pv ON pv.id = bcv.variant_id
+		JOIN products p ON p.id = pv.product_id
+		LEFT JOIN variant_option_values vov ON vov.variant_id = pv.id
+		LEFT JOIN product_option_values pov ON pov.id = vov.option_value_id
+		LEFT JOIN product_options po ON po.id = pov.option_id
+		WHERE bc.bundle_id = $1
+		GROUP BY bc.id, bcv.component_id, bcv.variant_id, pv.id, p.id
+		ORDER BY bc.position, bcv.is_default DESC, pv.sku`
+	if err := r.db.SelectContext(ctx, &rows, query, productID); err != nil {
+		return nil, nil, shared.PostgresError(err)
+	}
+
+	return &bundle, rows, nil
+}
+
+// BundlePrice prices a bundle in currency with variantIDs chosen for their
+// components, see the bundle_price SQL function.
+func (r *ProductRepo) BundlePrice(ctx context.Context, productID, currency string, variantIDs []string) (*shared.Decimal, error) {
+	var price *shared.Decimal
+	if err := r.db.GetContext(ctx, &price, "SELECT bundle_price($1, $2, $3::uuid[])", productID, currency, pq.Array(variantIDs)); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+	return price, nil
+}
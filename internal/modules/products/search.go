@@ -0,0 +1,21 @@
+package products
+
+import (
+	"strings"
+	"unicode"
+)
+
+// prefixTSQuery turns free text into a to_tsquery expression where every term
+// is a prefix match, e.g. "hard hat" -> "hard:* & hat:*". Terms are reduced to
+// letters and digits so user input can never produce tsquery syntax.
+func prefixTSQuery(search string) string {
+	terms := strings.FieldsFunc(search, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for i, term := range terms {
+		terms[i] = strings.ToLower(term) + ":*"
+	}
+
+	return strings.Join(terms, " & ")
+}
@@ -2,10 +2,18 @@ package products
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/smart-safety-hub/backend/shared"
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
 )
 
 type ProductService struct {
@@ -20,22 +28,31 @@ func NewProductService(logger *zap.Logger, repo *ProductRepo) *ProductService {
 	}
 }
 
-func (b *ProductService) CreateProduct(ctx context.Context, request ProductRequestDTO) (*GenericResponseDTO, error) {
-	productId, err := b.repo.SaveProduct(ctx, request)
+func (b *ProductService) CreateProduct(ctx context.Context, sellerID string, request ProductRequestDTO) (*GenericResponseDTO, error) {
+	productId, slug, err := b.repo.SaveProduct(ctx, sellerID, request)
 	if err != nil {
-		return nil, fmt.Errorf("Error came while saving it to DB: %v", err)
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
 	}
 
 	return &GenericResponseDTO{
 		ID:      productId,
+		Slug:    slug,
 		Status:  "success",
 		Message: "Product Created Successfully",
 	}, nil
 }
 
 func (b *ProductService) UpdateProduct(ctx context.Context, productId string, request ProductRequestDTO) (*GenericResponseDTO, error) {
+	if _, err := b.managedProduct(ctx, productId); err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	if request.Status != "" {
+		return nil, fmt.Errorf("%w: status can only be changed through the workflow", ErrInvalidTransition)
+	}
+
 	if err := b.repo.UpdateProduct(ctx, productId, request); err != nil {
-		return nil, fmt.Errorf("Error came while saving it to DB: %v", err)
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
 	}
 
 	return &GenericResponseDTO{
@@ -44,9 +61,9 @@ func (b *ProductService) UpdateProduct(ctx context.Context, productId string, re
 	}, nil
 }
 
-func (b *ProductService) DeleteProduct(ctx context.Context, productID string, status string) (*GenericResponseDTO, error) {
-	if err := b.repo.DeleteProduct(ctx, productID, status); err != nil {
-		return nil, fmt.Errorf("Error came while saving it to DB: %v", err)
+func (b *ProductService) DeleteProduct(ctx context.Context, productID string) (*GenericResponseDTO, error) {
+	if _, err := b.repo.TransitionProduct(ctx, productID, ActionArchive, claimPermissions(ctx), WorkflowRequestDTO{}); err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
 	}
 
 	return &GenericResponseDTO{
@@ -55,85 +72,269 @@ func (b *ProductService) DeleteProduct(ctx context.Context, productID string, st
 	}, nil
 }
 
+func (b *ProductService) TransitionProduct(ctx context.Context, productID string, action string, request WorkflowRequestDTO) (*GenericResponseDTO, error) {
+	status, err := b.repo.TransitionProduct(ctx, productID, action, claimPermissions(ctx), request)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return &GenericResponseDTO{
+		ID:      &productID,
+		Status:  "success",
+		Message: fmt.Sprintf("Product Moved to %s Successfully", status),
+	}, nil
+}
+
+// RunScheduler publishes and unpublishes scheduled products every interval
+// until ctx is cancelled.
+func (b *ProductService) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		published, unpublished, err := b.repo.RunScheduledTransitions(ctx)
+		if err != nil && ctx.Err() == nil {
+			b.logger.Error("failed to run scheduled product transitions", zap.Error(err))
+		}
+		if published > 0 || unpublished > 0 {
+			b.logger.Info("scheduled product transitions", zap.Int("published", published), zap.Int("unpublished", unpublished))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// readableProduct returns the product when the user making the request may
+// read it, and ErrProductNotFound otherwise, so unpublished products are not
+// revealed to the public.
+func (b *ProductService) readableProduct(ctx context.Context, productId string) (*Product, error) {
+	product, err := b.repo.GetProductByID(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	if !canReadProduct(ctx, product) {
+		return nil, ErrProductNotFound
+	}
+	return product, nil
+}
+
+// readableProductBySlug is readableProduct for a product looked up by slug.
+func (b *ProductService) readableProductBySlug(ctx context.Context, slug string) (*Product, error) {
+	product, err := b.repo.GetProductBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if !canReadProduct(ctx, product) {
+		return nil, ErrProductNotFound
+	}
+	return product, nil
+}
+
 func (b *ProductService) GetProductByID(ctx context.Context, productId string) (*ProductResponseDTO, error) {
-	resp, err := b.repo.GetProductByID(ctx, productId)
+	resp, err := b.readableProduct(ctx, productId)
 	if err != nil {
-		return nil, fmt.Errorf("Error came while getting data from DB: %v", err)
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
 	}
 
 	response := &ProductResponseDTO{
-		ID:          resp.ID,
-		Name:        resp.Name,
-		Slug:        resp.Slug,
-		Description: resp.Description,
-		SellerID:    resp.SellerID,
-		BrandID:     resp.BrandID,
-		CategoryID:  resp.CategoryID,
-		Status:      resp.Status,
-		CreatedAt:   resp.CreatedAt,
+		ID:                  resp.ID,
+		Name:                resp.Name,
+		Slug:                resp.Slug,
+		Description:         resp.Description,
+		SellerID:            resp.SellerID,
+		BrandID:             resp.BrandID,
+		CategoryID:          resp.CategoryID,
+		Status:              resp.Status,
+		Type:                resp.Type,
+		RejectionReason:     resp.RejectionReason,
+		PublishAt:           resp.PublishAt,
+		UnpublishAt:         resp.UnpublishAt,
+		ComplianceFlaggedAt: resp.ComplianceFlaggedAt,
+		RatingAverage:       resp.RatingAverage,
+		RatingCount:         resp.RatingCount,
+		CreatedAt:           resp.CreatedAt,
+	}
+
+	if resp.Type == BUNDLE {
+		if response.Bundle, err = b.productBundle(ctx, resp.ID); err != nil {
+			return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+		}
 	}
 
 	return response, nil
 }
 
 func (b *ProductService) GetProductBySlug(ctx context.Context, slug string) (*ProductResponseDTO, error) {
-	resp, err := b.repo.GetProductBySlug(ctx, slug)
+	resp, err := b.readableProductBySlug(ctx, slug)
 	if err != nil {
-		return nil, fmt.Errorf("Error came while getting data from DB: %v", err)
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
 	}
 
 	response := &ProductResponseDTO{
-		ID:          resp.ID,
-		Name:        resp.Name,
-		Slug:        resp.Slug,
-		Description: resp.Description,
-		SellerID:    resp.SellerID,
-		BrandID:     resp.BrandID,
-		CategoryID:  resp.CategoryID,
-		Status:      resp.Status,
-		CreatedAt:   resp.CreatedAt,
+		ID:                  resp.ID,
+		Name:                resp.Name,
+		Slug:                resp.Slug,
+		Description:         resp.Description,
+		SellerID:            resp.SellerID,
+		BrandID:             resp.BrandID,
+		CategoryID:          resp.CategoryID,
+		Status:              resp.Status,
+		Type:                resp.Type,
+		RejectionReason:     resp.RejectionReason,
+		PublishAt:           resp.PublishAt,
+		UnpublishAt:         resp.UnpublishAt,
+		ComplianceFlaggedAt: resp.ComplianceFlaggedAt,
+		RatingAverage:       resp.RatingAverage,
+		RatingCount:         resp.RatingCount,
+		CreatedAt:           resp.CreatedAt,
+	}
+
+	if resp.Type == BUNDLE {
+		if response.Bundle, err = b.productBundle(ctx, resp.ID); err != nil {
+			return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+		}
 	}
 
 	return response, nil
 }
 
 func (b *ProductService) GetAllProducts(ctx context.Context, request ProductFilters) (*ProductListResponse, error) {
-	resp, err := b.repo.GetAllProducts(ctx, request)
+	companyID, err := b.repo.GetCompanyID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+	request.CompanyID = companyID
+	scopeProductListing(ctx, &request)
+
+	request.Currency, err = b.repo.ResolveCurrency(ctx, request.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
 
+	buyerState, err := b.repo.BuyerState(ctx, request.ShipTo)
 	if err != nil {
-		return nil, fmt.Errorf("Error came while getting data from DB: %v", err)
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
 	}
 
-	totalCount := 0
-	if len(resp) > 0 {
-		totalCount = resp[0].TotalCount
+	places, err := b.repo.CurrencyPlaces(ctx, request.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	resp, err := b.repo.GetAllProducts(ctx, request)
+
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
 	}
 
-	productsDTO := make([]GetProductsDTO, 0, len(resp))
-	for _, data := range resp {
+	productsDTO := make([]GetProductsDTO, 0, len(resp.Products))
+	for _, data := range resp.Products {
 		productsDTO = append(productsDTO, GetProductsDTO{
-			ID:           data.ID,
-			Name:         data.Name,
-			Slug:         data.Slug,
-			Description:  data.Description,
-			Status:       data.Status,
-			CategoryName: data.CategoryName,
-			BrandName:    data.BrandName,
-			ImageURL:     data.ImageURL,
+			ID:              data.ID,
+			Name:            data.Name,
+			Slug:            data.Slug,
+			Description:     data.Description,
+			Status:          data.Status,
+			CategoryName:    data.CategoryName,
+			BrandName:       data.BrandName,
+			ImageURL:        data.ImageURL,
+			MinPrice:        data.MinPrice,
+			MaxPrice:        data.MaxPrice,
+			MinPriceInclTax: priceInclTax(data.MinPrice, data.TaxRule, buyerState, places),
+			MaxPriceInclTax: priceInclTax(data.MaxPrice, data.TaxRule, buyerState, places),
+			TaxRate:         data.TaxRule.Rate,
+			Certifications:  data.Certifications,
+			RatingAverage:   data.RatingAverage,
+			RatingCount:     data.RatingCount,
 		})
 	}
-	return &ProductListResponse{
-		Products:   productsDTO,
-		TotalCount: totalCount,
-		Page:       request.Page,
-		Limit:      request.Limit,
-	}, nil
+	response := &ProductListResponse{
+		Products:       productsDTO,
+		TotalCount:     resp.TotalCount,
+		TotalEstimated: resp.Estimated,
+		Page:           request.Page,
+		Limit:          request.Limit,
+		NextCursor:     resp.NextCursor,
+		PrevCursor:     resp.PrevCursor,
+		Currency:       request.Currency,
+	}
+
+	if request.Facets {
+		facets, err := b.repo.GetProductFacets(ctx, request)
+		if err != nil {
+			return nil, fmt.Errorf("Error came while getting facets from DB: %w", err)
+		}
+		response.Facets = toProductFacets(facets)
+	}
+
+	return response, nil
+}
+
+func toProductFacets(counts *ProductFacetCounts) *ProductFacets {
+	facets := &ProductFacets{
+		Brands:         toFacetBuckets(counts.Brands),
+		Categories:     toFacetBuckets(counts.Categories),
+		PriceRanges:    make([]PriceRangeBucket, 0, len(counts.PriceRanges)),
+		Attributes:     make(map[string][]FacetBucket),
+		Certifications: toFacetBuckets(counts.Certifications),
+	}
+
+	for _, data := range counts.PriceRanges {
+		bucket := PriceRangeBucket{Count: data.Count}
+		if data.Bucket > 0 {
+			bucket.Min = counts.PriceBounds[data.Bucket-1]
+		}
+		if data.Bucket < len(counts.PriceBounds) {
+			bucket.Max = &counts.PriceBounds[data.Bucket]
+		}
+		facets.PriceRanges = append(facets.PriceRanges, bucket)
+	}
+
+	for _, data := range counts.Attributes {
+		facets.Attributes[data.Key] = append(facets.Attributes[data.Key], FacetBucket{
+			Value: data.Value,
+			Label: data.Label,
+			Count: data.Count,
+		})
+	}
+
+	return facets
+}
+
+func toFacetBuckets(counts []FacetCount) []FacetBucket {
+	buckets := make([]FacetBucket, 0, len(counts))
+	for _, data := range counts {
+		buckets = append(buckets, FacetBucket{
+			Value: data.Value,
+			Label: data.Label,
+			Count: data.Count,
+		})
+	}
+	return buckets
 }
 
 func (b *ProductService) AddProductAttribute(ctx context.Context, request ProductAttributeDTO) (*GenericResponseDTO, error) {
-	productAttribute := make([]ProductAttributeArrayDTO, 0, len(request.Attributes))
+	if _, err := b.managedProduct(ctx, request.ProductID); err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	definitions, err := b.repo.GetAttributeDefinitions(ctx, request.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	attributes, err := validateAttributes(definitions, request.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	productAttribute := make([]ProductAttributeArrayDTO, 0, len(attributes))
 
-	for _, data := range request.Attributes {
+	for _, data := range attributes {
 		productAttribute = append(productAttribute, ProductAttributeArrayDTO{
 			ProductID:      request.ProductID,
 			AttributeKey:   data.AttributeKey,
@@ -141,9 +342,9 @@ func (b *ProductService) AddProductAttribute(ctx context.Context, request Produc
 		})
 	}
 
-	err := b.repo.AddProductAttribute(ctx, request.ProductID, productAttribute)
+	err = b.repo.AddProductAttribute(ctx, request.ProductID, productAttribute)
 	if err != nil {
-		return nil, fmt.Errorf("Error came while saving it to DB: %v", err)
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
 	}
 
 	return &GenericResponseDTO{
@@ -154,9 +355,13 @@ func (b *ProductService) AddProductAttribute(ctx context.Context, request Produc
 }
 
 func (b *ProductService) GetProductAttributeByID(ctx context.Context, productId string) (*ProductAttributeDTO, error) {
+	if _, err := b.readableProduct(ctx, productId); err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
 	response, err := b.repo.GetProductAttributeByID(ctx, productId)
 	if err != nil {
-		return nil, fmt.Errorf("Error came while getting data from DB: %v", err)
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
 	}
 
 	var productAttribute []ProductAttributeArray
@@ -175,9 +380,13 @@ func (b *ProductService) GetProductAttributeByID(ctx context.Context, productId
 }
 
 func (b *ProductService) SyncProductVariants(ctx context.Context, productId string, request VariantRequestDTO) (*GenericResponseDTO, error) {
+	if _, err := b.managedProduct(ctx, productId); err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
 	err := b.repo.SyncProductVariants(ctx, productId, request)
 	if err != nil {
-		return nil, fmt.Errorf("Error came while getting data from DB: %v", err)
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
 	}
 
 	return &GenericResponseDTO{
@@ -187,10 +396,150 @@ func (b *ProductService) SyncProductVariants(ctx context.Context, productId stri
 	}, nil
 }
 
+// SaveBundle replaces the composition of a bundle product.
+func (b *ProductService) SaveBundle(ctx context.Context, productId string, request BundleRequestDTO) (*GenericResponseDTO, error) {
+	if _, err := b.managedProduct(ctx, productId); err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	if err := validateBundle(request); err != nil {
+		return nil, err
+	}
+
+	if err := b.repo.SaveBundle(ctx, productId, request); err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return &GenericResponseDTO{
+		ID:      &productId,
+		Status:  "success",
+		Message: "Product Bundle Saved Successfully",
+	}, nil
+}
+
+// ConfigureBundle prices and checks the availability of a kit with the
+// buyer's choice of variants.
+func (b *ProductService) ConfigureBundle(ctx context.Context, productId string, request BundleSelectionDTO) (*BundleConfigurationDTO, error) {
+	if _, err := b.readableProduct(ctx, productId); err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	bundle, err := b.productBundle(ctx, productId)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+	if bundle == nil {
+		return nil, fmt.Errorf("%w: the product has no bundle composition", ErrInvalidBundle)
+	}
+
+	chosen, err := chooseBundleVariants(bundle, request.Selections)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &BundleConfigurationDTO{
+		ProductID:  productId,
+		Currency:   bundle.Currency,
+		Available:  true,
+		Components: chosen,
+	}
+
+	variantIDs := make([]string, 0, len(chosen))
+	for _, component := range chosen {
+		variantIDs = append(variantIDs, component.VariantID)
+		response.Available = response.Available && component.Available
+	}
+
+	if bundle.Pricing == BundlePricingComponents {
+		response.Price, err = b.repo.BundlePrice(ctx, productId, bundle.Currency, variantIDs)
+		if err != nil {
+			return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+		}
+	}
+
+	return response, nil
+}
+
+// productBundle returns the composition of a bundle, nil when it has none.
+func (b *ProductService) productBundle(ctx context.Context, productId string) (*BundleDTO, error) {
+	bundle, rows, err := b.repo.GetBundle(ctx, productId)
+	if err != nil || bundle == nil {
+		return nil, err
+	}
+	return toBundleDTO(*bundle, rows), nil
+}
+
+// GenerateVariantMatrix plans the variants for every combination of the
+// requested options and, unless dryRun is set, saves them.
+func (b *ProductService) GenerateVariantMatrix(ctx context.Context, productId string, request VariantMatrixRequestDTO, dryRun bool) (*VariantMatrixResponse, error) {
+	if _, err := b.managedProduct(ctx, productId); err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	productSlug, brandSlug, err := b.repo.GetProductSKUParts(ctx, productId)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	// The matrix keeps the list prices of existing variants
+	current, err := b.productVariants(ctx, productId, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := buildVariantMatrix(productSlug, brandSlug, request, current.Variants)
+	if err != nil {
+		return nil, err
+	}
+	response.ProductID = productId
+	response.DryRun = dryRun
+
+	skus := make([]string, 0, len(response.Variants))
+	for _, variant := range response.Variants {
+		skus = append(skus, variant.SKU)
+	}
+	owners, err := b.repo.GetVariantOwners(ctx, skus)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+	for _, sku := range skus {
+		if owner, ok := owners[sku]; ok && owner != productSlug {
+			return nil, fmt.Errorf("%w: sku %q already belongs to product %q", ErrInvalidVariants, sku, owner)
+		}
+	}
+
+	if dryRun {
+		return response, nil
+	}
+
+	variants := VariantRequestDTO{ProductID: productId, Options: request.Options}
+	for _, variant := range response.Variants {
+		variants.Variants = append(variants.Variants, ProductVariant{
+			SKU:        variant.SKU,
+			Price:      variant.Price,
+			Currency:   variant.Currency,
+			Weight:     variant.Weight,
+			IsActive:   variant.IsActive,
+			Options:    variant.Options,
+			OrderRules: variant.OrderRules,
+		})
+	}
+
+	if err := b.repo.SyncProductVariants(ctx, productId, variants); err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return response, nil
+}
+
 func (b *ProductService) AddProductMedia(ctx context.Context, productId string, request []ProductMediaDTO) (*GenericResponseDTO, error) {
+	if _, err := b.managedProduct(ctx, productId); err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
 	err := b.repo.AddProductMedia(ctx, productId, request)
 	if err != nil {
-		return nil, fmt.Errorf("Error came while getting data from DB: %v", err)
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
 	}
 
 	return &GenericResponseDTO{
@@ -201,9 +550,17 @@ func (b *ProductService) AddProductMedia(ctx context.Context, productId string,
 }
 
 func (b *ProductService) GetProductMedia(ctx context.Context, productId string) (*[]ProductMediaDTO, error) {
+	if _, err := b.readableProduct(ctx, productId); err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	return b.productMedia(ctx, productId)
+}
+
+func (b *ProductService) productMedia(ctx context.Context, productId string) (*[]ProductMediaDTO, error) {
 	response, err := b.repo.GetProductMedia(ctx, productId)
 	if err != nil {
-		return nil, fmt.Errorf("Error came while getting data from DB: %v", err)
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
 	}
 
 	mediaData := make([]ProductMediaDTO, 0, len(response))
@@ -225,12 +582,98 @@ func (b *ProductService) GetProductMedia(ctx context.Context, productId string)
 	return &mediaData, nil
 }
 
-func (b *ProductService) GetProductVariants(ctx context.Context, productId string) (*VariantRequestDTO, error) {
+// GetProductVariants returns the variants of a product priced for the
+// signed-in user's company, in options.Currency or, when empty, in each
+// variant's own currency, with GST for options.ShipTo.
+func (b *ProductService) GetProductVariants(ctx context.Context, productId string, options PriceOptions) (*VariantRequestDTO, error) {
+	if _, err := b.readableProduct(ctx, productId); err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	companyID, err := b.repo.GetCompanyID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	target, err := b.targetCurrency(ctx, options.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := b.productVariants(ctx, productId, companyID, target)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.applyTax(ctx, productId, response.Variants, options.ShipTo); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// applyTax adds the tax-inclusive price and GST breakdown to variants of a
+// product.
+func (b *ProductService) applyTax(ctx context.Context, productId string, variants []ProductVariant, shipTo string) error {
+	buyerState, err := b.repo.BuyerState(ctx, shipTo)
+	if err != nil {
+		return fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	rule, err := b.repo.GetTaxRule(ctx, productId)
+	if err != nil {
+		return fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	// Variants are priced in their own currencies unless one was requested
+	places := make(map[string]int)
+	for i := range variants {
+		currency := variants[i].Currency
+		if _, ok := places[currency]; !ok {
+			if places[currency], err = b.repo.CurrencyPlaces(ctx, currency); err != nil {
+				return fmt.Errorf("Error came while getting data from DB: %w", err)
+			}
+		}
+
+		variants[i].Tax = shared.ComputeTax(variants[i].Price, *rule, buyerState, places[currency])
+		if variants[i].Tax != nil {
+			variants[i].PriceInclTax = &variants[i].Tax.PriceInclusive
+		}
+	}
+	return nil
+}
+
+// priceInclTax is price with the tax of rule, or nil when either is unknown.
+func priceInclTax(price *shared.Decimal, rule shared.TaxRule, buyerState string, places int) *shared.Decimal {
+	if price == nil {
+		return nil
+	}
+	tax := shared.ComputeTax(*price, rule, buyerState, places)
+	if tax == nil {
+		return nil
+	}
+	return &tax.PriceInclusive
+}
+
+// targetCurrency resolves a requested currency, or nil when none is requested.
+func (b *ProductService) targetCurrency(ctx context.Context, currency string) (*string, error) {
+	if currency == "" {
+		return nil, nil
+	}
+
+	resolved, err := b.repo.ResolveCurrency(ctx, currency)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+	return &resolved, nil
+}
+
+func (b *ProductService) productVariants(ctx context.Context, productId string, companyID, currency *string) (*VariantRequestDTO, error) {
 	var response VariantRequestDTO
 
-	result, err := b.repo.GetProductVariants(ctx, productId)
+	result, err := b.repo.GetProductVariants(ctx, productId, companyID, currency)
 	if err != nil {
-		return nil, fmt.Errorf("Error came while getting data from DB: %v", err)
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
 	}
 
 	response.ProductID = result.ProductID
@@ -246,6 +689,10 @@ func (b *ProductService) GetProductVariants(ctx context.Context, productId strin
 }
 
 func (b *ProductService) SaveProductSEO(ctx context.Context, productId string, request ProductSEODTO) error {
+	if _, err := b.managedProduct(ctx, productId); err != nil {
+		return fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
 	keywordsJSON, err := json.Marshal(request.Keywords)
 	if err != nil {
 		return fmt.Errorf("failed to marshal keywords: %v", err)
@@ -263,11 +710,19 @@ func (b *ProductService) SaveProductSEO(ctx context.Context, productId string, r
 }
 
 func (b *ProductService) GetProductSEO(ctx context.Context, productId string) (*ProductSEODTO, error) {
+	if _, err := b.readableProduct(ctx, productId); err != nil {
+		return nil, fmt.Errorf("failed to get SEO data: %w", err)
+	}
+
 	result, err := b.repo.GetProductSEO(ctx, productId)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get SEO data: %v", err)
+		return nil, fmt.Errorf("failed to get SEO data: %w", err)
 	}
 
+	return toProductSEODTO(result)
+}
+
+func toProductSEODTO(result *ProductSEO) (*ProductSEODTO, error) {
 	var keywords []string
 	if len(result.Keywords) > 0 {
 		if err := json.Unmarshal(result.Keywords, &keywords); err != nil {
@@ -283,3 +738,708 @@ func (b *ProductService) GetProductSEO(ctx context.Context, productId string) (*
 		Keywords:        keywords,
 	}, nil
 }
+
+func (b *ProductService) GetProductDetailByID(ctx context.Context, productId string, include map[string]bool, options PriceOptions) (*ProductDetailDTO, error) {
+	product, err := b.readableProduct(ctx, productId)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	return b.getProductDetail(ctx, product, include, options)
+}
+
+func (b *ProductService) GetProductDetailBySlug(ctx context.Context, slug string, include map[string]bool, options PriceOptions) (*ProductDetailDTO, error) {
+	product, err := b.readableProductBySlug(ctx, slug)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	return b.getProductDetail(ctx, product, include, options)
+}
+
+// getProductDetail loads the requested sections of the product document in
+// parallel, reusing the same repository queries as the per-section endpoints.
+func (b *ProductService) getProductDetail(ctx context.Context, product *Product, include map[string]bool, options PriceOptions) (*ProductDetailDTO, error) {
+	target, err := b.targetCurrency(ctx, options.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &ProductDetailDTO{
+		ProductResponseDTO: ProductResponseDTO{
+			ID:            product.ID,
+			Name:          product.Name,
+			Slug:          product.Slug,
+			Description:   product.Description,
+			SellerID:      product.SellerID,
+			BrandID:       product.BrandID,
+			CategoryID:    product.CategoryID,
+			Status:        product.Status,
+			Type:          product.Type,
+			RatingAverage: product.RatingAverage,
+			RatingCount:   product.RatingCount,
+			CreatedAt:     product.CreatedAt,
+			UpdatedAt:     product.UpdatedAt,
+		},
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	if product.Type == BUNDLE {
+		g.Go(func() error {
+			bundle, err := b.productBundle(ctx, product.ID)
+			detail.Bundle = bundle
+			return err
+		})
+	}
+
+	if include[DetailBrand] && product.BrandID != "" {
+		g.Go(func() error {
+			brand, err := b.repo.GetProductBrand(ctx, product.BrandID)
+			if err != nil || brand == nil {
+				return err
+			}
+			detail.Brand = &ProductBrandDTO{
+				ID:      brand.ID,
+				Name:    brand.Name,
+				Slug:    brand.Slug,
+				LogoUrl: brand.LogoUrl,
+			}
+			return nil
+		})
+	}
+
+	if include[DetailBreadcrumb] && product.CategoryID != "" {
+		g.Go(func() error {
+			breadcrumb, err := b.repo.GetCategoryBreadcrumb(ctx, product.CategoryID)
+			if err != nil {
+				return err
+			}
+			detail.Breadcrumb = make([]CategoryBreadcrumbDTO, 0, len(breadcrumb))
+			for _, data := range breadcrumb {
+				detail.Breadcrumb = append(detail.Breadcrumb, CategoryBreadcrumbDTO{
+					ID:   data.ID,
+					Name: data.Name,
+					Slug: data.Slug,
+				})
+			}
+			return nil
+		})
+	}
+
+	if include[DetailAttributes] {
+		g.Go(func() error {
+			attributes, err := b.repo.GetProductAttributeByID(ctx, product.ID)
+			if err != nil {
+				return err
+			}
+			detail.Attributes = make([]ProductAttributeArray, 0, len(attributes))
+			for _, data := range attributes {
+				detail.Attributes = append(detail.Attributes, ProductAttributeArray{
+					AttributeKey:   data.AttributeKey,
+					AttributeValue: data.AttributeValue,
+				})
+			}
+			return nil
+		})
+	}
+
+	if include[DetailVariants] {
+		g.Go(func() error {
+			companyID, err := b.repo.GetCompanyID(ctx)
+			if err != nil {
+				return err
+			}
+			variants, err := b.repo.GetProductVariants(ctx, product.ID, companyID, target)
+			if err != nil || variants == nil {
+				return err
+			}
+			if len(variants.Options) > 0 {
+				if err := json.Unmarshal(variants.Options, &detail.Options); err != nil {
+					return err
+				}
+			}
+			if len(variants.Variants) > 0 {
+				if err := json.Unmarshal(variants.Variants, &detail.Variants); err != nil {
+					return err
+				}
+			}
+			return b.applyTax(ctx, product.ID, detail.Variants, options.ShipTo)
+		})
+	}
+
+	if include[DetailMedia] {
+		g.Go(func() error {
+			media, err := b.productMedia(ctx, product.ID)
+			if err != nil {
+				return err
+			}
+			detail.Media = *media
+			return nil
+		})
+	}
+
+	if include[DetailSEO] {
+		g.Go(func() error {
+			seo, err := b.repo.GetProductSEO(ctx, product.ID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil
+				}
+				return err
+			}
+			detail.SEO, err = toProductSEODTO(seo)
+			return err
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	return detail, nil
+}
+
+// ImportProducts parses an import file and records it as a job. Files of up to
+// ImportSyncRowLimit products are processed before returning unless async is
+// set; larger ones are processed in the background and tracked through
+// GetImportJob.
+func (b *ProductService) ImportProducts(ctx context.Context, userID string, format string, dryRun bool, async bool, file io.Reader) (*ImportJobDTO, error) {
+	rows, err := parseImportFile(format, file)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no products found", ErrInvalidImportFile)
+	}
+
+	job, err := b.repo.CreateImportJob(ctx, ImportJob{
+		Format:    format,
+		DryRun:    dryRun,
+		Status:    ImportPending,
+		TotalRows: len(rows),
+		CreatedBy: &userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	if async || len(rows) > ImportSyncRowLimit {
+		// The job outlives the request, so it must not use the request context
+		go b.runImport(context.Background(), *job, userID, rows)
+		return toImportJobDTO(job)
+	}
+
+	b.runImport(ctx, *job, userID, rows)
+
+	return b.GetImportJob(ctx, userID, job.ID)
+}
+
+// GetImportJob returns a job started by userID.
+func (b *ProductService) GetImportJob(ctx context.Context, userID string, jobID string) (*ImportJobDTO, error) {
+	job, err := b.repo.GetImportJob(ctx, userID, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	return toImportJobDTO(job)
+}
+
+// importProgressEvery is how many rows are processed between progress updates.
+const importProgressEvery = 25
+
+func (b *ProductService) runImport(ctx context.Context, job ImportJob, userID string, rows []ImportRow) {
+	job.Status = ImportRunning
+	report := make([]ImportRowResult, 0, len(rows))
+	cache := newImportCache()
+
+	save := func() {
+		data, err := json.Marshal(report)
+		if err != nil {
+			b.logger.Error("failed to encode import report", zap.String("job_id", job.ID), zap.Error(err))
+			return
+		}
+		job.Report = data
+		if err := b.repo.UpdateImportJob(ctx, job); err != nil {
+			b.logger.Error("failed to update import job", zap.String("job_id", job.ID), zap.Error(err))
+		}
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			message := fmt.Sprintf("import stopped: %v", r)
+			job.Status = ImportFailed
+			job.Error = &message
+			save()
+		}
+	}()
+
+	save()
+
+	for _, row := range rows {
+		result := b.importRow(ctx, cache, job.DryRun, userID, row)
+		report = append(report, result)
+
+		job.ProcessedRows++
+		if result.Success {
+			job.SucceededRows++
+		} else {
+			job.FailedRows++
+		}
+
+		if job.ProcessedRows%importProgressEvery == 0 {
+			save()
+		}
+	}
+
+	job.Status = ImportCompleted
+	save()
+}
+
+// importCache remembers slug lookups and category schemas across the rows of
+// one import.
+type importCache struct {
+	brands      map[string]*string
+	categories  map[string]*string
+	definitions map[string][]AttributeDefinition
+	slugs       map[string]bool
+}
+
+func newImportCache() *importCache {
+	return &importCache{
+		brands:      make(map[string]*string),
+		categories:  make(map[string]*string),
+		definitions: make(map[string][]AttributeDefinition),
+		slugs:       make(map[string]bool),
+	}
+}
+
+func (b *ProductService) importRow(ctx context.Context, cache *importCache, dryRun bool, userID string, row ImportRow) ImportRowResult {
+	result := ImportRowResult{
+		Line: row.Line,
+		Slug: row.Slug,
+	}
+
+	fail := func(problems ...string) ImportRowResult {
+		result.Errors = append(result.Errors, problems...)
+		return result
+	}
+
+	if row.Err != nil {
+		return fail(row.Err.Error())
+	}
+
+	problems := validateImportRow(row)
+
+	// A renamed product is updated through its old slug too. Updates are
+	// limited to the seller's own products that are not under review or
+	// published, which change through the workflow only.
+	var existing *ImportTarget
+	if row.Slug != "" {
+		var err error
+		if existing, err = b.repo.GetImportTarget(ctx, row.Slug); err != nil {
+			return fail(err.Error())
+		}
+	}
+	if existing != nil {
+		row.Slug = existing.Slug
+		switch {
+		case existing.SellerID != userID:
+			problems = append(problems, fmt.Sprintf("slug %q belongs to another seller", result.Slug))
+		case !slices.Contains(importUpdatableStatuses, existing.Status):
+			problems = append(problems, fmt.Sprintf("product %q is %s, only DRAFT and REJECTED products can be updated by import", existing.Slug, existing.Status))
+		}
+	}
+
+	if row.Slug != "" && cache.slugs[row.Slug] {
+		problems = append(problems, "slug appears more than once in the file")
+	}
+	cache.slugs[row.Slug] = true
+
+	brandID, ok := cache.brands[row.Brand]
+	if !ok && row.Brand != "" {
+		id, err := b.repo.GetBrandIDBySlug(ctx, row.Brand)
+		if err != nil {
+			return fail(err.Error())
+		}
+		brandID, cache.brands[row.Brand] = id, id
+	}
+	if brandID == nil && row.Brand != "" {
+		problems = append(problems, fmt.Sprintf("brand %q not found", row.Brand))
+	}
+
+	categoryID, ok := cache.categories[row.Category]
+	if !ok && row.Category != "" {
+		id, err := b.repo.GetCategoryIDBySlug(ctx, row.Category)
+		if err != nil {
+			return fail(err.Error())
+		}
+		categoryID, cache.categories[row.Category] = id, id
+	}
+	if categoryID == nil && row.Category != "" {
+		problems = append(problems, fmt.Sprintf("category %q not found", row.Category))
+	}
+
+	var attributes []ProductAttributeArray
+	if categoryID != nil && len(row.Attributes) > 0 {
+		definitions, ok := cache.definitions[*categoryID]
+		if !ok {
+			var err error
+			if definitions, err = b.repo.GetCategoryAttributeDefinitions(ctx, *categoryID); err != nil {
+				return fail(err.Error())
+			}
+			cache.definitions[*categoryID] = definitions
+		}
+
+		normalized, err := validateAttributes(definitions, importAttributes(row.Attributes))
+		if err != nil {
+			problems = append(problems, strings.TrimPrefix(err.Error(), ErrInvalidAttributes.Error()+": "))
+		}
+		attributes = normalized
+	}
+
+	skus := make([]string, 0, len(row.Variants))
+	for _, variant := range row.Variants {
+		skus = append(skus, variant.SKU)
+	}
+	owners, err := b.repo.GetVariantOwners(ctx, skus)
+	if err != nil {
+		return fail(err.Error())
+	}
+	for _, sku := range skus {
+		if owner, ok := owners[sku]; ok && owner != row.Slug {
+			problems = append(problems, fmt.Sprintf("sku %q belongs to product %q", sku, owner))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fail(problems...)
+	}
+
+	result.Action = ImportActionCreate
+	if existing != nil {
+		result.Action = ImportActionUpdate
+		result.ProductID = &existing.ID
+	}
+
+	if dryRun {
+		result.Success = true
+		return result
+	}
+
+	product := ImportProduct{
+		Slug:        row.Slug,
+		Name:        row.Name,
+		Description: row.Description,
+		SellerID:    userID,
+		BrandID:     *brandID,
+		CategoryID:  *categoryID,
+		Status:      row.Status,
+		Attributes:  attributes,
+	}
+
+	if len(row.Variants) > 0 {
+		variants := &VariantRequestDTO{Options: row.Options}
+		for _, variant := range row.Variants {
+			isActive := variant.IsActive == nil || *variant.IsActive
+			variants.Variants = append(variants.Variants, ProductVariant{
+				SKU:          variant.SKU,
+				Price:        variant.Price,
+				Currency:     strings.ToUpper(variant.Currency),
+				Weight:       variant.Weight,
+				IsActive:     isActive,
+				Options:      variant.Options,
+				OptionValues: variant.OptionValues,
+				OrderRules:   variant.OrderRules,
+			})
+		}
+		product.Variants = variants
+	}
+
+	for _, media := range row.Media {
+		mediaType := media.Type
+		if mediaType == "" {
+			mediaType = mediaTypeFromURL(media.Url)
+		}
+		product.Media = append(product.Media, ProductMediaDTO{
+			Url:          media.Url,
+			MediaType:    mediaType,
+			DisplayOrder: media.DisplayOrder,
+		})
+	}
+
+	if row.SEO != nil {
+		keywords, err := json.Marshal(row.SEO.Keywords)
+		if err != nil {
+			return fail(err.Error())
+		}
+		if row.SEO.Keywords == nil {
+			keywords = []byte("[]")
+		}
+		product.SEO = &ProductSEO{
+			MetaTitle:       row.SEO.MetaTitle,
+			MetaDescription: row.SEO.MetaDescription,
+			OgImageUrl:      row.SEO.OgImageUrl,
+			Keywords:        keywords,
+		}
+	}
+
+	productID, created, err := b.repo.ImportProduct(ctx, product)
+	if err != nil {
+		return fail(err.Error())
+	}
+
+	result.ProductID = &productID
+	result.Action = ImportActionUpdate
+	if created {
+		result.Action = ImportActionCreate
+	}
+	result.Success = true
+
+	return result
+}
+
+func toImportJobDTO(job *ImportJob) (*ImportJobDTO, error) {
+	report := []ImportRowResult{}
+	if len(job.Report) > 0 {
+		if err := json.Unmarshal(job.Report, &report); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ImportJobDTO{
+		ID:            job.ID,
+		Format:        job.Format,
+		DryRun:        job.DryRun,
+		Status:        job.Status,
+		TotalRows:     job.TotalRows,
+		ProcessedRows: job.ProcessedRows,
+		SucceededRows: job.SucceededRows,
+		FailedRows:    job.FailedRows,
+		Report:        report,
+		Error:         job.Error,
+		CreatedAt:     job.CreatedAt,
+		FinishedAt:    job.FinishedAt,
+	}, nil
+}
+
+// ExportProducts writes the products matching filters in format, one batch of
+// exportBatchSize products at a time so the catalog is never held in memory.
+// Page, Cursor and Sort of filters are ignored, and the products are limited
+// to what the user may list, see scopeProductListing. The columns and the
+// first batch are loaded before start is called for the writer, so an error
+// returned without start having been called means nothing was written. On a
+// later error an XLSX archive is left unfinished, so a cut short file does
+// not open.
+func (b *ProductService) ExportProducts(ctx context.Context, filters ProductFilters, format string, start func() io.Writer) error {
+	scopeProductListing(ctx, &filters)
+
+	if err := b.exportProducts(ctx, filters, format, start); err != nil {
+		b.logger.Error("product export failed", zap.String("format", format), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (b *ProductService) exportProducts(ctx context.Context, filters ProductFilters, format string, start func() io.Writer) error {
+	columns, err := b.repo.GetExportColumns(ctx, filters)
+	if err != nil {
+		return fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	batch, err := b.repo.GetExportBatch(ctx, filters, "", exportBatchSize)
+	if err != nil {
+		return fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	w := start()
+
+	var (
+		writer        exportWriter
+		flush, finish func() error
+		encoder       *json.Encoder
+	)
+
+	if format == ExportJSONL {
+		encoder = json.NewEncoder(w)
+	} else {
+		if writer, flush, finish, err = newExportWriter(format, w); err != nil {
+			return err
+		}
+		if err := writer.Write(exportHeader(columns)); err != nil {
+			return err
+		}
+	}
+
+	for {
+		for _, product := range batch {
+			if encoder != nil {
+				if err := encoder.Encode(exportRow(product)); err != nil {
+					return err
+				}
+				continue
+			}
+
+			for _, record := range exportRecords(columns, product) {
+				if err := writer.Write(record); err != nil {
+					return err
+				}
+			}
+		}
+
+		if flush != nil {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		if f, ok := w.(interface{ Flush() }); ok {
+			f.Flush()
+		}
+
+		if len(batch) < exportBatchSize {
+			break
+		}
+
+		batch, err = b.repo.GetExportBatch(ctx, filters, batch[len(batch)-1].ID, exportBatchSize)
+		if err != nil {
+			return fmt.Errorf("Error came while getting data from DB: %w", err)
+		}
+	}
+
+	if finish != nil {
+		return finish()
+	}
+	return nil
+}
+
+// managedProduct returns the product when the user making the request may
+// change it, see canManageProduct, and ErrProductNotFound otherwise, so one
+// seller can neither edit nor see the history of another seller's products.
+func (b *ProductService) managedProduct(ctx context.Context, productId string) (*Product, error) {
+	product, err := b.repo.GetProductByID(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageProduct(ctx, product) {
+		return nil, ErrProductNotFound
+	}
+	return product, nil
+}
+
+func (b *ProductService) GetProductVersions(ctx context.Context, productId string) (*ProductVersionListResponse, error) {
+	if _, err := b.managedProduct(ctx, productId); err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	response, err := b.repo.GetProductVersions(ctx, productId)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	versions := make([]ProductVersionDTO, 0, len(response))
+	for i := range response {
+		version, err := toProductVersionDTO(&response[i], false)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, *version)
+	}
+
+	return &ProductVersionListResponse{
+		ProductID: productId,
+		Versions:  versions,
+	}, nil
+}
+
+func (b *ProductService) GetProductVersion(ctx context.Context, productId string, version int) (*ProductVersionDTO, error) {
+	if _, err := b.managedProduct(ctx, productId); err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	response, err := b.repo.GetProductVersion(ctx, productId, version)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	return toProductVersionDTO(response, true)
+}
+
+// DiffProductVersions compares the snapshots of two versions of a product.
+func (b *ProductService) DiffProductVersions(ctx context.Context, productId string, from, to int) (*ProductVersionDiffResponse, error) {
+	if _, err := b.managedProduct(ctx, productId); err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	var fromVersion, toVersion *ProductVersion
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		var err error
+		fromVersion, err = b.repo.GetProductVersion(gctx, productId, from)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		toVersion, err = b.repo.GetProductVersion(gctx, productId, to)
+		return err
+	})
+	if err := g.Wait(); err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	var fromSnapshot, toSnapshot ProductSnapshot
+	if err := json.Unmarshal(fromVersion.Snapshot, &fromSnapshot); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(toVersion.Snapshot, &toSnapshot); err != nil {
+		return nil, err
+	}
+
+	return &ProductVersionDiffResponse{
+		ProductID: productId,
+		From:      from,
+		To:        to,
+		Diff:      diffSnapshots(&fromSnapshot, &toSnapshot),
+	}, nil
+}
+
+func (b *ProductService) RestoreProductVersion(ctx context.Context, productId string, version int) (*GenericResponseDTO, error) {
+	if _, err := b.managedProduct(ctx, productId); err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	if err := b.repo.RestoreProductVersion(ctx, productId, version, changedBy(ctx)); err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return &GenericResponseDTO{
+		ID:      &productId,
+		Status:  "success",
+		Message: fmt.Sprintf("Product Restored to Version %d Successfully", version),
+	}, nil
+}
+
+func toProductVersionDTO(version *ProductVersion, withSnapshot bool) (*ProductVersionDTO, error) {
+	response := &ProductVersionDTO{
+		Version:      version.Version,
+		ChangeType:   version.ChangeType,
+		ChangedBy:    version.ChangedBy,
+		RestoredFrom: version.RestoredFrom,
+		CreatedAt:    version.CreatedAt,
+		Diff:         []DiffEntry{},
+	}
+
+	if err := json.Unmarshal(version.Diff, &response.Diff); err != nil {
+		return nil, err
+	}
+
+	if withSnapshot {
+		response.Snapshot = &ProductSnapshot{}
+		if err := json.Unmarshal(version.Snapshot, response.Snapshot); err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
@@ -0,0 +1,283 @@
+package products
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+var ErrInvalidVariants = errors.New("invalid variants")
+
+// maxMatrixVariants caps how many variants one matrix may generate.
+const maxMatrixVariants = 1000
+
+// optionValueKey identifies an option value by its option, so that "Black" of
+// "Shell Colour" and "Black" of "Strap Colour" stay distinct.
+func optionValueKey(option, value string) string {
+	return option + "\x00" + value
+}
+
+// validateOptions checks that option names are unique and that every option
+// has distinct, non-empty values.
+func validateOptions(options []ProductOptionValue) error {
+	names := make(map[string]bool, len(options))
+	for _, option := range options {
+		if option.Name == "" {
+			return fmt.Errorf("%w: option name is required", ErrInvalidVariants)
+		}
+		if names[option.Name] {
+			return fmt.Errorf("%w: option %q is listed more than once", ErrInvalidVariants, option.Name)
+		}
+		names[option.Name] = true
+
+		if len(option.Values) == 0 {
+			return fmt.Errorf("%w: option %q has no values", ErrInvalidVariants, option.Name)
+		}
+		values := make(map[string]bool, len(option.Values))
+		for _, value := range option.Values {
+			if value == "" || values[value] {
+				return fmt.Errorf("%w: option %q has an empty or repeated value %q", ErrInvalidVariants, option.Name, value)
+			}
+			values[value] = true
+		}
+	}
+	return nil
+}
+
+// resolveVariantOptions returns the option value keys of a variant. Variants
+// name their values either with the Options map (option name
+// to value) or with OptionValues, where each value is matched to the first
+// option not yet used by the variant that has it.
+func resolveVariantOptions(options []ProductOptionValue, variant ProductVariant) ([]string, error) {
+	var keys []string
+
+	if len(variant.Options) > 0 {
+		for _, option := range options {
+			value, ok := variant.Options[option.Name]
+			if !ok {
+				continue
+			}
+			if !slices.Contains(option.Values, value) {
+				return nil, fmt.Errorf("%w: sku %q: %q is not a value of option %q", ErrInvalidVariants, variant.SKU, value, option.Name)
+			}
+			keys = append(keys, optionValueKey(option.Name, value))
+		}
+		if len(keys) != len(variant.Options) {
+			return nil, fmt.Errorf("%w: sku %q names an option the product does not have", ErrInvalidVariants, variant.SKU)
+		}
+		return keys, nil
+	}
+
+	used := make([]bool, len(options))
+	for _, value := range variant.OptionValues {
+		i := -1
+		for j, option := range options {
+			if !used[j] && slices.Contains(option.Values, value) {
+				i = j
+				break
+			}
+		}
+		if i < 0 {
+			return nil, fmt.Errorf("%w: sku %q: option value %q does not match any remaining option", ErrInvalidVariants, variant.SKU, value)
+		}
+		used[i] = true
+		keys = append(keys, optionValueKey(options[i].Name, value))
+	}
+
+	return keys, nil
+}
+
+// combinationKey identifies a variant by its set of option values.
+func combinationKey(options map[string]string) string {
+	keys := make([]string, 0, len(options))
+	for name, value := range options {
+		keys = append(keys, optionValueKey(name, value))
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, "\x01")
+}
+
+// expandMatrix returns the Cartesian product of the option values, each
+// combination as an option name to value map, varying the last option fastest.
+func expandMatrix(options []ProductOptionValue) []map[string]string {
+	combinations := []map[string]string{{}}
+	for _, option := range options {
+		next := make([]map[string]string, 0, len(combinations)*len(option.Values))
+		for _, combination := range combinations {
+			for _, value := range option.Values {
+				expanded := make(map[string]string, len(combination)+1)
+				for k, v := range combination {
+					expanded[k] = v
+				}
+				expanded[option.Name] = value
+				next = append(next, expanded)
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+// skuToken turns a name or value into the upper-case form used in SKUs.
+func skuToken(value string) string {
+	return strings.ToUpper(shared.Slugify(value))
+}
+
+// placeholderKey normalizes placeholder and option names so that {CUT_LEVEL},
+// {CutLevel} and the option "Cut Level" all match.
+func placeholderKey(name string) string {
+	return strings.ReplaceAll(skuToken(name), "-", "")
+}
+
+// renderSKU fills the {PLACEHOLDERS} of template from vars, keyed by
+// placeholderKey.
+func renderSKU(template string, vars map[string]string) (string, error) {
+	var b strings.Builder
+	for rest := template; rest != ""; {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			b.WriteString(rest)
+			break
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("%w: unclosed placeholder in sku template", ErrInvalidVariants)
+		}
+
+		b.WriteString(rest[:start])
+		name := rest[start+1 : start+end]
+		value, ok := vars[placeholderKey(name)]
+		if !ok {
+			available := make([]string, 0, len(vars))
+			for key := range vars {
+				available = append(available, "{"+key+"}")
+			}
+			sort.Strings(available)
+			return "", fmt.Errorf("%w: unknown placeholder {%s}, allowed: %s", ErrInvalidVariants, name, strings.Join(available, ", "))
+		}
+		b.WriteString(value)
+		rest = rest[start+end+1:]
+	}
+
+	sku := strings.TrimSpace(b.String())
+	if sku == "" || len(sku) > 100 {
+		return "", fmt.Errorf("%w: sku %q must be between 1 and 100 characters", ErrInvalidVariants, sku)
+	}
+	return sku, nil
+}
+
+// buildVariantMatrix plans the variants of a product for a matrix request.
+// Existing variants whose option combination is in the matrix keep their SKU,
+// price, weight and status; new combinations get a SKU from the template and
+// the default price and weight; existing variants outside the matrix are
+// deactivated.
+func buildVariantMatrix(productSlug, brandSlug string, request VariantMatrixRequestDTO, existing []ProductVariant) (*VariantMatrixResponse, error) {
+	if err := validateOptions(request.Options); err != nil {
+		return nil, err
+	}
+
+	total := 1
+	for _, option := range request.Options {
+		total *= len(option.Values)
+		if total > maxMatrixVariants {
+			return nil, fmt.Errorf("%w: the matrix has more than %d variants", ErrInvalidVariants, maxMatrixVariants)
+		}
+	}
+
+	byCombination := make(map[string]ProductVariant, len(existing))
+	for _, variant := range existing {
+		if len(variant.Options) > 0 {
+			byCombination[combinationKey(variant.Options)] = variant
+		}
+	}
+
+	vars := map[string]string{
+		"BRAND":   skuToken(brandSlug),
+		"PRODUCT": skuToken(productSlug),
+	}
+
+	response := &VariantMatrixResponse{
+		Options:  request.Options,
+		Variants: make([]MatrixVariant, 0, total),
+	}
+
+	kept := make(map[string]bool)
+	skus := make(map[string]bool, total)
+	for _, combination := range expandMatrix(request.Options) {
+		values := make([]string, 0, len(request.Options))
+		for _, option := range request.Options {
+			value := combination[option.Name]
+			values = append(values, value)
+
+			code := request.Codes[option.Name][value]
+			if code == "" {
+				code = skuToken(value)
+			}
+			vars[placeholderKey(option.Name)] = code
+		}
+
+		variant := MatrixVariant{
+			Price:        request.Price,
+			Currency:     request.Currency,
+			Weight:       request.Weight,
+			IsActive:     true,
+			Options:      combination,
+			OptionValues: values,
+			OrderRules:   request.OrderRules,
+			Action:       MatrixCreate,
+		}
+
+		if current, ok := byCombination[combinationKey(combination)]; ok {
+			variant.ID = current.ID
+			variant.SKU = current.SKU
+			variant.Price = current.Price
+			variant.Currency = current.Currency
+			variant.Weight = current.Weight
+			variant.IsActive = current.IsActive
+			variant.OrderRules = current.OrderRules
+			variant.Action = MatrixKeep
+			kept[current.SKU] = true
+			response.Kept++
+		} else {
+			sku, err := renderSKU(request.SKUTemplate, vars)
+			if err != nil {
+				return nil, err
+			}
+			variant.SKU = sku
+			response.Created++
+		}
+
+		if skus[variant.SKU] {
+			return nil, fmt.Errorf("%w: sku %q is generated more than once, add the missing option placeholders to the template", ErrInvalidVariants, variant.SKU)
+		}
+		skus[variant.SKU] = true
+		response.Variants = append(response.Variants, variant)
+	}
+
+	for _, current := range existing {
+		if kept[current.SKU] {
+			continue
+		}
+		if skus[current.SKU] {
+			return nil, fmt.Errorf("%w: sku %q is generated for a new combination but belongs to an existing variant", ErrInvalidVariants, current.SKU)
+		}
+		response.Variants = append(response.Variants, MatrixVariant{
+			ID:         current.ID,
+			SKU:        current.SKU,
+			Price:      current.Price,
+			Currency:   current.Currency,
+			Weight:     current.Weight,
+			IsActive:   false,
+			Options:    map[string]string{},
+			OrderRules: current.OrderRules,
+			Action:     MatrixDeactivate,
+		})
+		response.Deactivated++
+	}
+
+	return response, nil
+}
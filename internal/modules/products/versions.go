@@ -0,0 +1,114 @@
+package products
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+// diffSnapshots lists the fields that differ between two snapshots. Either
+// may be nil, in which case every field of the other is reported.
+func diffSnapshots(from, to *ProductSnapshot) []DiffEntry {
+	old, updated := flattenSnapshot(from), flattenSnapshot(to)
+
+	paths := make([]string, 0, len(old)+len(updated))
+	for path := range old {
+		paths = append(paths, path)
+	}
+	for path := range updated {
+		if _, ok := old[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	diff := make([]DiffEntry, 0)
+	for _, path := range paths {
+		oldValue, hadOld := old[path]
+		newValue, hasNew := updated[path]
+		if hadOld && hasNew && oldValue == newValue {
+			continue
+		}
+
+		entry := DiffEntry{Path: path}
+		if hadOld {
+			entry.Old = &oldValue
+		}
+		if hasNew {
+			entry.New = &newValue
+		}
+		diff = append(diff, entry)
+	}
+
+	return diff
+}
+
+// flattenSnapshot maps every field of a snapshot to a path. List items are
+// keyed by their identity (attribute key, option name, SKU, media URL) rather
+// than position, so reordering is not reported as a change.
+func flattenSnapshot(snapshot *ProductSnapshot) map[string]string {
+	flat := make(map[string]string)
+	if snapshot == nil {
+		return flat
+	}
+
+	flat["name"] = snapshot.Name
+	flat["slug"] = snapshot.Slug
+	flat["description"] = stringValue(snapshot.Description)
+	flat["seller_id"] = snapshot.SellerID
+	flat["brand_id"] = stringValue(snapshot.BrandID)
+	flat["category_id"] = stringValue(snapshot.CategoryID)
+	flat["status"] = string(snapshot.Status)
+
+	for _, attribute := range snapshot.Attributes {
+		flat["attributes."+attribute.AttributeKey] = attribute.AttributeValue
+	}
+
+	for _, option := range snapshot.Options {
+		flat["options."+option.Name] = strings.Join(option.Values, "|")
+	}
+
+	for _, variant := range snapshot.Variants {
+		prefix := "variants." + variant.SKU + "."
+		flat[prefix+"price"] = variant.Price.String()
+		flat[prefix+"currency"] = variant.Currency
+		if variant.Weight != nil {
+			flat[prefix+"weight"] = strconv.FormatFloat(*variant.Weight, 'f', -1, 64)
+		}
+		flat[prefix+"is_active"] = strconv.FormatBool(variant.IsActive)
+		flat[prefix+"option_values"] = strings.Join(variant.OptionValues, "|")
+		rules := variant.OrderRules.Normalized()
+		flat[prefix+"unit"] = rules.Unit
+		flat[prefix+"min_order_quantity"] = strconv.Itoa(rules.MinOrderQuantity)
+		flat[prefix+"order_multiple"] = strconv.Itoa(rules.OrderMultiple)
+		flat[prefix+"pack_sizes"] = rules.PackSizes.String()
+	}
+
+	for _, media := range snapshot.Media {
+		prefix := "media." + media.Url + "."
+		flat[prefix+"type"] = string(media.Type)
+		flat[prefix+"display_order"] = strconv.Itoa(media.DisplayOrder)
+		flat[prefix+"variant_sku"] = stringValue(media.VariantSKU)
+	}
+
+	if snapshot.SEO != nil {
+		flat["seo.meta_title"] = snapshot.SEO.MetaTitle
+		flat["seo.meta_description"] = snapshot.SEO.MetaDescription
+		flat["seo.og_image_url"] = snapshot.SEO.OgImageUrl
+		flat["seo.keywords"] = strings.Join(snapshot.SEO.Keywords, "|")
+	}
+
+	return flat
+}
+
+// changedBy is the user making the request, taken from the JWT claims.
+func changedBy(ctx context.Context) *string {
+	claims, ok := ctx.Value(shared.UserClaimsKey).(*shared.UserClaims)
+	if !ok || claims.UserID == "" {
+		return nil
+	}
+	return &claims.UserID
+}
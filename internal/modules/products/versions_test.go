@@ -0,0 +1,173 @@
+package products
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+func testSnapshot() *ProductSnapshot {
+	description := "Steel toe cap"
+	brandID := "brand-1"
+	weight := 1.2
+	return &ProductSnapshot{
+		Name:        "Safety Shoe",
+		Slug:        "safety-shoe",
+		Description: &description,
+		SellerID:    "seller-1",
+		BrandID:     &brandID,
+		Status:      DRAFT,
+		Attributes: []ProductAttributeArray{
+			{AttributeKey: "material", AttributeValue: "leather"},
+			{AttributeKey: "standard", AttributeValue: "EN ISO 20345"},
+		},
+		Options: []ProductOptionValue{{Name: "size", Values: []string{"8", "9"}}},
+		Variants: []SnapshotVariant{
+			{SKU: "SHOE-8", Price: shared.NewDecimal(1499), Currency: "INR", Weight: &weight, IsActive: true, OptionValues: []string{"8"}},
+			{SKU: "SHOE-9", Price: shared.NewDecimal(1499), Currency: "INR", IsActive: true, OptionValues: []string{"9"}},
+		},
+		Media: []SnapshotMedia{
+			{Url: "https://cdn.example.com/a.jpg", Type: IMAGE, DisplayOrder: 0},
+			{Url: "https://cdn.example.com/b.jpg", Type: IMAGE, DisplayOrder: 1},
+		},
+	}
+}
+
+// formatDiff renders entries as "path: old -> new", with nil shown as <nil>.
+func formatDiff(diff []DiffEntry) []string {
+	value := func(s *string) string {
+		if s == nil {
+			return "<nil>"
+		}
+		return fmt.Sprintf("%q", *s)
+	}
+
+	lines := make([]string, len(diff))
+	for i, entry := range diff {
+		lines[i] = entry.Path + ": " + value(entry.Old) + " -> " + value(entry.New)
+	}
+	return lines
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(*ProductSnapshot)
+		want   []string
+	}{
+		{
+			name:   "unchanged",
+			change: func(*ProductSnapshot) {},
+			want:   []string{},
+		},
+		{
+			name: "reordered lists",
+			change: func(s *ProductSnapshot) {
+				slices.Reverse(s.Attributes)
+				slices.Reverse(s.Variants)
+				slices.Reverse(s.Media)
+			},
+			want: []string{},
+		},
+		{
+			name: "default order rules",
+			change: func(s *ProductSnapshot) {
+				s.Variants[0].OrderRules = shared.OrderRules{Unit: shared.DefaultUnit, MinOrderQuantity: 1, OrderMultiple: 1}
+			},
+			want: []string{},
+		},
+		{
+			name: "fields",
+			change: func(s *ProductSnapshot) {
+				s.Name = "Safety Boot"
+				s.Description = nil
+				s.Status = PENDING_REVIEW
+			},
+			want: []string{
+				`description: "Steel toe cap" -> ""`,
+				`name: "Safety Shoe" -> "Safety Boot"`,
+				`status: "DRAFT" -> "PENDING_REVIEW"`,
+			},
+		},
+		{
+			name: "variant",
+			change: func(s *ProductSnapshot) {
+				s.Variants[1].Price = shared.NewDecimal(1299)
+				s.Variants[1].MinOrderQuantity = 10
+				s.Variants[0].Weight = nil
+			},
+			want: []string{
+				`variants.SHOE-8.weight: "1.2" -> <nil>`,
+				`variants.SHOE-9.min_order_quantity: "1" -> "10"`,
+				`variants.SHOE-9.price: "1499" -> "1299"`,
+			},
+		},
+		{
+			name: "added and removed items",
+			change: func(s *ProductSnapshot) {
+				s.Attributes = append(s.Attributes[:1], ProductAttributeArray{AttributeKey: "colour", AttributeValue: "black"})
+				s.Media = s.Media[:1]
+				s.Options[0].Values = append(s.Options[0].Values, "10")
+			},
+			want: []string{
+				`attributes.colour: <nil> -> "black"`,
+				`attributes.standard: "EN ISO 20345" -> <nil>`,
+				`media.https://cdn.example.com/b.jpg.display_order: "1" -> <nil>`,
+				`media.https://cdn.example.com/b.jpg.type: "image" -> <nil>`,
+				`media.https://cdn.example.com/b.jpg.variant_sku: "" -> <nil>`,
+				`options.size: "8|9" -> "8|9|10"`,
+			},
+		},
+		{
+			name: "seo added",
+			change: func(s *ProductSnapshot) {
+				s.SEO = &ProductSEODTO{MetaTitle: "Safety Shoe", Keywords: []string{"shoe", "ppe"}}
+			},
+			want: []string{
+				`seo.keywords: <nil> -> "shoe|ppe"`,
+				`seo.meta_description: <nil> -> ""`,
+				`seo.meta_title: <nil> -> "Safety Shoe"`,
+				`seo.og_image_url: <nil> -> ""`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			updated := testSnapshot()
+			test.change(updated)
+
+			got := formatDiff(diffSnapshots(testSnapshot(), updated))
+			if !slices.Equal(got, test.want) {
+				t.Errorf("diff =\n%v\nwant\n%v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDiffSnapshotsNil(t *testing.T) {
+	snapshot := testSnapshot()
+	fields := len(flattenSnapshot(snapshot))
+
+	created := diffSnapshots(nil, snapshot)
+	if len(created) != fields {
+		t.Fatalf("diff from nil has %d entries, want %d", len(created), fields)
+	}
+	for _, entry := range created {
+		if entry.Old != nil || entry.New == nil {
+			t.Errorf("diff from nil: %s has old %v and new %v", entry.Path, entry.Old, entry.New)
+		}
+	}
+
+	for _, entry := range diffSnapshots(snapshot, nil) {
+		if entry.Old == nil || entry.New != nil {
+			t.Errorf("diff to nil: %s has old %v and new %v", entry.Path, entry.Old, entry.New)
+		}
+	}
+
+	if diff := diffSnapshots(nil, nil); len(diff) != 0 {
+		t.Errorf("diff of nil snapshots = %v, want none", diff)
+	}
+}
@@ -0,0 +1,126 @@
+package products
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+var (
+	ErrInvalidTransition = errors.New("invalid status transition")
+	ErrTransitionDenied  = errors.New("missing permission for transition")
+)
+
+// Workflow actions, each moving a product between statuses.
+const (
+	ActionSubmit   = "submit"
+	ActionWithdraw = "withdraw"
+	ActionApprove  = "approve"
+	ActionReject   = "reject"
+	ActionPublish  = "publish"
+	ActionArchive  = "archive"
+	ActionRestore  = "restore"
+)
+
+// Transition describes one workflow action: the statuses it applies to, the
+// status it leads to and the scope required to perform it.
+type Transition struct {
+	From  []ProductStatus
+	To    ProductStatus
+	Scope string
+}
+
+// Transitions is the product state machine. Approving with a future
+// publish_at leads to SCHEDULED instead of ACTIVE. The scheduler moves
+// SCHEDULED products to ACTIVE at publish_at and ACTIVE products to ARCHIVED
+// at unpublish_at.
+var Transitions = map[string]Transition{
+	ActionSubmit:   {From: []ProductStatus{DRAFT, REJECTED}, To: PENDING_REVIEW, Scope: "catalog:update"},
+	ActionWithdraw: {From: []ProductStatus{PENDING_REVIEW, SCHEDULED}, To: DRAFT, Scope: "catalog:update"},
+	ActionApprove:  {From: []ProductStatus{PENDING_REVIEW}, To: ACTIVE, Scope: "catalog:review"},
+	ActionReject:   {From: []ProductStatus{PENDING_REVIEW, SCHEDULED}, To: REJECTED, Scope: "catalog:review"},
+	ActionPublish:  {From: []ProductStatus{SCHEDULED}, To: ACTIVE, Scope: "catalog:review"},
+	ActionArchive:  {From: []ProductStatus{DRAFT, REJECTED, SCHEDULED, ACTIVE}, To: ARCHIVED, Scope: "catalog:delete"},
+	ActionRestore:  {From: []ProductStatus{ARCHIVED}, To: DRAFT, Scope: "catalog:update"},
+}
+
+// approvedStatuses hold content a reviewer has approved; editing the product
+// sends it back to PENDING_REVIEW.
+var approvedStatuses = []ProductStatus{SCHEDULED, ACTIVE}
+
+// resolveTransition checks that action may be performed on a product in
+// status by a user holding permissions, and returns the status it leads to.
+func resolveTransition(action string, status ProductStatus, permissions []string, request WorkflowRequestDTO, now time.Time) (ProductStatus, error) {
+	transition, ok := Transitions[action]
+	if !ok {
+		return "", fmt.Errorf("%w: unknown action %q", ErrInvalidTransition, action)
+	}
+
+	if !slices.Contains(permissions, transition.Scope) {
+		return "", fmt.Errorf("%w: %s requires %s", ErrTransitionDenied, action, transition.Scope)
+	}
+
+	if !slices.Contains(transition.From, status) {
+		return "", fmt.Errorf("%w: cannot %s a product in %s", ErrInvalidTransition, action, status)
+	}
+
+	if action == ActionReject && request.Reason == "" {
+		return "", fmt.Errorf("%w: a reason is required to reject", ErrInvalidTransition)
+	}
+
+	if action == ActionApprove && request.UnpublishAt != nil {
+		if !request.UnpublishAt.After(now) || (request.PublishAt != nil && !request.UnpublishAt.After(*request.PublishAt)) {
+			return "", fmt.Errorf("%w: unpublish_at must be in the future and after publish_at", ErrInvalidTransition)
+		}
+	}
+
+	if action == ActionApprove && request.PublishAt != nil && request.PublishAt.After(now) {
+		return SCHEDULED, nil
+	}
+
+	return transition.To, nil
+}
+
+// claimPermissions are the permissions of the user making the request, taken
+// from the JWT claims.
+func claimPermissions(ctx context.Context) []string {
+	claims, ok := ctx.Value(shared.UserClaimsKey).(*shared.UserClaims)
+	if !ok {
+		return nil
+	}
+	return claims.Permissions
+}
+
+// canReadProduct reports whether the user making the request may read a
+// product: anyone once it is ACTIVE, otherwise only its seller and catalog
+// reviewers.
+func canReadProduct(ctx context.Context, product *Product) bool {
+	return product.Status == ACTIVE || canManageProduct(ctx, product)
+}
+
+// canManageProduct reports whether the user making the request may change a
+// product, see shared.CanManageProduct.
+func canManageProduct(ctx context.Context, product *Product) bool {
+	return shared.CanManageProduct(ctx, product.SellerID)
+}
+
+// scopeProductListing limits a listing to what the user making the request
+// may read, as canReadProduct does for one product. The public and buyers only
+// list ACTIVE products; another status is listed for catalog reviewers, and
+// for sellers over their own products only.
+func scopeProductListing(ctx context.Context, filters *ProductFilters) {
+	claims, ok := ctx.Value(shared.UserClaimsKey).(*shared.UserClaims)
+	switch {
+	case filters.Status == "" || filters.Status == string(ACTIVE) || !ok:
+		filters.Status = string(ACTIVE)
+	case slices.Contains(claims.Permissions, "catalog:review"):
+	case slices.Contains(claims.Permissions, "catalog:update") && claims.UserID != "":
+		filters.SellerID = &claims.UserID
+	default:
+		filters.Status = string(ACTIVE)
+	}
+}
@@ -0,0 +1,171 @@
+package products
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+func TestResolveTransition(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	past, future, later := now.Add(-time.Hour), now.Add(time.Hour), now.Add(48*time.Hour)
+
+	seller := []string{"catalog:view", "catalog:create", "catalog:update", "catalog:delete"}
+	reviewer := []string{"catalog:view", "catalog:review"}
+
+	tests := []struct {
+		name        string
+		action      string
+		status      ProductStatus
+		permissions []string
+		request     WorkflowRequestDTO
+		want        ProductStatus
+		err         error
+	}{
+		{name: "submit draft", action: ActionSubmit, status: DRAFT, permissions: seller, want: PENDING_REVIEW},
+		{name: "resubmit rejected", action: ActionSubmit, status: REJECTED, permissions: seller, want: PENDING_REVIEW},
+		{name: "submit active", action: ActionSubmit, status: ACTIVE, permissions: seller, err: ErrInvalidTransition},
+		{name: "withdraw scheduled", action: ActionWithdraw, status: SCHEDULED, permissions: seller, want: DRAFT},
+		{name: "seller cannot approve", action: ActionApprove, status: PENDING_REVIEW, permissions: seller, err: ErrTransitionDenied},
+		{name: "reviewer cannot submit", action: ActionSubmit, status: DRAFT, permissions: reviewer, err: ErrTransitionDenied},
+		{name: "reviewer cannot archive", action: ActionArchive, status: ACTIVE, permissions: reviewer, err: ErrTransitionDenied},
+		{name: "no permissions", action: ActionRestore, status: ARCHIVED, err: ErrTransitionDenied},
+		// The scope is checked before the status, so a denied user learns
+		// nothing about the product.
+		{name: "denied before status check", action: ActionPublish, status: DRAFT, permissions: seller, err: ErrTransitionDenied},
+		{name: "unknown action", action: "delete", status: DRAFT, permissions: append(seller, reviewer...), err: ErrInvalidTransition},
+		{name: "approve", action: ActionApprove, status: PENDING_REVIEW, permissions: reviewer, want: ACTIVE},
+		{name: "approve draft", action: ActionApprove, status: DRAFT, permissions: reviewer, err: ErrInvalidTransition},
+		{name: "approve past publish_at", action: ActionApprove, status: PENDING_REVIEW, permissions: reviewer,
+			request: WorkflowRequestDTO{PublishAt: &past}, want: ACTIVE},
+		{name: "approve future publish_at", action: ActionApprove, status: PENDING_REVIEW, permissions: reviewer,
+			request: WorkflowRequestDTO{PublishAt: &future}, want: SCHEDULED},
+		{name: "approve with unpublish_at", action: ActionApprove, status: PENDING_REVIEW, permissions: reviewer,
+			request: WorkflowRequestDTO{PublishAt: &future, UnpublishAt: &later}, want: SCHEDULED},
+		{name: "unpublish_at in the past", action: ActionApprove, status: PENDING_REVIEW, permissions: reviewer,
+			request: WorkflowRequestDTO{UnpublishAt: &past}, err: ErrInvalidTransition},
+		{name: "unpublish_at before publish_at", action: ActionApprove, status: PENDING_REVIEW, permissions: reviewer,
+			request: WorkflowRequestDTO{PublishAt: &later, UnpublishAt: &future}, err: ErrInvalidTransition},
+		{name: "unpublish_at at publish_at", action: ActionApprove, status: PENDING_REVIEW, permissions: reviewer,
+			request: WorkflowRequestDTO{PublishAt: &future, UnpublishAt: &future}, err: ErrInvalidTransition},
+		{name: "reject without reason", action: ActionReject, status: PENDING_REVIEW, permissions: reviewer, err: ErrInvalidTransition},
+		{name: "reject", action: ActionReject, status: PENDING_REVIEW, permissions: reviewer,
+			request: WorkflowRequestDTO{Reason: "missing certificate"}, want: REJECTED},
+		{name: "reject scheduled", action: ActionReject, status: SCHEDULED, permissions: reviewer,
+			request: WorkflowRequestDTO{Reason: "recalled"}, want: REJECTED},
+		{name: "publish scheduled", action: ActionPublish, status: SCHEDULED, permissions: reviewer, want: ACTIVE},
+		{name: "archive active", action: ActionArchive, status: ACTIVE, permissions: seller, want: ARCHIVED},
+		{name: "archive pending", action: ActionArchive, status: PENDING_REVIEW, permissions: seller, err: ErrInvalidTransition},
+		{name: "archive archived", action: ActionArchive, status: ARCHIVED, permissions: seller, err: ErrInvalidTransition},
+		{name: "restore", action: ActionRestore, status: ARCHIVED, permissions: seller, want: DRAFT},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := resolveTransition(test.action, test.status, test.permissions, test.request, now)
+			if test.err != nil {
+				if !errors.Is(err, test.err) {
+					t.Fatalf("error = %v, want %v", err, test.err)
+				}
+				return
+			}
+			if err != nil || got != test.want {
+				t.Fatalf("got %s, %v, want %s", got, err, test.want)
+			}
+		})
+	}
+}
+
+func withClaims(userID string, permissions ...string) context.Context {
+	return context.WithValue(context.Background(), shared.UserClaimsKey, &shared.UserClaims{UserID: userID, Permissions: permissions})
+}
+
+func TestCanReadProduct(t *testing.T) {
+	seller := withClaims("seller-1", "catalog:view", "catalog:update")
+	otherSeller := withClaims("seller-2", "catalog:view", "catalog:update")
+	reviewer := withClaims("admin-1", "catalog:view", "catalog:review")
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		status ProductStatus
+		want   bool
+	}{
+		{name: "public active", ctx: context.Background(), status: ACTIVE, want: true},
+		{name: "public draft", ctx: context.Background(), status: DRAFT},
+		{name: "public scheduled", ctx: context.Background(), status: SCHEDULED},
+		{name: "buyer pending", ctx: withClaims("buyer-1", "catalog:view"), status: PENDING_REVIEW},
+		{name: "own draft", ctx: seller, status: DRAFT, want: true},
+		{name: "own rejected", ctx: seller, status: REJECTED, want: true},
+		{name: "another seller's draft", ctx: otherSeller, status: DRAFT},
+		{name: "another seller's active", ctx: otherSeller, status: ACTIVE, want: true},
+		{name: "reviewer pending", ctx: reviewer, status: PENDING_REVIEW, want: true},
+		{name: "reviewer archived", ctx: reviewer, status: ARCHIVED, want: true},
+	}
+
+	for _, test := range tests {
+		product := &Product{SellerID: "seller-1", Status: test.status}
+		if got := canReadProduct(test.ctx, product); got != test.want {
+			t.Errorf("%s: canReadProduct = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestCanManageProduct(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want bool
+	}{
+		{name: "public", ctx: context.Background()},
+		{name: "own product", ctx: withClaims("seller-1", "catalog:update"), want: true},
+		{name: "another seller's product", ctx: withClaims("seller-2", "catalog:update", "catalog:delete")},
+		{name: "reviewer", ctx: withClaims("admin-1", "catalog:review"), want: true},
+		{name: "no user id", ctx: withClaims("", "catalog:update")},
+	}
+
+	for _, test := range tests {
+		product := &Product{SellerID: "seller-1", Status: ACTIVE}
+		if got := canManageProduct(test.ctx, product); got != test.want {
+			t.Errorf("%s: canManageProduct = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestScopeProductListing(t *testing.T) {
+	seller := withClaims("seller-1", "catalog:view", "catalog:update")
+	buyer := withClaims("buyer-1", "catalog:view")
+	reviewer := withClaims("admin-1", "catalog:view", "catalog:update", "catalog:review")
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		status   string
+		want     string
+		sellerID string
+	}{
+		{name: "public", ctx: context.Background(), want: "ACTIVE"},
+		{name: "public draft", ctx: context.Background(), status: "DRAFT", want: "ACTIVE"},
+		{name: "buyer scheduled", ctx: buyer, status: "SCHEDULED", want: "ACTIVE"},
+		{name: "seller without status", ctx: seller, want: "ACTIVE"},
+		{name: "seller active", ctx: seller, status: "ACTIVE", want: "ACTIVE"},
+		{name: "seller drafts", ctx: seller, status: "DRAFT", want: "DRAFT", sellerID: "seller-1"},
+		{name: "reviewer pending", ctx: reviewer, status: "PENDING_REVIEW", want: "PENDING_REVIEW"},
+	}
+
+	for _, test := range tests {
+		filters := ProductFilters{Status: test.status}
+		scopeProductListing(test.ctx, &filters)
+
+		sellerID := ""
+		if filters.SellerID != nil {
+			sellerID = *filters.SellerID
+		}
+		if filters.Status != test.want || sellerID != test.sellerID {
+			t.Errorf("%s: status %q seller %q, want %q seller %q", test.name, filters.Status, sellerID, test.want, test.sellerID)
+		}
+	}
+}
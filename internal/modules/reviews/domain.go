@@ -0,0 +1,72 @@
+package reviews
+
+import (
+	"errors"
+	"time"
+
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+var (
+	ErrReviewNotFound   = errors.New("review not found")
+	ErrProductNotFound  = errors.New("product not found")
+	ErrAlreadyReviewed  = errors.New("product already reviewed")
+	ErrInvalidReview    = errors.New("invalid review")
+	ErrNotProductSeller = errors.New("only the seller of the product can reply")
+	ErrAlreadyReplied   = errors.New("review already has a reply")
+)
+
+type Review struct {
+	ID                 string     `db:"id"`
+	ProductID          string     `db:"product_id"`
+	ProductName        string     `db:"product_name"`
+	UserID             string     `db:"user_id"`
+	AuthorName         string     `db:"author_name"`
+	Rating             int        `db:"rating"`
+	Title              string     `db:"title"`
+	Body               string     `db:"body"`
+	Status             string     `db:"status"`
+	RejectionReason    *string    `db:"rejection_reason"`
+	IsVerifiedPurchase bool       `db:"is_verified_purchase"`
+	ModeratedBy        *string    `db:"moderated_by"`
+	ModeratedAt        *time.Time `db:"moderated_at"`
+	Reply              *string    `db:"reply"`
+	RepliedBy          *string    `db:"replied_by"`
+	RepliedAt          *time.Time `db:"replied_at"`
+	CreatedAt          time.Time  `db:"created_at"`
+	UpdatedAt          time.Time  `db:"updated_at"`
+}
+
+type ReviewPhoto struct {
+	ReviewID string `db:"review_id"`
+	URL      string `db:"url"`
+}
+
+// ReviewFilters narrow a review listing. Rating selects one star rating and
+// Sort is one of ReviewSortOrders.
+type ReviewFilters struct {
+	ProductID string
+	Status    string
+	Rating    int
+	Sort      string
+	Page      int
+	Limit     int
+}
+
+type ReviewPage struct {
+	Reviews    []Review
+	Photos     []ReviewPhoto
+	TotalCount int
+}
+
+type RatingCount struct {
+	Rating int `db:"rating"`
+	Count  int `db:"count"`
+}
+
+// RatingSummary is the aggregate of the APPROVED reviews of a product.
+type RatingSummary struct {
+	Average shared.Decimal `db:"rating_average"`
+	Count   int            `db:"rating_count"`
+	Ratings []RatingCount
+}
@@ -0,0 +1,93 @@
+package reviews
+
+import (
+	"time"
+
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+// ReviewRequestDTO is a buyer's review. Photos are URLs returned by
+// /upload-review-photo; other URLs are rejected.
+type ReviewRequestDTO struct {
+	Rating int      `json:"rating" validate:"required,min=1,max=5"`
+	Title  string   `json:"title" validate:"required,max=200"`
+	Body   string   `json:"body" validate:"required,max=5000"`
+	Photos []string `json:"photos" validate:"omitempty,max=5,dive,url"`
+}
+
+// PhotoUploadResponse has the shape of the generic upload response.
+type PhotoUploadResponse struct {
+	Success bool               `json:"success"`
+	Count   int                `json:"count"`
+	Data    []UploadedPhotoDTO `json:"data"`
+}
+
+type UploadedPhotoDTO struct {
+	URL string `json:"url"`
+}
+
+// ModerateReviewDTO approves or rejects a review; Reason is required to
+// reject. VerifiedPurchase, when set, marks whether the purchase is confirmed.
+// The catalog has no orders to derive it from, so the moderator sets it by
+// hand after checking the purchase.
+type ModerateReviewDTO struct {
+	Action           string `json:"action" validate:"required,oneof=APPROVE REJECT"`
+	Reason           string `json:"reason" validate:"required_if=Action REJECT,max=1000"`
+	VerifiedPurchase *bool  `json:"verified_purchase"`
+}
+
+type ReplyRequestDTO struct {
+	Reply string `json:"reply" validate:"required,max=2000"`
+}
+
+// ReviewResponse leaves the moderation fields out of public listings.
+type ReviewResponse struct {
+	ID               string       `json:"id"`
+	ProductID        string       `json:"product_id"`
+	ProductName      string       `json:"product_name,omitempty"`
+	AuthorName       string       `json:"author_name"`
+	Rating           int          `json:"rating"`
+	Title            string       `json:"title"`
+	Body             string       `json:"body"`
+	Photos           []string     `json:"photos"`
+	VerifiedPurchase bool         `json:"verified_purchase"`
+	Reply            *ReviewReply `json:"reply"`
+	Status           string       `json:"status,omitempty"`
+	RejectionReason  *string      `json:"rejection_reason,omitempty"`
+	ModeratedAt      *time.Time   `json:"moderated_at,omitempty"`
+	CreatedAt        time.Time    `json:"created_at"`
+}
+
+// ReviewReply is the seller's public reply to a review.
+type ReviewReply struct {
+	Body      string    `json:"body"`
+	RepliedAt time.Time `json:"replied_at"`
+}
+
+type ReviewListResponse struct {
+	Reviews []ReviewResponse `json:"reviews"`
+	// Summary of the approved reviews, on the public listing of a product.
+	Summary    *RatingSummaryDTO `json:"summary,omitempty"`
+	TotalCount int               `json:"total_count"`
+	Page       int               `json:"page"`
+	Limit      int               `json:"limit"`
+}
+
+// RatingSummaryDTO is the average rating and review count with the count per
+// star rating, from 5 down to 1.
+type RatingSummaryDTO struct {
+	Average shared.Decimal   `json:"average"`
+	Count   int              `json:"count"`
+	Ratings []RatingCountDTO `json:"ratings"`
+}
+
+type RatingCountDTO struct {
+	Rating int `json:"rating"`
+	Count  int `json:"count"`
+}
+
+type GenericResponseDTO struct {
+	ID      *string `json:"id,omitempty"`
+	Status  string  `json:"success"`
+	Message string  `json:"message"`
+}
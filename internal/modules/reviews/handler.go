@@ -0,0 +1,310 @@
+package reviews
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+type RestHandler struct {
+	service   *ReviewService
+	validator *validator.Validate
+}
+
+func NewRestHandler(service *ReviewService, validator *validator.Validate) *RestHandler {
+	return &RestHandler{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// reviewFilters reads ?[rating=<1-5>][&sort=<sort>][&page=<n>][&limit=<n>].
+func reviewFilters(query url.Values) (ReviewFilters, error) {
+	filters := ReviewFilters{
+		Sort:  query.Get("sort"),
+		Page:  1,
+		Limit: 20,
+	}
+
+	if filters.Sort != "" && !slices.Contains(ReviewSortOrders, filters.Sort) {
+		return filters, errors.New("Invalid sort, allowed: " + strings.Join(ReviewSortOrders, ","))
+	}
+
+	if value := query.Get("rating"); value != "" {
+		rating, err := strconv.Atoi(value)
+		if err != nil || rating < 1 || rating > 5 {
+			return filters, errors.New("rating must be between 1 and 5")
+		}
+		filters.Rating = rating
+	}
+
+	if p, err := strconv.Atoi(query.Get("page")); err == nil && p > 0 {
+		filters.Page = p
+	}
+
+	if l, err := strconv.Atoi(query.Get("limit")); err == nil && l > 0 {
+		filters.Limit = min(l, 100)
+	}
+
+	return filters, nil
+}
+
+// GetProductReviews is the public listing of the approved reviews of a
+// product with its rating summary.
+func (h *RestHandler) GetProductReviews(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "id")
+
+	if productID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	filters, err := reviewFilters(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filters.ProductID = productID
+
+	response, err := h.service.GetProductReviews(r.Context(), filters)
+	if err != nil {
+		http.Error(w, err.Error(), reviewErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// GetReviews is the moderation queue: PENDING reviews unless
+// ?status=<status>, optionally of one ?product_id=<id>.
+func (h *RestHandler) GetReviews(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	filters, err := reviewFilters(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filters.ProductID = query.Get("product_id")
+	filters.Status = StatusPending
+
+	if status := strings.ToUpper(query.Get("status")); status != "" {
+		if !slices.Contains(ReviewStatuses, status) {
+			http.Error(w, "Invalid status, allowed: "+strings.Join(ReviewStatuses, ","), http.StatusBadRequest)
+			return
+		}
+		filters.Status = status
+	}
+
+	response, err := h.service.GetReviews(r.Context(), filters)
+	if err != nil {
+		http.Error(w, err.Error(), reviewErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// UploadReviewPhotos takes up to maxReviewPhotos PNG, JPEG or WebP images as
+// "file" parts and returns the URLs to list in a review.
+func (h *RestHandler) UploadReviewPhotos(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxReviewPhotos*maxPhotoSize+1<<20)
+	if err := r.ParseMultipartForm(maxPhotoSize); err != nil {
+		http.Error(w, "Multipart parse error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	headers := r.MultipartForm.File["file"]
+	if len(headers) == 0 {
+		http.Error(w, "No files provided", http.StatusBadRequest)
+		return
+	}
+	if len(headers) > maxReviewPhotos {
+		http.Error(w, fmt.Sprintf("At most %d photos can be uploaded", maxReviewPhotos), http.StatusBadRequest)
+		return
+	}
+
+	response := PhotoUploadResponse{
+		Success: true,
+		Data:    make([]UploadedPhotoDTO, 0, len(headers)),
+	}
+	for _, header := range headers {
+		photo, err := h.uploadPhoto(r.Context(), header)
+		if err != nil {
+			http.Error(w, err.Error(), reviewErrorStatus(err))
+			return
+		}
+		response.Data = append(response.Data, *photo)
+	}
+	response.Count = len(response.Data)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RestHandler) uploadPhoto(ctx context.Context, header *multipart.FileHeader) (*UploadedPhotoDTO, error) {
+	if header.Size > maxPhotoSize {
+		return nil, fmt.Errorf("%w: %s is larger than %d MB", ErrInvalidReview, header.Filename, maxPhotoSize>>20)
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	contentType, err := shared.DetectContentType(file)
+	if err != nil || !slices.Contains(photoTypes, contentType) {
+		return nil, fmt.Errorf("%w: %s must be a PNG, JPEG or WebP image", ErrInvalidReview, header.Filename)
+	}
+
+	return h.service.UploadPhoto(ctx, file, header)
+}
+
+func (h *RestHandler) CreateReview(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(shared.UserClaimsKey).(*shared.UserClaims)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	productID := chi.URLParam(r, "id")
+
+	if productID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var request ReviewRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	request.Title = strings.TrimSpace(request.Title)
+	request.Body = strings.TrimSpace(request.Body)
+	if err := h.validator.Struct(request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.CreateReview(r.Context(), claims.UserID, productID, request)
+	if err != nil {
+		http.Error(w, err.Error(), reviewErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RestHandler) ModerateReview(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(shared.UserClaimsKey).(*shared.UserClaims)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	reviewID := chi.URLParam(r, "id")
+
+	if reviewID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var request ModerateReviewDTO
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.validator.Struct(request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.ModerateReview(r.Context(), claims.UserID, reviewID, request)
+	if err != nil {
+		http.Error(w, err.Error(), reviewErrorStatus(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// ReplyToReview sets the seller's reply to a review. A review has one reply;
+// a second one is answered with 409.
+func (h *RestHandler) ReplyToReview(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(shared.UserClaimsKey).(*shared.UserClaims)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	reviewID := chi.URLParam(r, "id")
+
+	if reviewID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var request ReplyRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	request.Reply = strings.TrimSpace(request.Reply)
+	if err := h.validator.Struct(request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.ReplyToReview(r.Context(), claims.UserID, reviewID, request)
+	if err != nil {
+		http.Error(w, err.Error(), reviewErrorStatus(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func reviewErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrReviewNotFound), errors.Is(err, ErrProductNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrNotProductSeller):
+		return http.StatusForbidden
+	case errors.Is(err, ErrAlreadyReviewed), errors.Is(err, ErrAlreadyReplied), errors.Is(err, shared.ErrUniqueViolation):
+		return http.StatusConflict
+	case errors.Is(err, ErrInvalidReview), errors.Is(err, shared.ErrForeignKeyViolation):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,202 @@
+package reviews
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+type ReviewRepo struct {
+	db *sqlx.DB
+}
+
+func NewReviewRepo(db *sqlx.DB) *ReviewRepo {
+	return &ReviewRepo{
+		db: db,
+	}
+}
+
+// Reviews show the first name of their author only.
+const reviewColumns = `r.id, r.product_id, p.name AS product_name, r.user_id, split_part(u.full_name, ' ', 1) AS author_name,
+	r.rating, r.title, r.body, r.status, r.rejection_reason, r.is_verified_purchase, r.moderated_by, r.moderated_at,
+	r.reply, r.replied_by, r.replied_at, r.created_at, r.updated_at`
+
+const reviewFrom = `
+	FROM product_reviews r
+	JOIN products p ON p.id = r.product_id
+	JOIN users u ON u.id = r.user_id`
+
+func (r *ReviewRepo) GetReviews(ctx context.Context, filters ReviewFilters) (*ReviewPage, error) {
+	var where strings.Builder
+	var args []interface{}
+
+	if filters.ProductID != "" {
+		where.WriteString(" AND r.product_id = ?")
+		args = append(args, filters.ProductID)
+	}
+
+	if filters.Status != "" {
+		where.WriteString(" AND r.status = ?")
+		args = append(args, filters.Status)
+	}
+
+	if filters.Rating > 0 {
+		where.WriteString(" AND r.rating = ?")
+		args = append(args, filters.Rating)
+	}
+
+	page := &ReviewPage{}
+
+	countQuery := r.db.Rebind("SELECT COUNT(*)" + reviewFrom + " WHERE 1=1" + where.String())
+	if err := r.db.GetContext(ctx, &page.TotalCount, countQuery, args...); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+
+	query := "SELECT " + reviewColumns + reviewFrom + " WHERE 1=1" + where.String() + reviewOrder(filters.Sort) + " LIMIT ? OFFSET ?"
+	args = append(args, filters.Limit, (filters.Page-1)*filters.Limit)
+
+	if err := r.db.SelectContext(ctx, &page.Reviews, r.db.Rebind(query), args...); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+
+	if len(page.Reviews) == 0 {
+		return page, nil
+	}
+
+	ids := make([]string, 0, len(page.Reviews))
+	for _, review := range page.Reviews {
+		ids = append(ids, review.ID)
+	}
+
+	photoQuery, photoArgs, err := sqlx.In("SELECT review_id, url FROM review_photos WHERE review_id IN (?) ORDER BY review_id, position", ids)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.SelectContext(ctx, &page.Photos, r.db.Rebind(photoQuery), photoArgs...); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+
+	return page, nil
+}
+
+// GetRatingSummary returns the rating of an ACTIVE product with its review
+// count per star rating.
+func (r *ReviewRepo) GetRatingSummary(ctx context.Context, productID string) (*RatingSummary, error) {
+	var summary RatingSummary
+	query := "SELECT rating_average, rating_count FROM products WHERE id = $1 AND status = 'ACTIVE'"
+	if err := r.db.GetContext(ctx, &summary, query, productID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrProductNotFound
+		}
+		return nil, shared.PostgresError(err)
+	}
+
+	query = "SELECT rating, COUNT(*) AS count FROM product_reviews WHERE product_id = $1 AND status = 'APPROVED' GROUP BY rating"
+	if err := r.db.SelectContext(ctx, &summary.Ratings, query, productID); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+
+	return &summary, nil
+}
+
+// CreateReview saves a PENDING review of an ACTIVE product; a buyer reviews
+// a product once.
+func (r *ReviewRepo) CreateReview(ctx context.Context, productID string, userID string, request ReviewRequestDTO) (string, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return "", shared.PostgresError(err)
+	}
+
+	defer tx.Rollback()
+
+	// Reviews are taken for published products only
+	var active bool
+	if err := tx.GetContext(ctx, &active, "SELECT EXISTS (SELECT 1 FROM products WHERE id = $1 AND status = 'ACTIVE')", productID); err != nil {
+		return "", shared.PostgresError(err)
+	}
+	if !active {
+		return "", ErrProductNotFound
+	}
+
+	var id string
+	query := "INSERT INTO product_reviews (product_id, user_id, rating, title, body) VALUES ($1, $2, $3, $4, $5) RETURNING id"
+	if err := tx.GetContext(ctx, &id, query, productID, userID, request.Rating, request.Title, request.Body); err != nil {
+		err = shared.PostgresError(err)
+		if errors.Is(err, shared.ErrUniqueViolation) {
+			return "", ErrAlreadyReviewed
+		}
+		return "", err
+	}
+
+	for position, url := range request.Photos {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO review_photos (review_id, url, position) VALUES ($1, $2, $3)", id, url, position); err != nil {
+			return "", shared.PostgresError(err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", shared.PostgresError(err)
+	}
+	return id, nil
+}
+
+// ModerateReview sets the status of a review. The product rating follows
+// through the product_reviews_rating trigger.
+func (r *ReviewRepo) ModerateReview(ctx context.Context, id string, moderatorID string, status string, reason *string, verifiedPurchase *bool) error {
+	query := `UPDATE product_reviews SET status = $1, rejection_reason = $2, is_verified_purchase = COALESCE($3, is_verified_purchase),
+		moderated_by = $4, moderated_at = CURRENT_TIMESTAMP WHERE id = $5`
+	result, err := r.db.ExecContext(ctx, query, status, reason, verifiedPurchase, moderatorID, id)
+	if err != nil {
+		return shared.PostgresError(err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrReviewNotFound
+	}
+	return nil
+}
+
+// ReplyToReview sets the one reply of an APPROVED review. Only the seller of
+// the product replies, and only once.
+func (r *ReviewRepo) ReplyToReview(ctx context.Context, id string, sellerID string, reply string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return shared.PostgresError(err)
+	}
+
+	defer tx.Rollback()
+
+	var review struct {
+		Status   string  `db:"status"`
+		SellerID string  `db:"seller_id"`
+		Reply    *string `db:"reply"`
+	}
+	query := "SELECT r.status, p.seller_id, r.reply FROM product_reviews r JOIN products p ON p.id = r.product_id WHERE r.id = $1 FOR UPDATE OF r"
+	if err := tx.GetContext(ctx, &review, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrReviewNotFound
+		}
+		return shared.PostgresError(err)
+	}
+
+	if review.SellerID != sellerID {
+		return ErrNotProductSeller
+	}
+	if review.Status != StatusApproved {
+		return fmt.Errorf("%w: only approved reviews can be replied to", ErrInvalidReview)
+	}
+	if review.Reply != nil {
+		return ErrAlreadyReplied
+	}
+
+	query = "UPDATE product_reviews SET reply = $1, replied_by = $2, replied_at = CURRENT_TIMESTAMP WHERE id = $3"
+	if _, err := tx.ExecContext(ctx, query, reply, sellerID, id); err != nil {
+		return shared.PostgresError(err)
+	}
+
+	return tx.Commit()
+}
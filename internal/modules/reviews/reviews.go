@@ -0,0 +1,124 @@
+package reviews
+
+import (
+	"fmt"
+	"strings"
+)
+
+// photoBucket is the key prefix review photos are uploaded under.
+const photoBucket = "reviews"
+
+// A review has up to maxReviewPhotos photos of up to maxPhotoSize each.
+const (
+	maxReviewPhotos = 5
+	maxPhotoSize    = 10 << 20
+)
+
+// photoTypes are the content types accepted for review photos.
+var photoTypes = []string{"image/png", "image/jpeg", "image/webp"}
+
+// Review statuses, see migrations/018_reviews.sql. Only APPROVED reviews are
+// public and counted in the product rating.
+const (
+	StatusPending  = "PENDING"
+	StatusApproved = "APPROVED"
+	StatusRejected = "REJECTED"
+)
+
+var ReviewStatuses = []string{StatusPending, StatusApproved, StatusRejected}
+
+// Moderation actions.
+const (
+	ActionApprove = "APPROVE"
+	ActionReject  = "REJECT"
+)
+
+// Review sort orders.
+const (
+	SortNewest     = "newest"
+	SortRatingDesc = "rating_desc"
+	SortRatingAsc  = "rating_asc"
+)
+
+var ReviewSortOrders = []string{SortNewest, SortRatingDesc, SortRatingAsc}
+
+// reviewOrder is the ORDER BY clause of a sort order, newest first within a
+// rating.
+func reviewOrder(sort string) string {
+	switch sort {
+	case SortRatingDesc:
+		return " ORDER BY r.rating DESC, r.created_at DESC, r.id DESC"
+	case SortRatingAsc:
+		return " ORDER BY r.rating ASC, r.created_at DESC, r.id DESC"
+	default:
+		return " ORDER BY r.created_at DESC, r.id DESC"
+	}
+}
+
+// toReviewResponses attaches the photos to their reviews. The moderation
+// fields are left out when public.
+func toReviewResponses(reviews []Review, photos []ReviewPhoto, public bool) []ReviewResponse {
+	byReview := make(map[string][]string, len(reviews))
+	for _, photo := range photos {
+		byReview[photo.ReviewID] = append(byReview[photo.ReviewID], photo.URL)
+	}
+
+	responses := make([]ReviewResponse, 0, len(reviews))
+	for _, review := range reviews {
+		response := ReviewResponse{
+			ID:               review.ID,
+			ProductID:        review.ProductID,
+			AuthorName:       review.AuthorName,
+			Rating:           review.Rating,
+			Title:            review.Title,
+			Body:             review.Body,
+			Photos:           byReview[review.ID],
+			VerifiedPurchase: review.IsVerifiedPurchase,
+			CreatedAt:        review.CreatedAt,
+		}
+		if response.Photos == nil {
+			response.Photos = []string{}
+		}
+		if review.Reply != nil && review.RepliedAt != nil {
+			response.Reply = &ReviewReply{Body: *review.Reply, RepliedAt: *review.RepliedAt}
+		}
+		if !public {
+			response.ProductName = review.ProductName
+			response.Status = review.Status
+			response.RejectionReason = review.RejectionReason
+			response.ModeratedAt = review.ModeratedAt
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
+
+// toRatingSummary fills in the star ratings without reviews, from 5 down to 1.
+func toRatingSummary(summary *RatingSummary) *RatingSummaryDTO {
+	counts := make(map[int]int, len(summary.Ratings))
+	for _, rating := range summary.Ratings {
+		counts[rating.Rating] = rating.Count
+	}
+
+	response := &RatingSummaryDTO{
+		Average: summary.Average,
+		Count:   summary.Count,
+		Ratings: make([]RatingCountDTO, 0, 5),
+	}
+	for rating := 5; rating >= 1; rating-- {
+		response.Ratings = append(response.Ratings, RatingCountDTO{Rating: rating, Count: counts[rating]})
+	}
+	return response
+}
+
+// validatePhotos checks that every photo is a file uploaded through the
+// review photo upload, whose URLs start with prefix.
+func validatePhotos(photos []string, prefix string) error {
+	for _, photo := range photos {
+		name, ok := strings.CutPrefix(photo, prefix)
+		if !ok || name == "" || strings.ContainsAny(name, "/?#\\") {
+			return fmt.Errorf("%w: photo %q was not uploaded through /upload-review-photo", ErrInvalidReview, photo)
+		}
+	}
+	return nil
+}
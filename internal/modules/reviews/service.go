@@ -0,0 +1,119 @@
+package reviews
+
+import (
+	"context"
+	"fmt"
+	"mime/multipart"
+
+	"github.com/smart-safety-hub/backend/internal/modules/aws"
+	"go.uber.org/zap"
+)
+
+type ReviewService struct {
+	logger   *zap.Logger
+	repo     *ReviewRepo
+	uploader *aws.UploadService
+}
+
+func NewReviewService(logger *zap.Logger, repo *ReviewRepo, uploader *aws.UploadService) *ReviewService {
+	return &ReviewService{
+		logger:   logger,
+		repo:     repo,
+		uploader: uploader,
+	}
+}
+
+// GetProductReviews is the public listing: the APPROVED reviews of an ACTIVE
+// product with its rating summary.
+func (b *ReviewService) GetProductReviews(ctx context.Context, filters ReviewFilters) (*ReviewListResponse, error) {
+	summary, err := b.repo.GetRatingSummary(ctx, filters.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	filters.Status = StatusApproved
+	page, err := b.repo.GetReviews(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	return &ReviewListResponse{
+		Reviews:    toReviewResponses(page.Reviews, page.Photos, true),
+		Summary:    toRatingSummary(summary),
+		TotalCount: page.TotalCount,
+		Page:       filters.Page,
+		Limit:      filters.Limit,
+	}, nil
+}
+
+// GetReviews is the moderation listing, of any status.
+func (b *ReviewService) GetReviews(ctx context.Context, filters ReviewFilters) (*ReviewListResponse, error) {
+	page, err := b.repo.GetReviews(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	return &ReviewListResponse{
+		Reviews:    toReviewResponses(page.Reviews, page.Photos, false),
+		TotalCount: page.TotalCount,
+		Page:       filters.Page,
+		Limit:      filters.Limit,
+	}, nil
+}
+
+// UploadPhoto stores a review photo and returns its URL for
+// ReviewRequestDTO.Photos.
+func (b *ReviewService) UploadPhoto(ctx context.Context, file multipart.File, header *multipart.FileHeader) (*UploadedPhotoDTO, error) {
+	upload, err := b.uploader.UploadImage(ctx, file, header, photoBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UploadedPhotoDTO{URL: upload.URL}, nil
+}
+
+func (b *ReviewService) CreateReview(ctx context.Context, userID string, productID string, request ReviewRequestDTO) (*GenericResponseDTO, error) {
+	if err := validatePhotos(request.Photos, b.uploader.URLPrefix(photoBucket)); err != nil {
+		return nil, err
+	}
+
+	id, err := b.repo.CreateReview(ctx, productID, userID, request)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return &GenericResponseDTO{
+		ID:      &id,
+		Status:  "success",
+		Message: "Review Submitted For Moderation",
+	}, nil
+}
+
+func (b *ReviewService) ModerateReview(ctx context.Context, moderatorID string, id string, request ModerateReviewDTO) (*GenericResponseDTO, error) {
+	status, message, reason := StatusApproved, "Review Approved Successfully", (*string)(nil)
+	if request.Action == ActionReject {
+		status, message, reason = StatusRejected, "Review Rejected Successfully", &request.Reason
+	}
+
+	if err := b.repo.ModerateReview(ctx, id, moderatorID, status, reason, request.VerifiedPurchase); err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return &GenericResponseDTO{
+		ID:      &id,
+		Status:  "success",
+		Message: message,
+	}, nil
+}
+
+func (b *ReviewService) ReplyToReview(ctx context.Context, sellerID string, id string, request ReplyRequestDTO) (*GenericResponseDTO, error) {
+	if err := b.repo.ReplyToReview(ctx, id, sellerID, request.Reply); err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return &GenericResponseDTO{
+		ID:      &id,
+		Status:  "success",
+		Message: "Reply Saved Successfully",
+	}, nil
+}
@@ -0,0 +1,39 @@
+package tax
+
+import (
+	"errors"
+	"time"
+
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+var (
+	ErrTaxClassNotFound = errors.New("tax class not found")
+	ErrInvalidTaxClass  = errors.New("invalid tax class")
+	ErrProductNotFound  = errors.New("product not found")
+	ErrCategoryNotFound = errors.New("category not found")
+)
+
+type TaxClass struct {
+	ID          string          `db:"id"`
+	Name        string          `db:"name"`
+	HSNCode     string          `db:"hsn_code"`
+	Description *string         `db:"description"`
+	Rate        *shared.Decimal `db:"rate"`
+	CreatedAt   time.Time       `db:"created_at"`
+	UpdatedAt   time.Time       `db:"updated_at"`
+}
+
+type TaxRate struct {
+	ID            string         `db:"id"`
+	TaxClassID    string         `db:"tax_class_id"`
+	Rate          shared.Decimal `db:"rate"`
+	EffectiveFrom time.Time      `db:"effective_from"`
+	CreatedAt     time.Time      `db:"created_at"`
+}
+
+// TaxClassDetail is a tax class with its rate history.
+type TaxClassDetail struct {
+	TaxClass
+	Rates []TaxRate
+}
@@ -0,0 +1,47 @@
+package tax
+
+import (
+	"time"
+
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+// TaxClassRequestDTO creates or replaces a tax class. Rates are total GST
+// percentages, each in force from its effective_from (YYYY-MM-DD) until the
+// next one.
+type TaxClassRequestDTO struct {
+	Name        string       `json:"name" validate:"required,max=100"`
+	HSNCode     string       `json:"hsn_code" validate:"required,number"`
+	Description *string      `json:"description"`
+	Rates       []TaxRateDTO `json:"rates" validate:"required,min=1,dive"`
+}
+
+type TaxRateDTO struct {
+	Rate          shared.Decimal `json:"rate" validate:"gte=0"`
+	EffectiveFrom string         `json:"effective_from" validate:"required,datetime=2006-01-02"`
+}
+
+// AssignTaxClassDTO sets the tax class of a product or category; null
+// removes it, so products fall back to their category's.
+type AssignTaxClassDTO struct {
+	TaxClassID *string `json:"tax_class_id" validate:"omitempty,uuid"`
+}
+
+// TaxClassResponse carries the rate in force today, null before the first
+// rate, and the full history when a single class is requested.
+type TaxClassResponse struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	HSNCode     string          `json:"hsn_code"`
+	Description *string         `json:"description"`
+	Rate        *shared.Decimal `json:"rate"`
+	Rates       []TaxRateDTO    `json:"rates,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+type GenericResponseDTO struct {
+	ID      *string `json:"id,omitempty"`
+	Status  string  `json:"success"`
+	Message string  `json:"message"`
+}
@@ -0,0 +1,183 @@
+package tax
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+type RestHandler struct {
+	service   *TaxService
+	validator *validator.Validate
+}
+
+func NewRestHandler(service *TaxService, validator *validator.Validate) *RestHandler {
+	return &RestHandler{
+		service:   service,
+		validator: validator,
+	}
+}
+
+func (h *RestHandler) GetTaxClasses(w http.ResponseWriter, r *http.Request) {
+	response, err := h.service.GetTaxClasses(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), taxErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RestHandler) GetTaxClass(w http.ResponseWriter, r *http.Request) {
+	taxClassID := chi.URLParam(r, "id")
+
+	if taxClassID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.GetTaxClass(r.Context(), taxClassID)
+	if err != nil {
+		http.Error(w, err.Error(), taxErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RestHandler) CreateTaxClass(w http.ResponseWriter, r *http.Request) {
+	var request TaxClassRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.validator.Struct(request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.CreateTaxClass(r.Context(), request)
+	if err != nil {
+		http.Error(w, err.Error(), taxErrorStatus(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// UpdateTaxClass replaces a tax class, including its rate history.
+func (h *RestHandler) UpdateTaxClass(w http.ResponseWriter, r *http.Request) {
+	taxClassID := chi.URLParam(r, "id")
+
+	if taxClassID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var request TaxClassRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.validator.Struct(request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.UpdateTaxClass(r.Context(), taxClassID, request)
+	if err != nil {
+		http.Error(w, err.Error(), taxErrorStatus(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RestHandler) DeleteTaxClass(w http.ResponseWriter, r *http.Request) {
+	taxClassID := chi.URLParam(r, "id")
+
+	if taxClassID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.DeleteTaxClass(r.Context(), taxClassID)
+	if err != nil {
+		http.Error(w, err.Error(), taxErrorStatus(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (h *RestHandler) AssignProductTaxClass(w http.ResponseWriter, r *http.Request) {
+	h.assignTaxClass(w, r, h.service.AssignProductTaxClass)
+}
+
+// AssignCategoryTaxClass sets the default tax class of the products of a
+// category and its subcategories.
+func (h *RestHandler) AssignCategoryTaxClass(w http.ResponseWriter, r *http.Request) {
+	h.assignTaxClass(w, r, h.service.AssignCategoryTaxClass)
+}
+
+func (h *RestHandler) assignTaxClass(w http.ResponseWriter, r *http.Request, assign func(ctx context.Context, id string, request AssignTaxClassDTO) (*GenericResponseDTO, error)) {
+	id := chi.URLParam(r, "id")
+
+	if id == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var request AssignTaxClassDTO
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.validator.Struct(request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := assign(r.Context(), id, request)
+	if err != nil {
+		http.Error(w, err.Error(), taxErrorStatus(err))
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func taxErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrTaxClassNotFound), errors.Is(err, ErrProductNotFound), errors.Is(err, ErrCategoryNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidTaxClass), errors.Is(err, shared.ErrForeignKeyViolation):
+		return http.StatusBadRequest
+	case errors.Is(err, shared.ErrUniqueViolation):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
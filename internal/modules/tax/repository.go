@@ -0,0 +1,149 @@
+package tax
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+type TaxRepo struct {
+	db *sqlx.DB
+}
+
+func NewTaxRepo(db *sqlx.DB) *TaxRepo {
+	return &TaxRepo{
+		db: db,
+	}
+}
+
+func (r *TaxRepo) GetTaxClasses(ctx context.Context) ([]TaxClass, error) {
+	var classes []TaxClass
+	query := `SELECT id, name, hsn_code, description, tax_rate(id, CURRENT_DATE) AS rate, created_at, updated_at
+	FROM tax_classes ORDER BY name`
+	if err := r.db.SelectContext(ctx, &classes, query); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+	return classes, nil
+}
+
+func (r *TaxRepo) GetTaxClass(ctx context.Context, id string) (*TaxClassDetail, error) {
+	var detail TaxClassDetail
+	query := `SELECT id, name, hsn_code, description, tax_rate(id, CURRENT_DATE) AS rate, created_at, updated_at
+	FROM tax_classes WHERE id = $1`
+	if err := r.db.GetContext(ctx, &detail.TaxClass, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTaxClassNotFound
+		}
+		return nil, shared.PostgresError(err)
+	}
+
+	query = "SELECT * FROM tax_rates WHERE tax_class_id = $1 ORDER BY effective_from"
+	if err := r.db.SelectContext(ctx, &detail.Rates, query, id); err != nil {
+		return nil, shared.PostgresError(err)
+	}
+
+	return &detail, nil
+}
+
+func (r *TaxRepo) SaveTaxClass(ctx context.Context, request TaxClassRequestDTO) (string, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return "", shared.PostgresError(err)
+	}
+
+	defer tx.Rollback()
+
+	var id string
+	query := "INSERT INTO tax_classes (name, hsn_code, description) VALUES ($1, $2, $3) RETURNING id"
+	if err := tx.GetContext(ctx, &id, query, request.Name, request.HSNCode, request.Description); err != nil {
+		return "", shared.PostgresError(err)
+	}
+
+	if err := replaceTaxRates(ctx, tx, id, request.Rates); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", shared.PostgresError(err)
+	}
+
+	return id, nil
+}
+
+func (r *TaxRepo) UpdateTaxClass(ctx context.Context, id string, request TaxClassRequestDTO) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return shared.PostgresError(err)
+	}
+
+	defer tx.Rollback()
+
+	query := "UPDATE tax_classes SET name = $2, hsn_code = $3, description = $4 WHERE id = $1"
+	result, err := tx.ExecContext(ctx, query, id, request.Name, request.HSNCode, request.Description)
+	if err != nil {
+		return shared.PostgresError(err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrTaxClassNotFound
+	}
+
+	if err := replaceTaxRates(ctx, tx, id, request.Rates); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// replaceTaxRates swaps the rate history of a tax class for rates.
+func replaceTaxRates(ctx context.Context, tx *sqlx.Tx, id string, rates []TaxRateDTO) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM tax_rates WHERE tax_class_id = $1", id); err != nil {
+		return shared.PostgresError(err)
+	}
+
+	query := "INSERT INTO tax_rates (tax_class_id, rate, effective_from) VALUES ($1, $2, $3)"
+	for _, rate := range rates {
+		if _, err := tx.ExecContext(ctx, query, id, rate.Rate, rate.EffectiveFrom); err != nil {
+			return shared.PostgresError(err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteTaxClass removes a tax class; products and categories that used it
+// are left without one.
+func (r *TaxRepo) DeleteTaxClass(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM tax_classes WHERE id = $1", id)
+	if err != nil {
+		return shared.PostgresError(err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrTaxClassNotFound
+	}
+	return nil
+}
+
+func (r *TaxRepo) AssignProductTaxClass(ctx context.Context, productID string, taxClassID *string) error {
+	result, err := r.db.ExecContext(ctx, "UPDATE products SET tax_class_id = $2 WHERE id = $1", productID, taxClassID)
+	if err != nil {
+		return shared.PostgresError(err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrProductNotFound
+	}
+	return nil
+}
+
+func (r *TaxRepo) AssignCategoryTaxClass(ctx context.Context, categoryID string, taxClassID *string) error {
+	result, err := r.db.ExecContext(ctx, "UPDATE categories SET tax_class_id = $2 WHERE id = $1", categoryID, taxClassID)
+	if err != nil {
+		return shared.PostgresError(err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrCategoryNotFound
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package tax
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type TaxService struct {
+	logger *zap.Logger
+	repo   *TaxRepo
+}
+
+func NewTaxService(logger *zap.Logger, repo *TaxRepo) *TaxService {
+	return &TaxService{
+		logger: logger,
+		repo:   repo,
+	}
+}
+
+func (b *TaxService) GetTaxClasses(ctx context.Context) ([]TaxClassResponse, error) {
+	resp, err := b.repo.GetTaxClasses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	classes := make([]TaxClassResponse, 0, len(resp))
+	for _, data := range resp {
+		classes = append(classes, toTaxClassResponse(data))
+	}
+	return classes, nil
+}
+
+func (b *TaxService) GetTaxClass(ctx context.Context, id string) (*TaxClassResponse, error) {
+	detail, err := b.repo.GetTaxClass(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while getting data from DB: %w", err)
+	}
+
+	response := toTaxClassResponse(detail.TaxClass)
+	response.Rates = make([]TaxRateDTO, 0, len(detail.Rates))
+	for _, rate := range detail.Rates {
+		response.Rates = append(response.Rates, TaxRateDTO{
+			Rate:          rate.Rate,
+			EffectiveFrom: rate.EffectiveFrom.Format(time.DateOnly),
+		})
+	}
+
+	return &response, nil
+}
+
+func (b *TaxService) CreateTaxClass(ctx context.Context, request TaxClassRequestDTO) (*GenericResponseDTO, error) {
+	if err := validateTaxClass(request); err != nil {
+		return nil, err
+	}
+
+	id, err := b.repo.SaveTaxClass(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return &GenericResponseDTO{
+		ID:      &id,
+		Status:  "success",
+		Message: "Tax Class Created Successfully",
+	}, nil
+}
+
+func (b *TaxService) UpdateTaxClass(ctx context.Context, id string, request TaxClassRequestDTO) (*GenericResponseDTO, error) {
+	if err := validateTaxClass(request); err != nil {
+		return nil, err
+	}
+
+	if err := b.repo.UpdateTaxClass(ctx, id, request); err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return &GenericResponseDTO{
+		ID:      &id,
+		Status:  "success",
+		Message: "Tax Class Updated Successfully",
+	}, nil
+}
+
+func (b *TaxService) DeleteTaxClass(ctx context.Context, id string) (*GenericResponseDTO, error) {
+	if err := b.repo.DeleteTaxClass(ctx, id); err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return &GenericResponseDTO{
+		ID:      &id,
+		Status:  "success",
+		Message: "Tax Class Deleted Successfully",
+	}, nil
+}
+
+func (b *TaxService) AssignProductTaxClass(ctx context.Context, productID string, request AssignTaxClassDTO) (*GenericResponseDTO, error) {
+	if err := b.repo.AssignProductTaxClass(ctx, productID, request.TaxClassID); err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return &GenericResponseDTO{
+		ID:      &productID,
+		Status:  "success",
+		Message: "Product Tax Class Saved Successfully",
+	}, nil
+}
+
+func (b *TaxService) AssignCategoryTaxClass(ctx context.Context, categoryID string, request AssignTaxClassDTO) (*GenericResponseDTO, error) {
+	if err := b.repo.AssignCategoryTaxClass(ctx, categoryID, request.TaxClassID); err != nil {
+		return nil, fmt.Errorf("Error came while saving it to DB: %w", err)
+	}
+
+	return &GenericResponseDTO{
+		ID:      &categoryID,
+		Status:  "success",
+		Message: "Category Tax Class Saved Successfully",
+	}, nil
+}
+
+func toTaxClassResponse(class TaxClass) TaxClassResponse {
+	return TaxClassResponse{
+		ID:          class.ID,
+		Name:        class.Name,
+		HSNCode:     class.HSNCode,
+		Description: class.Description,
+		Rate:        class.Rate,
+		CreatedAt:   class.CreatedAt,
+		UpdatedAt:   class.UpdatedAt,
+	}
+}
@@ -0,0 +1,29 @@
+package tax
+
+import (
+	"fmt"
+
+	"github.com/smart-safety-hub/backend/shared"
+)
+
+// validateTaxClass checks what the struct tags cannot: an HSN code of 4, 6 or
+// 8 digits, rates between 0 and 100 with at most two decimals, and one rate
+// per effective date.
+func validateTaxClass(request TaxClassRequestDTO) error {
+	if n := len(request.HSNCode); n != 4 && n != 6 && n != 8 {
+		return fmt.Errorf("%w: hsn_code must have 4, 6 or 8 digits", ErrInvalidTaxClass)
+	}
+
+	dates := make(map[string]bool, len(request.Rates))
+	for _, rate := range request.Rates {
+		if rate.Rate < 0 || rate.Rate > shared.NewDecimal(100) || rate.Rate.Round(2) != rate.Rate {
+			return fmt.Errorf("%w: rate %s must be between 0 and 100 with at most two decimals", ErrInvalidTaxClass, rate.Rate)
+		}
+		if dates[rate.EffectiveFrom] {
+			return fmt.Errorf("%w: more than one rate from %s", ErrInvalidTaxClass, rate.EffectiveFrom)
+		}
+		dates[rate.EffectiveFrom] = true
+	}
+
+	return nil
+}
@@ -15,6 +15,7 @@ type User struct {
 	Password    string    `db:"password"`
 	PhoneNumber string    `db:"phone_number"`
 	CompanyID   *string   `db:"company_id"`
+	StateCode   *string   `db:"state_code"`
 	CreatedAt   time.Time `db:"created_at"`
 	UpdatedAt   time.Time `db:"updated_at"`
 }
@@ -14,6 +14,9 @@ type RegisterDTO struct {
 	Password    string `json:"password" validate:"required,min=12,max=72"`
 	PhoneNumber string `json:"phone_number" validate:"required,e164"`
 	UserType    string `json:"user_type" validate:"required"`
+	// StateCode is the two-digit GST state code used to tax the user's
+	// purchases, or the products they sell.
+	StateCode *string `json:"state_code" validate:"omitempty,len=2,number"`
 }
 
 type ForgotPasswordDTO struct {
@@ -52,8 +52,8 @@ func (r *UserRepo) ExecuteTransaction(ctx context.Context, fn func(repo *UserRep
 
 func (r *UserRepo) SaveUser(ctx context.Context, u *User) (*User, error) {
 	var user User
-	query := "INSERT INTO users(full_name, email, password, phone_number) VALUES ($1,$2,$3,$4) RETURNING id, full_name, email, phone_number, created_at, updated_at"
-	if err := r.db.GetContext(ctx, &user, query, u.FullName, u.Email, u.Password, u.PhoneNumber); err != nil {
+	query := "INSERT INTO users(full_name, email, password, phone_number, state_code) VALUES ($1,$2,$3,$4,$5) RETURNING id, full_name, email, phone_number, state_code, created_at, updated_at"
+	if err := r.db.GetContext(ctx, &user, query, u.FullName, u.Email, u.Password, u.PhoneNumber, u.StateCode); err != nil {
 		return nil, shared.PostgresError(err)
 	}
 	return &user, nil
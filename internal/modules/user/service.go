@@ -30,6 +30,13 @@ func (u *UserService) Register(ctx context.Context, req *RegisterDTO) (*Response
 		Email:       req.Email,
 		Password:    req.Password,
 		PhoneNumber: req.PhoneNumber,
+		StateCode:   req.StateCode,
+	}
+
+	if req.StateCode != nil {
+		if err := shared.ValidateStateCode(*req.StateCode); err != nil {
+			return nil, err
+		}
 	}
 
 	if err := user.HashPassword(); err != nil {
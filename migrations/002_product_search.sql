@@ -0,0 +1,92 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE products ADD COLUMN search_vector tsvector;
+
+-- Weighted search document for a product:
+-- A: product name and variant SKUs, B: brand and category names,
+-- C: attribute values, D: description
+CREATE OR REPLACE FUNCTION product_search_document(p_id UUID, p_name TEXT, p_description TEXT, p_brand_id UUID, p_category_id UUID)
+RETURNS tsvector AS $$
+    SELECT
+        setweight(to_tsvector('english', COALESCE(p_name, '')), 'A') ||
+        setweight(to_tsvector('simple', COALESCE((SELECT string_agg(sku, ' ') FROM product_variants WHERE product_id = p_id), '')), 'A') ||
+        setweight(to_tsvector('simple', COALESCE((SELECT name FROM brands WHERE id = p_brand_id), '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE((SELECT name FROM categories WHERE id = p_category_id), '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE((SELECT string_agg(attribute_value, ' ') FROM products_attributes WHERE product_id = p_id), '')), 'C') ||
+        setweight(to_tsvector('english', COALESCE(p_description, '')), 'D');
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION refresh_product_search_vector(p_id UUID)
+RETURNS void AS $$
+    UPDATE products
+    SET search_vector = product_search_document(id, name, description, brand_id, category_id)
+    WHERE id = p_id;
+$$ LANGUAGE sql;
+
+-- products: recompute on the row itself
+CREATE OR REPLACE FUNCTION products_search_vector_trigger()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector = product_search_document(NEW.id, NEW.name, NEW.description, NEW.brand_id, NEW.category_id);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER products_search_vector BEFORE INSERT OR UPDATE OF name, description, brand_id, category_id ON products
+FOR EACH ROW EXECUTE PROCEDURE products_search_vector_trigger();
+
+-- products_attributes and product_variants: refresh the owning product
+CREATE OR REPLACE FUNCTION product_child_search_vector_trigger()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        PERFORM refresh_product_search_vector(OLD.product_id);
+        RETURN OLD;
+    END IF;
+
+    PERFORM refresh_product_search_vector(NEW.product_id);
+    IF TG_OP = 'UPDATE' AND OLD.product_id IS DISTINCT FROM NEW.product_id THEN
+        PERFORM refresh_product_search_vector(OLD.product_id);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER products_attributes_search_vector AFTER INSERT OR UPDATE OR DELETE ON products_attributes
+FOR EACH ROW EXECUTE PROCEDURE product_child_search_vector_trigger();
+
+CREATE TRIGGER product_variants_search_vector AFTER INSERT OR UPDATE OF sku, product_id OR DELETE ON product_variants
+FOR EACH ROW EXECUTE PROCEDURE product_child_search_vector_trigger();
+
+-- brands and categories: a rename refreshes every product that references it
+CREATE OR REPLACE FUNCTION brands_search_vector_trigger()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE products
+    SET search_vector = product_search_document(id, name, description, brand_id, category_id)
+    WHERE brand_id = NEW.id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER brands_search_vector AFTER UPDATE OF name ON brands
+FOR EACH ROW EXECUTE PROCEDURE brands_search_vector_trigger();
+
+CREATE OR REPLACE FUNCTION categories_search_vector_trigger()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE products
+    SET search_vector = product_search_document(id, name, description, brand_id, category_id)
+    WHERE category_id = NEW.id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER categories_search_vector AFTER UPDATE OF name ON categories
+FOR EACH ROW EXECUTE PROCEDURE categories_search_vector_trigger();
+
+-- Backfill existing rows
+UPDATE products SET search_vector = product_search_document(id, name, description, brand_id, category_id);
+
+CREATE INDEX idx_products_search_vector ON products USING GIN (search_vector);
+CREATE INDEX idx_products_name_trgm ON products USING GIN (name gin_trgm_ops);
@@ -0,0 +1,53 @@
+-- Materialized path of category ids from the root, e.g. '/<root-id>/<child-id>/'.
+-- The trailing slash keeps prefix matches from crossing sibling boundaries.
+ALTER TABLE categories ADD COLUMN path TEXT;
+
+-- Derive level and path from the parent on insert and whenever parent_id is set
+CREATE OR REPLACE FUNCTION categories_path_trigger()
+RETURNS TRIGGER AS $$
+DECLARE
+    parent_path TEXT;
+    parent_level INT;
+BEGIN
+    IF NEW.parent_id IS NULL THEN
+        NEW.path = '/' || NEW.id || '/';
+        NEW.level = 0;
+    ELSE
+        SELECT path, level INTO parent_path, parent_level FROM categories WHERE id = NEW.parent_id;
+        NEW.path = parent_path || NEW.id || '/';
+        NEW.level = parent_level + 1;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER categories_path BEFORE INSERT OR UPDATE OF parent_id ON categories
+FOR EACH ROW EXECUTE PROCEDURE categories_path_trigger();
+
+-- Re-root the subtree when a category moves
+CREATE OR REPLACE FUNCTION categories_subtree_path_trigger()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.path IS DISTINCT FROM OLD.path THEN
+        UPDATE categories
+        SET path = NEW.path || substring(path FROM length(OLD.path) + 1),
+            level = level + NEW.level - OLD.level
+        WHERE path LIKE OLD.path || '_%';
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER categories_subtree_path AFTER UPDATE OF parent_id ON categories
+FOR EACH ROW EXECUTE PROCEDURE categories_subtree_path_trigger();
+
+-- Backfill existing rows
+WITH RECURSIVE tree AS (
+    SELECT id, '/' || id || '/' AS path, 0 AS level FROM categories WHERE parent_id IS NULL
+    UNION ALL
+    SELECT c.id, t.path || c.id || '/', t.level + 1 FROM categories c JOIN tree t ON c.parent_id = t.id
+)
+UPDATE categories c SET path = tree.path, level = tree.level FROM tree WHERE c.id = tree.id;
+
+CREATE INDEX idx_categories_path ON categories(path text_pattern_ops);
+CREATE INDEX idx_categories_parent_id ON categories(parent_id);
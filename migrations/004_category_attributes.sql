@@ -0,0 +1,21 @@
+-- Typed attribute definitions attached to categories. A category's effective
+-- schema is its own definitions plus those of its ancestors; the nearest
+-- definition of a key wins.
+CREATE TYPE attribute_type_enum AS ENUM('ENUM', 'NUMBER', 'BOOLEAN', 'TEXT');
+
+CREATE TABLE category_attributes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    category_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    attribute_key VARCHAR(100) NOT NULL,
+    label VARCHAR(255) NOT NULL,
+    type attribute_type_enum NOT NULL,
+    unit VARCHAR(50),
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    allowed_values TEXT[] NOT NULL DEFAULT '{}',
+    display_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (category_id, attribute_key)
+);
+
+CREATE INDEX idx_category_attributes_category_id ON category_attributes(category_id);
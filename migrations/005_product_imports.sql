@@ -0,0 +1,21 @@
+-- Bulk product imports. Each upload is tracked as a job with a per-row report.
+CREATE TYPE import_status_enum AS ENUM('PENDING', 'RUNNING', 'COMPLETED', 'FAILED');
+
+CREATE TABLE product_imports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    format VARCHAR(10) NOT NULL,
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    status import_status_enum NOT NULL DEFAULT 'PENDING',
+    total_rows INT NOT NULL DEFAULT 0,
+    processed_rows INT NOT NULL DEFAULT 0,
+    succeeded_rows INT NOT NULL DEFAULT 0,
+    failed_rows INT NOT NULL DEFAULT 0,
+    report JSONB NOT NULL DEFAULT '[]'::jsonb,
+    error TEXT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX idx_product_imports_created_by ON product_imports(created_by);
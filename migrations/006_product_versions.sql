@@ -0,0 +1,16 @@
+-- Versioned snapshots of the full product aggregate (product, attributes,
+-- options, variants, media and SEO), one per mutation. diff holds the changes
+-- against the previous version. History starts with the first change made
+-- after this migration.
+CREATE TABLE product_versions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    version INT NOT NULL,
+    change_type VARCHAR(50) NOT NULL,
+    changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    restored_from INT,
+    snapshot JSONB NOT NULL,
+    diff JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (product_id, version)
+);
@@ -0,0 +1,22 @@
+-- Editorial workflow: sellers submit products for review, reviewers approve,
+-- reject or schedule them, and a scheduler publishes and unpublishes them.
+ALTER TYPE status_enum ADD VALUE IF NOT EXISTS 'PENDING_REVIEW';
+ALTER TYPE status_enum ADD VALUE IF NOT EXISTS 'REJECTED';
+ALTER TYPE status_enum ADD VALUE IF NOT EXISTS 'SCHEDULED';
+
+ALTER TABLE products
+    ADD COLUMN rejection_reason TEXT,
+    ADD COLUMN publish_at TIMESTAMPTZ,
+    ADD COLUMN unpublish_at TIMESTAMPTZ,
+    ADD COLUMN reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN reviewed_at TIMESTAMP;
+
+CREATE INDEX idx_products_publish_at ON products(publish_at) WHERE publish_at IS NOT NULL;
+CREATE INDEX idx_products_unpublish_at ON products(unpublish_at) WHERE unpublish_at IS NOT NULL;
+
+-- Approving, rejecting and publishing is reserved to reviewers (the admin role)
+INSERT INTO permissions (id, name, description) VALUES
+(uuid_generate_v4(), 'catalog:review', 'Approve, reject and publish products');
+
+INSERT INTO roles_permissions (role_id, permission_id)
+SELECT '37e13c1b-cfb5-44ad-a2ac-613d8e9650b4', id FROM permissions WHERE name = 'catalog:review';
@@ -0,0 +1,55 @@
+-- Previous slugs of brands, categories and products, so old links can be
+-- redirected to the current slug. entity_type is the table name.
+CREATE TABLE slug_history (
+    entity_type TEXT NOT NULL CHECK (entity_type IN ('brands', 'categories', 'products')),
+    slug TEXT NOT NULL,
+    entity_id UUID NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (entity_type, slug)
+);
+
+CREATE INDEX idx_slug_history_entity ON slug_history(entity_type, entity_id);
+
+-- Keep the old slug when it changes. A slug in use again, by the same row or
+-- another one, is dropped from the history: current slugs always win.
+CREATE OR REPLACE FUNCTION slug_history_trigger()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND NEW.slug IS DISTINCT FROM OLD.slug THEN
+        INSERT INTO slug_history (entity_type, slug, entity_id)
+        VALUES (TG_TABLE_NAME, OLD.slug, OLD.id)
+        ON CONFLICT (entity_type, slug) DO UPDATE
+        SET entity_id = EXCLUDED.entity_id, created_at = CURRENT_TIMESTAMP;
+    END IF;
+
+    DELETE FROM slug_history WHERE entity_type = TG_TABLE_NAME AND slug = NEW.slug;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER brands_slug_history AFTER INSERT OR UPDATE OF slug ON brands
+FOR EACH ROW EXECUTE PROCEDURE slug_history_trigger();
+
+CREATE TRIGGER categories_slug_history AFTER INSERT OR UPDATE OF slug ON categories
+FOR EACH ROW EXECUTE PROCEDURE slug_history_trigger();
+
+CREATE TRIGGER products_slug_history AFTER INSERT OR UPDATE OF slug ON products
+FOR EACH ROW EXECUTE PROCEDURE slug_history_trigger();
+
+-- Redirects are only meaningful while the entity exists
+CREATE OR REPLACE FUNCTION slug_history_cleanup_trigger()
+RETURNS TRIGGER AS $$
+BEGIN
+    DELETE FROM slug_history WHERE entity_type = TG_TABLE_NAME AND entity_id = OLD.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER brands_slug_history_cleanup AFTER DELETE ON brands
+FOR EACH ROW EXECUTE PROCEDURE slug_history_cleanup_trigger();
+
+CREATE TRIGGER categories_slug_history_cleanup AFTER DELETE ON categories
+FOR EACH ROW EXECUTE PROCEDURE slug_history_cleanup_trigger();
+
+CREATE TRIGGER products_slug_history_cleanup AFTER DELETE ON products
+FOR EACH ROW EXECUTE PROCEDURE slug_history_cleanup_trigger();
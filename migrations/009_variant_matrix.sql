@@ -0,0 +1,7 @@
+-- Keep options and their values in the order they were given, so variant
+-- option values can be read back positionally and SKU templates are stable.
+ALTER TABLE product_options ADD COLUMN position INT NOT NULL DEFAULT 0;
+ALTER TABLE product_option_values ADD COLUMN position INT NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_product_options_product_id ON product_options(product_id, position);
+CREATE INDEX idx_product_option_values_option_id ON product_option_values(option_id, position);
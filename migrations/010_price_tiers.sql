@@ -0,0 +1,15 @@
+-- Quantity-break prices per variant. A tier applies from min_quantity up to
+-- max_quantity (open-ended when NULL); quantities outside every tier pay the
+-- variant's base price.
+CREATE TABLE variant_price_tiers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    variant_id UUID NOT NULL REFERENCES product_variants(id) ON DELETE CASCADE,
+    min_quantity INT NOT NULL CHECK (min_quantity >= 1),
+    max_quantity INT CHECK (max_quantity IS NULL OR max_quantity >= min_quantity),
+    unit_price DECIMAL(12,2) NOT NULL CHECK (unit_price >= 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (variant_id, min_quantity)
+);
+
+CREATE TRIGGER update_variant_price_tiers_modtime BEFORE UPDATE ON variant_price_tiers FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
@@ -0,0 +1,110 @@
+-- Contract pricing: price lists with a validity window hold per-variant
+-- prices and percentage discounts per category or brand, and are assigned to
+-- companies (users.company_id).
+
+-- Price lists hold the contract terms of every company and discount products
+-- of every seller, so they are managed by admins only rather than under
+-- pricing:update, which sellers hold.
+INSERT INTO permissions (id, name, description) VALUES
+(uuid_generate_v4(), 'pricing:lists', 'Manage contract price lists');
+
+INSERT INTO roles_permissions (role_id, permission_id)
+SELECT '37e13c1b-cfb5-44ad-a2ac-613d8e9650b4', id FROM permissions WHERE name = 'pricing:lists';
+
+CREATE TABLE price_lists (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'INR',
+    valid_from TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    valid_to TIMESTAMPTZ,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (valid_to IS NULL OR valid_to > valid_from)
+);
+
+CREATE TRIGGER update_price_lists_modtime BEFORE UPDATE ON price_lists FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+-- Fixed contract prices, in the currency of the price list
+CREATE TABLE price_list_items (
+    price_list_id UUID NOT NULL REFERENCES price_lists(id) ON DELETE CASCADE,
+    variant_id UUID NOT NULL REFERENCES product_variants(id) ON DELETE CASCADE,
+    price DECIMAL(12,2) NOT NULL CHECK (price >= 0),
+    PRIMARY KEY (price_list_id, variant_id)
+);
+
+CREATE INDEX idx_price_list_items_variant ON price_list_items(variant_id);
+
+-- A discount applies to the products of a brand, or of a category and its
+-- subcategories. Deleting a category or brand is refused while discounts
+-- reference it, and a discount stays below 100% so nothing is given away.
+CREATE TABLE price_list_discounts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    price_list_id UUID NOT NULL REFERENCES price_lists(id) ON DELETE CASCADE,
+    category_id UUID REFERENCES categories(id) ON DELETE RESTRICT,
+    brand_id UUID REFERENCES brands(id) ON DELETE RESTRICT,
+    discount_percent DECIMAL(5,2) NOT NULL CHECK (discount_percent > 0 AND discount_percent < 100),
+    CHECK ((category_id IS NULL) <> (brand_id IS NULL))
+);
+
+CREATE INDEX idx_price_list_discounts_list ON price_list_discounts(price_list_id);
+
+CREATE TABLE company_price_lists (
+    company_id UUID NOT NULL,
+    price_list_id UUID NOT NULL REFERENCES price_lists(id) ON DELETE CASCADE,
+    PRIMARY KEY (company_id, price_list_id)
+);
+
+CREATE INDEX idx_company_price_lists_list ON company_price_lists(price_list_id);
+
+-- The price list in force for a company: active, inside its validity window,
+-- and the most recently started when several overlap.
+CREATE OR REPLACE FUNCTION active_price_list(p_company_id UUID)
+RETURNS UUID AS $$
+    SELECT pl.id FROM price_lists pl
+    JOIN company_price_lists cpl ON cpl.price_list_id = pl.id
+    WHERE cpl.company_id = p_company_id AND pl.is_active
+    AND pl.valid_from <= CURRENT_TIMESTAMP AND (pl.valid_to IS NULL OR pl.valid_to > CURRENT_TIMESTAMP)
+    ORDER BY pl.valid_from DESC, pl.created_at DESC
+    LIMIT 1
+$$ LANGUAGE sql STABLE;
+
+-- contract_price resolves list_price of a variant for a company: the fixed
+-- price of its active price list, else list_price less the largest discount
+-- matching the product's brand or category (or an ancestor), else list_price.
+CREATE OR REPLACE FUNCTION contract_price(p_variant_id UUID, p_company_id UUID, p_list_price NUMERIC)
+RETURNS NUMERIC AS $$
+DECLARE
+    list_id UUID;
+    resolved NUMERIC;
+    discount NUMERIC;
+BEGIN
+    IF p_company_id IS NULL THEN
+        RETURN p_list_price;
+    END IF;
+
+    list_id := active_price_list(p_company_id);
+    IF list_id IS NULL THEN
+        RETURN p_list_price;
+    END IF;
+
+    SELECT price INTO resolved FROM price_list_items WHERE price_list_id = list_id AND variant_id = p_variant_id;
+    IF FOUND THEN
+        RETURN resolved;
+    END IF;
+
+    SELECT MAX(d.discount_percent) INTO discount
+    FROM product_variants pv
+    JOIN products p ON p.id = pv.product_id
+    LEFT JOIN categories pc ON pc.id = p.category_id
+    JOIN price_list_discounts d ON d.price_list_id = list_id
+    LEFT JOIN categories dc ON dc.id = d.category_id
+    WHERE pv.id = p_variant_id AND (d.brand_id = p.brand_id OR pc.path LIKE dc.path || '%');
+
+    IF discount IS NULL THEN
+        RETURN p_list_price;
+    END IF;
+
+    RETURN ROUND(p_list_price * (100 - discount) / 100, 2);
+END;
+$$ LANGUAGE plpgsql STABLE;
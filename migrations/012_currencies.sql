@@ -0,0 +1,180 @@
+-- Currencies with their rounding rules, exchange rates against the store
+-- (base) currency, and a currency on every variant price.
+CREATE TABLE currencies (
+    code CHAR(3) PRIMARY KEY,
+    name VARCHAR(50) NOT NULL,
+    -- Converted amounts are rounded to a multiple of rounding_increment using
+    -- rounding_mode: HALF_UP (away from zero), HALF_EVEN (banker's), UP or DOWN
+    rounding_increment DECIMAL(12,4) NOT NULL DEFAULT 0.01 CHECK (rounding_increment > 0),
+    rounding_mode VARCHAR(10) NOT NULL DEFAULT 'HALF_UP' CHECK (rounding_mode IN ('HALF_UP', 'HALF_EVEN', 'UP', 'DOWN')),
+    is_base BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Exactly one store currency
+CREATE UNIQUE INDEX idx_currencies_base ON currencies(is_base) WHERE is_base;
+
+CREATE TRIGGER update_currencies_modtime BEFORE UPDATE ON currencies FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+INSERT INTO currencies (code, name, rounding_increment, rounding_mode, is_base) VALUES
+('INR', 'Indian Rupee', 0.01, 'HALF_UP', TRUE),
+('AED', 'UAE Dirham', 0.01, 'HALF_UP', FALSE),
+('SAR', 'Saudi Riyal', 0.01, 'HALF_UP', FALSE),
+('QAR', 'Qatari Riyal', 0.01, 'HALF_UP', FALSE),
+('OMR', 'Omani Rial', 0.001, 'HALF_UP', FALSE),
+('KWD', 'Kuwaiti Dinar', 0.001, 'HALF_UP', FALSE),
+('BHD', 'Bahraini Dinar', 0.001, 'HALF_UP', FALSE),
+('EUR', 'Euro', 0.01, 'HALF_EVEN', FALSE),
+('USD', 'US Dollar', 0.01, 'HALF_UP', FALSE);
+
+-- rate is how many units of currency one unit of the store currency buys.
+-- Rows are kept as history; the latest effective_at not in the future applies.
+CREATE TABLE exchange_rates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    currency CHAR(3) NOT NULL REFERENCES currencies(code) ON DELETE CASCADE,
+    rate DECIMAL(20,10) NOT NULL CHECK (rate > 0),
+    effective_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    source VARCHAR(20) NOT NULL DEFAULT 'manual',
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (currency, effective_at)
+);
+
+CREATE INDEX idx_exchange_rates_currency ON exchange_rates(currency, effective_at DESC);
+
+-- Setting exchange rates is reserved to admins
+INSERT INTO permissions (id, name, description) VALUES
+(uuid_generate_v4(), 'pricing:rates', 'Manage currencies and exchange rates');
+
+INSERT INTO roles_permissions (role_id, permission_id)
+SELECT '37e13c1b-cfb5-44ad-a2ac-613d8e9650b4', id FROM permissions WHERE name = 'pricing:rates';
+
+-- Existing prices are in the store currency; tier prices follow their variant.
+-- Prices get four decimal places for the three-digit currencies.
+ALTER TABLE product_variants ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'INR' REFERENCES currencies(code);
+ALTER TABLE product_variants ALTER COLUMN price TYPE DECIMAL(14,4);
+ALTER TABLE variant_price_tiers ALTER COLUMN unit_price TYPE DECIMAL(14,4);
+ALTER TABLE price_list_items ALTER COLUMN price TYPE DECIMAL(14,4);
+ALTER TABLE price_lists ADD CONSTRAINT price_lists_currency_fkey FOREIGN KEY (currency) REFERENCES currencies(code);
+
+CREATE OR REPLACE FUNCTION store_currency()
+RETURNS CHAR(3) AS $$
+    SELECT code FROM currencies WHERE is_base
+$$ LANGUAGE sql STABLE;
+
+-- Units of p_currency per unit of the store currency. Missing rates and
+-- unknown currencies raise SQLSTATE MN001 (shared.ErrNoExchangeRate).
+CREATE OR REPLACE FUNCTION exchange_rate(p_currency CHAR(3))
+RETURNS NUMERIC AS $$
+DECLARE
+    result NUMERIC;
+BEGIN
+    IF p_currency = store_currency() THEN
+        RETURN 1;
+    END IF;
+
+    SELECT rate INTO result FROM exchange_rates
+    WHERE currency = p_currency AND effective_at <= CURRENT_TIMESTAMP
+    ORDER BY effective_at DESC LIMIT 1;
+
+    IF result IS NULL THEN
+        RAISE EXCEPTION '%', p_currency USING ERRCODE = 'MN001';
+    END IF;
+
+    RETURN result;
+END;
+$$ LANGUAGE plpgsql STABLE;
+
+-- Rounds an amount with the rules of its currency
+CREATE OR REPLACE FUNCTION round_money(p_amount NUMERIC, p_currency CHAR(3))
+RETURNS NUMERIC AS $$
+DECLARE
+    increment NUMERIC;
+    mode VARCHAR(10);
+    steps NUMERIC;
+    whole NUMERIC;
+BEGIN
+    SELECT rounding_increment, rounding_mode INTO increment, mode FROM currencies WHERE code = p_currency;
+    IF increment IS NULL THEN
+        RAISE EXCEPTION 'unknown currency %', p_currency USING ERRCODE = 'MN001';
+    END IF;
+
+    steps := p_amount / increment;
+    whole := trunc(steps);
+
+    IF mode = 'UP' THEN
+        IF steps <> whole THEN
+            whole := whole + sign(steps);
+        END IF;
+    ELSIF mode = 'HALF_UP' THEN
+        whole := round(steps);
+    ELSIF mode = 'HALF_EVEN' THEN
+        IF abs(steps - whole) > 0.5 OR (abs(steps - whole) = 0.5 AND mod(whole, 2) <> 0) THEN
+            whole := whole + sign(steps);
+        END IF;
+    END IF;
+
+    RETURN whole * increment;
+END;
+$$ LANGUAGE plpgsql STABLE;
+
+-- Converts an amount between currencies through the store currency, rounded
+-- with the rules of the target. A NULL target keeps the amount as is.
+CREATE OR REPLACE FUNCTION convert_price(p_amount NUMERIC, p_from CHAR(3), p_to CHAR(3))
+RETURNS NUMERIC AS $$
+BEGIN
+    IF p_amount IS NULL OR p_to IS NULL OR p_from = p_to THEN
+        RETURN p_amount;
+    END IF;
+
+    RETURN round_money(p_amount / exchange_rate(p_from) * exchange_rate(p_to), p_to);
+END;
+$$ LANGUAGE plpgsql STABLE;
+
+-- contract_price now takes the list price in the variant's currency and
+-- answers in p_currency (the variant's currency when NULL). Fixed prices are
+-- converted from the currency of their price list.
+DROP FUNCTION contract_price(UUID, UUID, NUMERIC);
+
+CREATE OR REPLACE FUNCTION contract_price(p_variant_id UUID, p_company_id UUID, p_list_price NUMERIC, p_currency CHAR(3))
+RETURNS NUMERIC AS $$
+DECLARE
+    variant_currency CHAR(3);
+    list_id UUID;
+    list_currency CHAR(3);
+    resolved NUMERIC;
+    discount NUMERIC;
+BEGIN
+    SELECT currency INTO variant_currency FROM product_variants WHERE id = p_variant_id;
+    p_currency := COALESCE(p_currency, variant_currency);
+
+    IF p_company_id IS NOT NULL THEN
+        list_id := active_price_list(p_company_id);
+    END IF;
+    IF list_id IS NULL THEN
+        RETURN convert_price(p_list_price, variant_currency, p_currency);
+    END IF;
+
+    SELECT pli.price, pl.currency INTO resolved, list_currency
+    FROM price_list_items pli JOIN price_lists pl ON pl.id = pli.price_list_id
+    WHERE pli.price_list_id = list_id AND pli.variant_id = p_variant_id;
+    IF FOUND THEN
+        RETURN convert_price(resolved, list_currency, p_currency);
+    END IF;
+
+    SELECT MAX(d.discount_percent) INTO discount
+    FROM product_variants pv
+    JOIN products p ON p.id = pv.product_id
+    LEFT JOIN categories pc ON pc.id = p.category_id
+    JOIN price_list_discounts d ON d.price_list_id = list_id
+    LEFT JOIN categories dc ON dc.id = d.category_id
+    WHERE pv.id = p_variant_id AND (d.brand_id = p.brand_id OR pc.path LIKE dc.path || '%');
+
+    IF discount IS NOT NULL THEN
+        p_list_price := round_money(p_list_price * (100 - discount) / 100, variant_currency);
+    END IF;
+
+    RETURN convert_price(p_list_price, variant_currency, p_currency);
+END;
+$$ LANGUAGE plpgsql STABLE;
@@ -0,0 +1,72 @@
+-- GST: tax classes by HSN code with dated rates, assigned to products or, as
+-- a default for their products, to categories. Catalog prices stay
+-- tax-exclusive; users carry the GST state code used to split the tax.
+CREATE TABLE tax_classes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    hsn_code VARCHAR(8) NOT NULL CHECK (hsn_code ~ '^[0-9]{4}([0-9]{2}){0,2}$'),
+    description TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER update_tax_classes_modtime BEFORE UPDATE ON tax_classes FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+-- The total GST rate of a class from effective_from until the next row
+CREATE TABLE tax_rates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tax_class_id UUID NOT NULL REFERENCES tax_classes(id) ON DELETE CASCADE,
+    rate DECIMAL(5,2) NOT NULL CHECK (rate >= 0 AND rate <= 100),
+    effective_from DATE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tax_class_id, effective_from)
+);
+
+ALTER TABLE products ADD COLUMN tax_class_id UUID REFERENCES tax_classes(id) ON DELETE SET NULL;
+ALTER TABLE categories ADD COLUMN tax_class_id UUID REFERENCES tax_classes(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_products_tax_class ON products(tax_class_id);
+CREATE INDEX idx_categories_tax_class ON categories(tax_class_id);
+
+-- Two-digit GST state code of a buyer, or of a seller for the products it sells
+ALTER TABLE users ADD COLUMN state_code CHAR(2) CHECK (state_code ~ '^[0-9]{2}$');
+
+INSERT INTO permissions (id, name, description) VALUES
+(uuid_generate_v4(), 'tax:view', 'View tax classes'),
+(uuid_generate_v4(), 'tax:update', 'Manage tax classes and assign them');
+
+INSERT INTO roles_permissions (role_id, permission_id)
+SELECT '37e13c1b-cfb5-44ad-a2ac-613d8e9650b4', id FROM permissions WHERE name IN ('tax:view', 'tax:update');
+
+-- The tax class of a product: its own, else that of the nearest category up
+-- its category path that has one.
+CREATE OR REPLACE FUNCTION product_tax_class(p_product_id UUID)
+RETURNS UUID AS $$
+    SELECT COALESCE(p.tax_class_id, (
+        SELECT c.tax_class_id FROM categories pc
+        JOIN categories c ON pc.path LIKE c.path || '%'
+        WHERE pc.id = p.category_id AND c.tax_class_id IS NOT NULL
+        ORDER BY c.level DESC
+        LIMIT 1
+    ))
+    FROM products p WHERE p.id = p_product_id
+$$ LANGUAGE sql STABLE;
+
+-- The rate of a tax class in force on a day, NULL before its first rate
+CREATE OR REPLACE FUNCTION tax_rate(p_tax_class_id UUID, p_on DATE)
+RETURNS NUMERIC AS $$
+    SELECT rate FROM tax_rates
+    WHERE tax_class_id = p_tax_class_id AND effective_from <= p_on
+    ORDER BY effective_from DESC
+    LIMIT 1
+$$ LANGUAGE sql STABLE;
+
+-- Everything the tax of a product depends on, see shared.TaxRule
+CREATE OR REPLACE FUNCTION product_tax(p_product_id UUID, p_on DATE)
+RETURNS TABLE (tax_class_id UUID, hsn_code VARCHAR(8), tax_rate NUMERIC, seller_state CHAR(2)) AS $$
+    SELECT tc.id, tc.hsn_code, tax_rate(tc.id, p_on), u.state_code
+    FROM products p
+    LEFT JOIN tax_classes tc ON tc.id = product_tax_class(p.id)
+    LEFT JOIN users u ON u.id = p.seller_id
+    WHERE p.id = p_product_id
+$$ LANGUAGE sql STABLE;
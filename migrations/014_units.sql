@@ -0,0 +1,40 @@
+-- Units of measure: every variant is priced and ordered in a base unit, may
+-- also be sold in packs of a fixed number of base units, and may carry a
+-- minimum order quantity and an order multiple, both in base units.
+CREATE TABLE units_of_measure (
+    code VARCHAR(10) PRIMARY KEY CHECK (code ~ '^[A-Z0-9]+$'),
+    name VARCHAR(50) NOT NULL
+);
+
+INSERT INTO units_of_measure (code, name) VALUES
+('PC', 'Piece'),
+('PAIR', 'Pair'),
+('SET', 'Set'),
+('PACK', 'Pack'),
+('BOX', 'Box'),
+('CASE', 'Case'),
+('CARTON', 'Carton'),
+('ROLL', 'Roll'),
+('M', 'Metre'),
+('KG', 'Kilogram'),
+('L', 'Litre');
+
+ALTER TABLE product_variants ADD COLUMN unit VARCHAR(10) NOT NULL DEFAULT 'PC' REFERENCES units_of_measure(code);
+ALTER TABLE product_variants ADD COLUMN min_order_quantity INT NOT NULL DEFAULT 1 CHECK (min_order_quantity >= 1);
+ALTER TABLE product_variants ADD COLUMN order_multiple INT NOT NULL DEFAULT 1 CHECK (order_multiple >= 1);
+
+-- A BOX of 12 PAIR is (variant, 'BOX', 12); a CASE of 12 boxes is
+-- (variant, 'CASE', 144).
+CREATE TABLE variant_pack_sizes (
+    variant_id UUID NOT NULL REFERENCES product_variants(id) ON DELETE CASCADE,
+    unit VARCHAR(10) NOT NULL REFERENCES units_of_measure(code),
+    quantity INT NOT NULL CHECK (quantity > 1),
+    PRIMARY KEY (variant_id, unit)
+);
+
+-- The pack sizes of a variant as a JSON array, see shared.PackSizes
+CREATE OR REPLACE FUNCTION pack_sizes(p_variant_id UUID)
+RETURNS JSONB AS $$
+    SELECT COALESCE(jsonb_agg(jsonb_build_object('unit', unit, 'quantity', quantity) ORDER BY quantity), '[]')
+    FROM variant_pack_sizes WHERE variant_id = p_variant_id
+$$ LANGUAGE sql STABLE;
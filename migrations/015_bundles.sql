@@ -0,0 +1,108 @@
+-- Bundles and kits: a BUNDLE product is sold through its own variants like any
+-- product and is made of components, each a quantity of one variant the buyer
+-- chooses among the component's allowed variants (a boot size, say).
+ALTER TABLE products ADD COLUMN product_type VARCHAR(10) NOT NULL DEFAULT 'STANDARD' CHECK (product_type IN ('STANDARD', 'BUNDLE'));
+
+-- FIXED bundles keep the price of their variants. COMPONENTS bundles are
+-- priced at the sum of their components at the default variants less
+-- discount_percent, kept up to date on their variants by the triggers below.
+CREATE TABLE product_bundles (
+    product_id UUID PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+    pricing VARCHAR(10) NOT NULL DEFAULT 'FIXED' CHECK (pricing IN ('FIXED', 'COMPONENTS')),
+    discount_percent DECIMAL(5,2) NOT NULL DEFAULT 0 CHECK (discount_percent >= 0 AND discount_percent < 100),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER update_product_bundles_modtime BEFORE UPDATE ON product_bundles FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+CREATE TABLE bundle_components (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    bundle_id UUID NOT NULL REFERENCES product_bundles(product_id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    quantity INT NOT NULL CHECK (quantity >= 1),
+    position INT NOT NULL DEFAULT 0,
+    UNIQUE (bundle_id, name)
+);
+
+CREATE TABLE bundle_component_variants (
+    component_id UUID NOT NULL REFERENCES bundle_components(id) ON DELETE CASCADE,
+    variant_id UUID NOT NULL REFERENCES product_variants(id) ON DELETE CASCADE,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (component_id, variant_id)
+);
+
+CREATE UNIQUE INDEX idx_bundle_component_default ON bundle_component_variants(component_id) WHERE is_default;
+CREATE INDEX idx_bundle_component_variants_variant ON bundle_component_variants(variant_id);
+
+-- A variant can be sold, alone or in a bundle, while it is active and its
+-- product is published.
+CREATE OR REPLACE FUNCTION variant_available(p_variant_id UUID)
+RETURNS BOOLEAN AS $$
+    SELECT COALESCE((
+        SELECT pv.is_active AND p.status = 'ACTIVE'
+        FROM product_variants pv JOIN products p ON p.id = pv.product_id
+        WHERE pv.id = p_variant_id
+    ), FALSE)
+$$ LANGUAGE sql STABLE;
+
+-- The price in p_currency of a COMPONENTS bundle with the variants in
+-- p_variant_ids chosen for their components and the defaults for the rest;
+-- NULL for FIXED bundles.
+CREATE OR REPLACE FUNCTION bundle_price(p_bundle_id UUID, p_currency CHAR(3), p_variant_ids UUID[] DEFAULT '{}')
+RETURNS NUMERIC AS $$
+    SELECT round_money(SUM(convert_price(pv.price, pv.currency, p_currency) * bc.quantity) * (100 - b.discount_percent) / 100, p_currency)
+    FROM product_bundles b
+    JOIN bundle_components bc ON bc.bundle_id = b.product_id
+    JOIN LATERAL (
+        SELECT bcv.variant_id FROM bundle_component_variants bcv
+        WHERE bcv.component_id = bc.id
+        ORDER BY bcv.variant_id = ANY(p_variant_ids) DESC, bcv.is_default DESC
+        LIMIT 1
+    ) chosen ON true
+    JOIN product_variants pv ON pv.id = chosen.variant_id
+    WHERE b.product_id = p_bundle_id AND b.pricing = 'COMPONENTS'
+    GROUP BY b.discount_percent
+$$ LANGUAGE sql STABLE;
+
+-- Variants of COMPONENTS bundles always carry the bundle price. A missing
+-- exchange rate leaves the price as it was rather than failing the write.
+CREATE OR REPLACE FUNCTION price_bundle_variant()
+RETURNS TRIGGER AS $$
+DECLARE
+    bundle_total NUMERIC;
+BEGIN
+    IF EXISTS (SELECT 1 FROM products WHERE id = NEW.product_id AND product_type = 'BUNDLE') THEN
+        BEGIN
+            bundle_total := bundle_price(NEW.product_id, NEW.currency);
+            IF bundle_total IS NOT NULL THEN
+                NEW.price := bundle_total;
+            END IF;
+        EXCEPTION WHEN SQLSTATE 'MN001' THEN
+            RAISE WARNING 'bundle % not repriced: %', NEW.product_id, SQLERRM;
+        END;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER price_bundle_variant BEFORE INSERT OR UPDATE ON product_variants FOR EACH ROW EXECUTE PROCEDURE price_bundle_variant();
+
+-- Reprices the bundles a variant is the default component of when its price
+-- changes. Bundles are never components, so this does not recurse.
+CREATE OR REPLACE FUNCTION reprice_bundles()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE product_variants SET updated_at = CURRENT_TIMESTAMP
+    WHERE product_id IN (
+        SELECT bc.bundle_id FROM bundle_component_variants bcv
+        JOIN bundle_components bc ON bc.id = bcv.component_id
+        WHERE bcv.variant_id = NEW.id AND bcv.is_default
+    );
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER reprice_bundles AFTER UPDATE OF price, currency ON product_variants FOR EACH ROW
+WHEN (OLD.price IS DISTINCT FROM NEW.price OR OLD.currency IS DISTINCT FROM NEW.currency)
+EXECUTE PROCEDURE reprice_bundles();
@@ -0,0 +1,80 @@
+-- Compliance certificates (EN 397, ANSI Z89.1, IS 2925, CE marks ...) of a
+-- product, or of one of its variants, with the certificate PDF uploaded
+-- through the upload service. The compliance:view and compliance:upload
+-- permissions are seeded in 001_init.sql.
+CREATE TABLE product_certificates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    variant_id UUID REFERENCES product_variants(id) ON DELETE CASCADE,
+    standard VARCHAR(100) NOT NULL,
+    certifying_body VARCHAR(200) NOT NULL,
+    certificate_number VARCHAR(100) NOT NULL,
+    issued_on DATE NOT NULL,
+    expires_on DATE CHECK (expires_on > issued_on),
+    document_url TEXT NOT NULL,
+    -- A lapsed mandatory certificate flags its product; with on_expiry
+    -- ARCHIVE the product is archived as well.
+    is_mandatory BOOLEAN NOT NULL DEFAULT TRUE,
+    on_expiry VARCHAR(10) NOT NULL DEFAULT 'FLAG' CHECK (on_expiry IN ('FLAG', 'ARCHIVE')),
+    uploaded_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER update_product_certificates_modtime BEFORE UPDATE ON product_certificates FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+CREATE UNIQUE INDEX idx_product_certificates_number ON product_certificates(product_id, COALESCE(variant_id, '00000000-0000-0000-0000-000000000000'), certifying_body, certificate_number);
+CREATE INDEX idx_product_certificates_standard ON product_certificates(UPPER(standard));
+CREATE INDEX idx_product_certificates_expires ON product_certificates(expires_on) WHERE is_mandatory;
+
+-- Set by the daily compliance job while a mandatory certificate of the
+-- product has lapsed, cleared once it is renewed.
+ALTER TABLE products ADD COLUMN compliance_flagged_at TIMESTAMP;
+
+-- A certificate is valid from its issue date until its expiry date, inclusive.
+CREATE OR REPLACE FUNCTION certificate_valid(p_issued_on DATE, p_expires_on DATE, p_on DATE)
+RETURNS BOOLEAN AS $$
+    SELECT p_issued_on <= p_on AND (p_expires_on IS NULL OR p_expires_on >= p_on)
+$$ LANGUAGE sql IMMUTABLE;
+
+-- A certificate has lapsed when it is mandatory, expired before p_on and no
+-- valid certificate of the same standard covers the same product or variant.
+CREATE OR REPLACE FUNCTION certificate_lapsed(p_certificate_id UUID, p_on DATE)
+RETURNS BOOLEAN AS $$
+    SELECT COALESCE((
+        SELECT pc.is_mandatory AND pc.expires_on < p_on AND NOT EXISTS (
+            SELECT 1 FROM product_certificates renewed
+            WHERE renewed.product_id = pc.product_id
+            AND renewed.variant_id IS NOT DISTINCT FROM pc.variant_id
+            AND UPPER(renewed.standard) = UPPER(pc.standard)
+            AND certificate_valid(renewed.issued_on, renewed.expires_on, p_on)
+        )
+        FROM product_certificates pc WHERE pc.id = p_certificate_id
+    ), FALSE)
+$$ LANGUAGE sql STABLE;
+
+-- The standards a product holds a valid certificate for today, for the
+-- listing filter and the search document.
+CREATE OR REPLACE FUNCTION product_certifications(p_product_id UUID)
+RETURNS TEXT[] AS $$
+    SELECT COALESCE(array_agg(DISTINCT standard ORDER BY standard), '{}')
+    FROM product_certificates
+    WHERE product_id = p_product_id AND certificate_valid(issued_on, expires_on, CURRENT_DATE)
+$$ LANGUAGE sql STABLE;
+
+-- Certificate standards, bodies and numbers become searchable next to the
+-- brand and category names (weight B).
+CREATE OR REPLACE FUNCTION product_search_document(p_id UUID, p_name TEXT, p_description TEXT, p_brand_id UUID, p_category_id UUID)
+RETURNS tsvector AS $$
+    SELECT
+        setweight(to_tsvector('english', COALESCE(p_name, '')), 'A') ||
+        setweight(to_tsvector('simple', COALESCE((SELECT string_agg(sku, ' ') FROM product_variants WHERE product_id = p_id), '')), 'A') ||
+        setweight(to_tsvector('simple', COALESCE((SELECT name FROM brands WHERE id = p_brand_id), '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE((SELECT name FROM categories WHERE id = p_category_id), '')), 'B') ||
+        setweight(to_tsvector('simple', COALESCE((SELECT string_agg(standard || ' ' || certifying_body || ' ' || certificate_number, ' ') FROM product_certificates WHERE product_id = p_id), '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE((SELECT string_agg(attribute_value, ' ') FROM products_attributes WHERE product_id = p_id), '')), 'C') ||
+        setweight(to_tsvector('english', COALESCE(p_description, '')), 'D');
+$$ LANGUAGE sql STABLE;
+
+CREATE TRIGGER product_certificates_search_vector AFTER INSERT OR UPDATE OF product_id, standard, certifying_body, certificate_number OR DELETE ON product_certificates
+FOR EACH ROW EXECUTE PROCEDURE product_child_search_vector_trigger();
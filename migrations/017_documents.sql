@@ -0,0 +1,32 @@
+-- Technical documents of a product (safety data sheets, datasheets, manuals
+-- ...), kept apart from the gallery in product_media. A document has a file
+-- per language and version; downloads serve the latest version in a
+-- language. The document:upload and document:view permissions are seeded in
+-- 001_init.sql.
+CREATE TABLE product_documents (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('SDS', 'DATASHEET', 'MANUAL', 'BROCHURE', 'OTHER')),
+    title VARCHAR(255) NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER update_product_documents_modtime BEFORE UPDATE ON product_documents FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+CREATE INDEX idx_product_documents_product ON product_documents(product_id, kind);
+
+-- Versions are numbered from 1 per document and language.
+CREATE TABLE product_document_files (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    document_id UUID NOT NULL REFERENCES product_documents(id) ON DELETE CASCADE,
+    language VARCHAR(35) NOT NULL,
+    version INT NOT NULL CHECK (version >= 1),
+    file_url TEXT NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    file_size BIGINT NOT NULL CHECK (file_size >= 0),
+    uploaded_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (document_id, language, version)
+);
@@ -0,0 +1,92 @@
+-- Product reviews: a buyer rates a product once, with a title, a body and
+-- optional photos uploaded through the upload endpoint. Reviews are PENDING
+-- until a moderator approves or rejects them; only APPROVED reviews are shown
+-- and counted. The seller of the product may post one public reply.
+CREATE TABLE product_reviews (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    title VARCHAR(200) NOT NULL,
+    body TEXT NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED')),
+    rejection_reason TEXT,
+    -- Set by hand by the moderator once the purchase is confirmed; there are
+    -- no orders in the catalog to derive it from.
+    is_verified_purchase BOOLEAN NOT NULL DEFAULT FALSE,
+    moderated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    moderated_at TIMESTAMP,
+    reply TEXT,
+    replied_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    replied_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (product_id, user_id)
+);
+
+CREATE TRIGGER update_product_reviews_modtime BEFORE UPDATE ON product_reviews FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+CREATE INDEX idx_product_reviews_product ON product_reviews(product_id, created_at DESC) WHERE status = 'APPROVED';
+CREATE INDEX idx_product_reviews_pending ON product_reviews(created_at) WHERE status = 'PENDING';
+
+CREATE TABLE review_photos (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    review_id UUID NOT NULL REFERENCES product_reviews(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    position INT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_review_photos_review ON review_photos(review_id, position);
+
+-- Average and count of the APPROVED reviews, kept on the product for the
+-- listing filter and sort.
+ALTER TABLE products
+    ADD COLUMN rating_average DECIMAL(3,2) NOT NULL DEFAULT 0,
+    ADD COLUMN rating_count INT NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_products_rating ON products(rating_average DESC, rating_count DESC);
+
+CREATE OR REPLACE FUNCTION refresh_product_rating(p_product_id UUID)
+RETURNS void AS $$
+    UPDATE products p
+    SET rating_average = r.average, rating_count = r.count
+    FROM (
+        SELECT COALESCE(ROUND(AVG(rating), 2), 0) AS average, COUNT(*) AS count
+        FROM product_reviews WHERE product_id = p_product_id AND status = 'APPROVED'
+    ) r
+    WHERE p.id = p_product_id AND (p.rating_average, p.rating_count) IS DISTINCT FROM (r.average, r.count);
+$$ LANGUAGE sql;
+
+CREATE OR REPLACE FUNCTION product_reviews_rating_trigger()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        PERFORM refresh_product_rating(OLD.product_id);
+        RETURN OLD;
+    END IF;
+
+    PERFORM refresh_product_rating(NEW.product_id);
+    IF TG_OP = 'UPDATE' AND OLD.product_id IS DISTINCT FROM NEW.product_id THEN
+        PERFORM refresh_product_rating(OLD.product_id);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER product_reviews_rating AFTER INSERT OR UPDATE OF product_id, rating, status OR DELETE ON product_reviews
+FOR EACH ROW EXECUTE PROCEDURE product_reviews_rating_trigger();
+
+-- Buyers review, sellers reply, moderators (the admin role) approve
+INSERT INTO permissions (id, name, description) VALUES
+(uuid_generate_v4(), 'review:create', 'Post product reviews'),
+(uuid_generate_v4(), 'review:reply', 'Reply to reviews of own products'),
+(uuid_generate_v4(), 'review:moderate', 'Approve and reject reviews');
+
+INSERT INTO roles_permissions (role_id, permission_id)
+SELECT '37e13c1b-cfb5-44ad-a2ac-613d8e9650b4', id FROM permissions WHERE name IN ('review:create', 'review:reply', 'review:moderate');
+
+INSERT INTO roles_permissions (role_id, permission_id)
+SELECT '1c0cc3a1-f9e6-4ba7-adae-7d8a1c3128bd', id FROM permissions WHERE name = 'review:create';
+
+INSERT INTO roles_permissions (role_id, permission_id)
+SELECT 'a5c16d38-8a3a-49bd-874c-94ab690e314a', id FROM permissions WHERE name = 'review:reply';
@@ -0,0 +1,5 @@
+// Package catalogv1 contains the generated gRPC bindings for the catalog
+// services served on the gRPC listener.
+package catalogv1
+
+//go:generate protoc -I . --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative brand.proto category.proto product.proto
@@ -0,0 +1,27 @@
+package shared
+
+import (
+	"strings"
+	"unicode"
+)
+
+// AttributeKey normalizes an attribute name into its canonical key, so that
+// "Cut Level", "cut-level" and "CUT_LEVEL" all become "cut_level".
+func AttributeKey(name string) string {
+	var b strings.Builder
+	pendingSep := false
+
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if pendingSep && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			pendingSep = false
+			b.WriteRune(r)
+			continue
+		}
+		pendingSep = true
+	}
+
+	return b.String()
+}
@@ -0,0 +1,29 @@
+package shared
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CompanyID returns the company of the user making the request, or nil for
+// anonymous requests and users without a company. It is read from users rather
+// than the token so a change of company applies immediately.
+func CompanyID(ctx context.Context, q sqlx.QueryerContext) (*string, error) {
+	claims, ok := ctx.Value(UserClaimsKey).(*UserClaims)
+	if !ok || claims.UserID == "" {
+		return nil, nil
+	}
+
+	var companyID *string
+	if err := sqlx.GetContext(ctx, q, &companyID, "SELECT company_id FROM users WHERE id = $1", claims.UserID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, PostgresError(err)
+	}
+
+	return companyID, nil
+}
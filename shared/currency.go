@@ -0,0 +1,61 @@
+package shared
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var ErrUnsupportedCurrency = errors.New("unsupported currency")
+
+// ResolveCurrency checks a requested currency code: it must be the store
+// currency or have an exchange rate in force. An empty code resolves to the
+// store currency.
+func ResolveCurrency(ctx context.Context, q sqlx.QueryerContext, code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		var base string
+		if err := sqlx.GetContext(ctx, q, &base, "SELECT store_currency()"); err != nil {
+			return "", PostgresError(err)
+		}
+		return base, nil
+	}
+
+	var resolved string
+	query := `SELECT code FROM currencies c WHERE code = $1 AND (is_base
+	OR EXISTS (SELECT 1 FROM exchange_rates er WHERE er.currency = c.code AND er.effective_at <= CURRENT_TIMESTAMP))`
+	if err := sqlx.GetContext(ctx, q, &resolved, query, code); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("%w: %s", ErrUnsupportedCurrency, code)
+		}
+		return "", PostgresError(err)
+	}
+	return resolved, nil
+}
+
+// CurrencyPlaces returns the number of decimal places amounts in a currency
+// are kept to, taken from its rounding increment: 2 for INR, 3 for OMR.
+func CurrencyPlaces(ctx context.Context, q sqlx.QueryerContext, code string) (int, error) {
+	var increment Decimal
+	if err := sqlx.GetContext(ctx, q, &increment, "SELECT rounding_increment FROM currencies WHERE code = $1", code); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, code)
+		}
+		return 0, PostgresError(err)
+	}
+	return incrementPlaces(increment), nil
+}
+
+// incrementPlaces is the number of decimal places of a rounding increment,
+// e.g. 2 for 0.01 and 0.05.
+func incrementPlaces(increment Decimal) int {
+	places := 0
+	for places < DecimalPlaces && increment.Round(places) != increment {
+		places++
+	}
+	return places
+}
@@ -12,6 +12,7 @@ var (
 	ErrForeignKeyViolation    = errors.New("foreign key vioaltion")
 	ErrNullConstrainViolation = errors.New("null constrain violation")
 	ErrUserNotFound           = errors.New("Not Found")
+	ErrNoExchangeRate         = errors.New("no exchange rate")
 )
 
 func PostgresError(err error) error {
@@ -27,6 +28,8 @@ func PostgresError(err error) error {
 		return fmt.Errorf("%w: %s", ErrForeignKeyViolation, pqErr.Detail)
 	case "23502":
 		return fmt.Errorf("%w: %s", ErrNullConstrainViolation, pqErr.Detail)
+	case "MN001":
+		return fmt.Errorf("%w: %s", ErrNoExchangeRate, pqErr.Message)
 	default:
 		return fmt.Errorf("postgres err (%s): %w", pqErr.Code, err)
 	}
@@ -0,0 +1,179 @@
+package shared
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DecimalPlaces is the number of fractional digits a Decimal keeps; enough
+// for the three-digit currencies (KWD, BHD, OMR) with one to spare.
+const DecimalPlaces = 4
+
+const decimalScale = 10000
+
+var ErrInvalidDecimal = errors.New("invalid decimal")
+
+// Decimal is an exact fixed-point amount of money, stored as an integer
+// number of 1/10000 units, so sums and quantities never pick up float
+// rounding errors. It reads and writes Postgres NUMERIC and JSON numbers.
+// Being an integer kind, validator tags such as gte=0 work on it as usual.
+type Decimal int64
+
+// NewDecimal returns the Decimal of a whole number.
+func NewDecimal(n int64) Decimal {
+	return Decimal(n * decimalScale)
+}
+
+// DecimalFromFloat converts a float, rounding half away from zero to
+// DecimalPlaces. It is meant for values that are not money and arrive as
+// floats, such as the rating filter over gRPC.
+func DecimalFromFloat(f float64) Decimal {
+	return Decimal(math.Round(f * decimalScale))
+}
+
+// ParseDecimal parses a plain decimal such as "12", "-0.5" or "1499.99".
+// More than DecimalPlaces fractional digits is an error rather than a silent
+// rounding.
+func ParseDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	whole, fraction, _ := strings.Cut(s, ".")
+
+	negative := strings.HasPrefix(whole, "-")
+	whole = strings.TrimPrefix(strings.TrimPrefix(whole, "-"), "+")
+	if whole == "" && fraction == "" {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+	}
+
+	// Postgres pads NUMERIC(12,2) to its scale, so zeros beyond the last
+	// kept digit are fine.
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > DecimalPlaces {
+		return 0, fmt.Errorf("%w: %q has more than %d decimal places", ErrInvalidDecimal, s, DecimalPlaces)
+	}
+
+	var units int64
+	for _, digits := range []string{whole, fraction + strings.Repeat("0", DecimalPlaces-len(fraction))} {
+		for _, r := range digits {
+			if r < '0' || r > '9' {
+				return 0, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+			}
+			if units > (math.MaxInt64-9)/10 {
+				return 0, fmt.Errorf("%w: %q is out of range", ErrInvalidDecimal, s)
+			}
+			units = units*10 + int64(r-'0')
+		}
+	}
+
+	if negative {
+		units = -units
+	}
+	return Decimal(units), nil
+}
+
+func (d Decimal) Add(other Decimal) Decimal {
+	return d + other
+}
+
+func (d Decimal) Sub(other Decimal) Decimal {
+	return d - other
+}
+
+// MulInt multiplies by a quantity; the result is exact.
+func (d Decimal) MulInt(n int) Decimal {
+	return d * Decimal(n)
+}
+
+// Percent returns rate percent of d, rounded half away from zero to
+// DecimalPlaces. The product is taken in big integers so large amounts at
+// high rates do not overflow.
+func (d Decimal) Percent(rate Decimal) Decimal {
+	product := new(big.Int).Mul(big.NewInt(int64(d)), big.NewInt(int64(rate)))
+	return Decimal(roundQuotient(product, big.NewInt(100*decimalScale)))
+}
+
+// Round rounds half away from zero to places fractional digits.
+func (d Decimal) Round(places int) Decimal {
+	if places >= DecimalPlaces {
+		return d
+	}
+	step := int64(math.Pow10(DecimalPlaces - max(places, 0)))
+	return Decimal(roundQuotient(big.NewInt(int64(d)), big.NewInt(step)) * step)
+}
+
+func roundQuotient(n, d *big.Int) int64 {
+	quotient, remainder := new(big.Int).QuoRem(n, d, new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(d) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(n.Sign())))
+	}
+	return quotient.Int64()
+}
+
+func (d Decimal) IsZero() bool {
+	return d == 0
+}
+
+func (d Decimal) Float64() float64 {
+	return float64(d) / decimalScale
+}
+
+// String formats the amount without trailing zeros, e.g. "1499.9".
+func (d Decimal) String() string {
+	units := int64(d)
+	sign := ""
+	if units < 0 {
+		sign = "-"
+		units = -units
+	}
+
+	whole := units / decimalScale
+	fraction := strings.TrimRight(fmt.Sprintf("%0*d", DecimalPlaces, units%decimalScale), "0")
+	if fraction == "" {
+		return sign + strconv.FormatInt(whole, 10)
+	}
+	return sign + strconv.FormatInt(whole, 10) + "." + fraction
+}
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a numeric string.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	parsed, err := ParseDecimal(strings.Trim(string(data), `"`))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+func (d *Decimal) Scan(src interface{}) error {
+	var err error
+	switch value := src.(type) {
+	case []byte:
+		*d, err = ParseDecimal(string(value))
+	case string:
+		*d, err = ParseDecimal(value)
+	case int64:
+		*d = NewDecimal(value)
+	case float64:
+		*d = DecimalFromFloat(value)
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidDecimal, src)
+	}
+	return err
+}
+
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
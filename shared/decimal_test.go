@@ -0,0 +1,262 @@
+package shared
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Decimal
+		err  bool
+	}{
+		{in: "12", want: 120000},
+		{in: "-0.5", want: -5000},
+		{in: "+1499.99", want: 14999900},
+		{in: " 0.0001 ", want: 1},
+		{in: ".25", want: 2500},
+		{in: "7.", want: 70000},
+		// Postgres pads to the column scale.
+		{in: "12.500000", want: 125000},
+		{in: "0.00001", err: true},
+		{in: "1.23456", err: true},
+		{in: "", err: true},
+		{in: "-", err: true},
+		{in: ".", err: true},
+		{in: "1e5", err: true},
+		{in: "12,5", err: true},
+		{in: "--1", err: true},
+		{in: "900000000000000", want: Decimal(9000000000000000000)},
+		{in: "922337203685478", err: true},
+	}
+
+	for _, test := range tests {
+		got, err := ParseDecimal(test.in)
+		if test.err {
+			if !errors.Is(err, ErrInvalidDecimal) {
+				t.Errorf("ParseDecimal(%q) error = %v, want ErrInvalidDecimal", test.in, err)
+			}
+			continue
+		}
+		if err != nil || got != test.want {
+			t.Errorf("ParseDecimal(%q) = %d, %v, want %d", test.in, got, err, test.want)
+		}
+	}
+}
+
+func TestDecimalString(t *testing.T) {
+	tests := []struct {
+		in   Decimal
+		want string
+	}{
+		{in: 0, want: "0"},
+		{in: NewDecimal(1499), want: "1499"},
+		{in: 14999000, want: "1499.9"},
+		{in: 1, want: "0.0001"},
+		{in: -5000, want: "-0.5"},
+		{in: -125000, want: "-12.5"},
+	}
+
+	for _, test := range tests {
+		if got := test.in.String(); got != test.want {
+			t.Errorf("Decimal(%d).String() = %q, want %q", int64(test.in), got, test.want)
+		}
+	}
+}
+
+func TestDecimalRound(t *testing.T) {
+	tests := []struct {
+		in     string
+		places int
+		want   string
+	}{
+		{in: "1.005", places: 2, want: "1.01"},
+		{in: "1.0049", places: 2, want: "1"},
+		{in: "1.015", places: 2, want: "1.02"},
+		{in: "-1.005", places: 2, want: "-1.01"},
+		{in: "2.5", places: 0, want: "3"},
+		{in: "-2.5", places: 0, want: "-3"},
+		{in: "0.4999", places: 0, want: "0"},
+		{in: "1.2345", places: 4, want: "1.2345"},
+		{in: "1.2345", places: 6, want: "1.2345"},
+		{in: "1.5", places: -1, want: "2"},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDecimal(test.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := d.Round(test.places).String(); got != test.want {
+			t.Errorf("%s.Round(%d) = %s, want %s", test.in, test.places, got, test.want)
+		}
+	}
+}
+
+func TestDecimalPercent(t *testing.T) {
+	tests := []struct {
+		amount, rate, want string
+	}{
+		{amount: "1000", rate: "18", want: "180"},
+		{amount: "99.99", rate: "18", want: "17.9982"},
+		// 0.0001 * 50% is exactly half a unit and rounds away from zero.
+		{amount: "0.0001", rate: "50", want: "0.0001"},
+		{amount: "-0.0001", rate: "50", want: "-0.0001"},
+		{amount: "0.0001", rate: "49.9999", want: "0"},
+		{amount: "1499.99", rate: "12.5", want: "187.4988"},
+		// amount * rate overflows int64 before the division.
+		{amount: "900000000000000", rate: "28", want: "252000000000000"},
+	}
+
+	for _, test := range tests {
+		amount, err := ParseDecimal(test.amount)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rate, err := ParseDecimal(test.rate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := amount.Percent(rate).String(); got != test.want {
+			t.Errorf("%s.Percent(%s) = %s, want %s", test.amount, test.rate, got, test.want)
+		}
+	}
+}
+
+func TestDecimalFromFloat(t *testing.T) {
+	tests := []struct {
+		in   float64
+		want Decimal
+	}{
+		{in: 1499.99, want: 14999900},
+		{in: 0.1 + 0.2, want: 3000},
+		{in: 0.00005, want: 1},
+		{in: -0.00005, want: -1},
+	}
+
+	for _, test := range tests {
+		if got := DecimalFromFloat(test.in); got != test.want {
+			t.Errorf("DecimalFromFloat(%v) = %d, want %d", test.in, got, test.want)
+		}
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var value struct {
+		Price  Decimal  `json:"price"`
+		Quoted Decimal  `json:"quoted"`
+		Null   Decimal  `json:"null"`
+		Ptr    *Decimal `json:"ptr"`
+	}
+	value.Null = 42
+
+	if err := json.Unmarshal([]byte(`{"price": 1499.99, "quoted": "0.5", "null": null, "ptr": 2}`), &value); err != nil {
+		t.Fatal(err)
+	}
+	if value.Price != 14999900 || value.Quoted != 5000 || value.Null != 42 || value.Ptr == nil || *value.Ptr != 20000 {
+		t.Errorf("unmarshalled %+v", value)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"price":1499.99,"quoted":0.5,"null":0.0042,"ptr":2}`; string(data) != want {
+		t.Errorf("marshalled %s, want %s", data, want)
+	}
+
+	if err := json.Unmarshal([]byte(`{"price": 0.00001}`), &value); !errors.Is(err, ErrInvalidDecimal) {
+		t.Errorf("unmarshal of 0.00001: error = %v, want ErrInvalidDecimal", err)
+	}
+}
+
+func TestDecimalScan(t *testing.T) {
+	tests := []struct {
+		src  interface{}
+		want Decimal
+		err  bool
+	}{
+		{src: []byte("1499.9900"), want: 14999900},
+		{src: "12.5", want: 125000},
+		{src: int64(3), want: 30000},
+		{src: 0.25, want: 2500},
+		{src: nil, err: true},
+		{src: true, err: true},
+		{src: "abc", err: true},
+	}
+
+	for _, test := range tests {
+		var d Decimal
+		err := d.Scan(test.src)
+		if test.err {
+			if !errors.Is(err, ErrInvalidDecimal) {
+				t.Errorf("Scan(%#v) error = %v, want ErrInvalidDecimal", test.src, err)
+			}
+			continue
+		}
+		if err != nil || d != test.want {
+			t.Errorf("Scan(%#v) = %d, %v, want %d", test.src, d, err, test.want)
+		}
+	}
+}
+
+// TestRoundMoney checks the per-currency rounding of round_money, see
+// migrations/012_currencies.sql. It needs a database with the migrations
+// applied in TEST_DATABASE_URL and is skipped otherwise.
+func TestRoundMoney(t *testing.T) {
+	dbURL := os.Getenv("TEST_DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := sqlx.Connect("postgres", dbURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	tests := []struct {
+		amount, currency, want string
+	}{
+		// EUR rounds HALF_EVEN to 0.01: ties go to the even cent.
+		{amount: "0.125", currency: "EUR", want: "0.12"},
+		{amount: "0.135", currency: "EUR", want: "0.14"},
+		{amount: "-0.125", currency: "EUR", want: "-0.12"},
+		{amount: "-0.135", currency: "EUR", want: "-0.14"},
+		{amount: "0.1251", currency: "EUR", want: "0.13"},
+		{amount: "2.005", currency: "EUR", want: "2"},
+		{amount: "0.12", currency: "EUR", want: "0.12"},
+		// INR rounds HALF_UP, away from zero.
+		{amount: "0.125", currency: "INR", want: "0.13"},
+		{amount: "-0.125", currency: "INR", want: "-0.13"},
+		{amount: "0.1249", currency: "INR", want: "0.12"},
+		// OMR has an increment of 0.001.
+		{amount: "1.0005", currency: "OMR", want: "1.001"},
+		{amount: "1.0004", currency: "OMR", want: "1"},
+	}
+
+	for _, test := range tests {
+		amount, err := ParseDecimal(test.amount)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := ParseDecimal(test.want)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got Decimal
+		if err := db.Get(&got, "SELECT round_money($1, $2)", amount, test.currency); err != nil {
+			t.Fatalf("round_money(%s, %s): %v", test.amount, test.currency, err)
+		}
+		if got != want {
+			t.Errorf("round_money(%s, %s) = %s, want %s", test.amount, test.currency, got, want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package shared
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MethodScopes maps a full gRPC method name (e.g. "/catalog.v1.BrandService/GetBrand")
+// to the scope the caller must hold. An empty scope only requires a valid token.
+// Methods that are neither listed nor in PublicMethods are denied.
+type MethodScopes map[string]string
+
+// PublicMethods lists the full gRPC method names served without a token.
+type PublicMethods []string
+
+type authServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func UnaryAuthInterceptor(jm *JwtManager, scopes MethodScopes, public PublicMethods) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authorize(ctx, jm, scopes, public, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func StreamAuthInterceptor(jm *JwtManager, scopes MethodScopes, public PublicMethods) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(ss.Context(), jm, scopes, public, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authorize applies the same checks as JWTMiddleware and HasScope, reading the
+// bearer token from the "authorization" metadata key. Unlisted methods are
+// denied so that a newly registered service is never served by accident.
+func authorize(ctx context.Context, jm *JwtManager, scopes MethodScopes, public PublicMethods, method string) (context.Context, error) {
+	if slices.Contains(public, method) {
+		return ctx, nil
+	}
+
+	requiredScope, ok := scopes[method]
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "Forbidden: Method not allowed")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	auth := md.Get("authorization")
+	if len(auth) == 0 || auth[0] == "" {
+		return nil, status.Error(codes.Unauthenticated, "Missing Token")
+	}
+
+	parts := strings.SplitN(auth[0], " ", 2)
+	if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+		return nil, status.Error(codes.Unauthenticated, "Invalid auth token")
+	}
+
+	claims, err := jm.Verify(parts[1])
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "Invalid Token")
+	}
+
+	if requiredScope != "" && !slices.Contains(claims.Permissions, requiredScope) {
+		return nil, status.Error(codes.PermissionDenied, "Forbidden: Missing scope "+requiredScope)
+	}
+
+	return context.WithValue(ctx, UserClaimsKey, claims), nil
+}
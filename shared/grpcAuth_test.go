@@ -0,0 +1,165 @@
+package shared
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testReflectionMethod = "/grpc.reflection.v1.ServerReflection/ServerReflectionInfo"
+	testGetBrandMethod   = "/catalog.v1.BrandService/GetBrand"
+	testListMethod       = "/catalog.v1.ProductService/ListProducts"
+	testUnlistedMethod   = "/catalog.v1.SecretService/Dump"
+)
+
+func testJwtManager(t *testing.T) *JwtManager {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &JwtManager{privateKey: key, publicKey: &key.PublicKey, logger: zap.NewNop()}
+}
+
+func testToken(t *testing.T, jm *JwtManager, permissions string, ttl time.Duration) string {
+	t.Helper()
+	token, err := jm.GenerateToken("user-1", &RolesPermissions{Role: "SELLER", Permissions: permissions}, ttl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func withAuthorization(value string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", value))
+}
+
+func TestAuthorize(t *testing.T) {
+	jm := testJwtManager(t)
+	scopes := MethodScopes{
+		testGetBrandMethod: "catalog:read",
+		testListMethod:     "",
+	}
+	public := PublicMethods{testReflectionMethod}
+
+	valid := testToken(t, jm, "{catalog:read,catalog:update}", time.Hour)
+	noScopes := testToken(t, jm, "{}", time.Hour)
+	expired := testToken(t, jm, "{catalog:read}", -time.Hour)
+	foreign := testToken(t, testJwtManager(t), "{catalog:read}", time.Hour)
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		want   codes.Code
+	}{
+		{name: "public without token", ctx: context.Background(), method: testReflectionMethod, want: codes.OK},
+		{name: "unlisted without token", ctx: context.Background(), method: testUnlistedMethod, want: codes.PermissionDenied},
+		{name: "unlisted with token", ctx: withAuthorization("Bearer " + valid), method: testUnlistedMethod, want: codes.PermissionDenied},
+		{name: "no metadata", ctx: context.Background(), method: testGetBrandMethod, want: codes.Unauthenticated},
+		{name: "empty token", ctx: withAuthorization(""), method: testGetBrandMethod, want: codes.Unauthenticated},
+		{name: "not bearer", ctx: withAuthorization("Basic " + valid), method: testGetBrandMethod, want: codes.Unauthenticated},
+		{name: "garbage token", ctx: withAuthorization("Bearer not-a-jwt"), method: testGetBrandMethod, want: codes.Unauthenticated},
+		{name: "expired token", ctx: withAuthorization("Bearer " + expired), method: testGetBrandMethod, want: codes.Unauthenticated},
+		{name: "token signed by another key", ctx: withAuthorization("Bearer " + foreign), method: testGetBrandMethod, want: codes.Unauthenticated},
+		{name: "missing scope", ctx: withAuthorization("Bearer " + noScopes), method: testGetBrandMethod, want: codes.PermissionDenied},
+		{name: "scope held", ctx: withAuthorization("bearer " + valid), method: testGetBrandMethod, want: codes.OK},
+		{name: "token only", ctx: withAuthorization("Bearer " + noScopes), method: testListMethod, want: codes.OK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := authorize(test.ctx, jm, scopes, public, test.method)
+			if got := status.Code(err); got != test.want {
+				t.Fatalf("code = %s (%v), want %s", got, err, test.want)
+			}
+		})
+	}
+}
+
+func TestUnaryAuthInterceptor(t *testing.T) {
+	jm := testJwtManager(t)
+	interceptor := UnaryAuthInterceptor(jm, MethodScopes{testGetBrandMethod: "catalog:read"}, PublicMethods{testReflectionMethod})
+
+	var claims *UserClaims
+	handler := func(ctx context.Context, req any) (any, error) {
+		claims, _ = ctx.Value(UserClaimsKey).(*UserClaims)
+		return "ok", nil
+	}
+
+	ctx := withAuthorization("Bearer " + testToken(t, jm, "{catalog:read}", time.Hour))
+	resp, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: testGetBrandMethod}, handler)
+	if err != nil || resp != "ok" {
+		t.Fatalf("got %v, %v, want ok", resp, err)
+	}
+	if claims == nil || claims.UserID != "user-1" {
+		t.Fatalf("handler saw claims %+v, want user-1", claims)
+	}
+
+	called := false
+	denied := func(ctx context.Context, req any) (any, error) {
+		called = true
+		return nil, nil
+	}
+	_, err = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: testUnlistedMethod}, denied)
+	if status.Code(err) != codes.PermissionDenied || called {
+		t.Fatalf("unlisted method: code %s, handler called %v", status.Code(err), called)
+	}
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestStreamAuthInterceptor(t *testing.T) {
+	jm := testJwtManager(t)
+	interceptor := StreamAuthInterceptor(jm, MethodScopes{testGetBrandMethod: "catalog:read"}, PublicMethods{testReflectionMethod})
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		want   codes.Code
+	}{
+		{name: "public reflection", ctx: context.Background(), method: testReflectionMethod, want: codes.OK},
+		{name: "unlisted", ctx: context.Background(), method: testUnlistedMethod, want: codes.PermissionDenied},
+		{name: "missing token", ctx: context.Background(), method: testGetBrandMethod, want: codes.Unauthenticated},
+		{name: "missing scope", ctx: withAuthorization("Bearer " + testToken(t, jm, "{catalog:update}", time.Hour)), method: testGetBrandMethod, want: codes.PermissionDenied},
+		{name: "scope held", ctx: withAuthorization("Bearer " + testToken(t, jm, "{catalog:read}", time.Hour)), method: testGetBrandMethod, want: codes.OK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			called := false
+			handler := func(srv any, stream grpc.ServerStream) error {
+				called = true
+				if _, ok := stream.Context().Value(UserClaimsKey).(*UserClaims); !ok && test.method != testReflectionMethod {
+					t.Error("handler stream carries no claims")
+				}
+				return nil
+			}
+
+			err := interceptor(nil, &testServerStream{ctx: test.ctx}, &grpc.StreamServerInfo{FullMethod: test.method}, handler)
+			if got := status.Code(err); got != test.want {
+				t.Fatalf("code = %s (%v), want %s", got, err, test.want)
+			}
+			if called != (test.want == codes.OK) {
+				t.Fatalf("handler called = %v", called)
+			}
+		})
+	}
+}
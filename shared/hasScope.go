@@ -1,8 +1,10 @@
 package shared
 
 import (
+	"context"
 	"log"
 	"net/http"
+	"slices"
 
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -14,6 +16,21 @@ type UserClaims struct {
 	Token       *jwt.Token
 }
 
+// ReviewScope is held by catalog reviewers, marketplace admins who act on any
+// seller's products.
+const ReviewScope = "catalog:review"
+
+// CanManageProduct reports whether the user making the request may change a
+// product sold by sellerID, along with its documents, certificates and
+// prices: its seller, or a catalog reviewer.
+func CanManageProduct(ctx context.Context, sellerID string) bool {
+	claims, ok := ctx.Value(UserClaimsKey).(*UserClaims)
+	if !ok {
+		return false
+	}
+	return (claims.UserID != "" && claims.UserID == sellerID) || slices.Contains(claims.Permissions, ReviewScope)
+}
+
 func HasScope(requriedScope string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
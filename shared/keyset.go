@@ -0,0 +1,140 @@
+package shared
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// SortKey is one column of a keyset ordering. Expr may contain ? placeholders
+// bound to Args; Type is the SQL type cursor values are cast back to.
+type SortKey struct {
+	Expr string
+	Args []interface{}
+	Type string
+	Desc bool
+}
+
+// Cursor is the opaque position handed to clients as next_cursor/prev_cursor.
+// Values are the text form of the sort keys of the boundary row, so they
+// compare exactly once cast back to their SQL type.
+type Cursor struct {
+	Sort     string    `json:"s"`
+	Backward bool      `json:"b,omitempty"`
+	Values   []*string `json:"v"`
+}
+
+func EncodeCursor(cursor Cursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(encoded string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// KeysetValues returns a select expression that collects the sort key values
+// of a row as a JSON array of text, ready to be turned into a Cursor.
+func KeysetValues(keys []SortKey) (string, []interface{}) {
+	parts := make([]string, len(keys))
+	var args []interface{}
+	for i, key := range keys {
+		parts[i] = fmt.Sprintf("(%s)::text", key.Expr)
+		args = append(args, key.Args...)
+	}
+	return "jsonb_build_array(" + strings.Join(parts, ", ") + ")", args
+}
+
+// KeysetCondition returns an " AND (...)" clause selecting the rows after the
+// cursor in the keys' order, or before it when backward is set. Equality uses
+// IS NOT DISTINCT FROM so NULL sort values are handled by a preceding
+// "expr IS NULL" key.
+func KeysetCondition(keys []SortKey, values []*string, backward bool) (string, []interface{}, error) {
+	if len(values) != len(keys) {
+		return "", nil, ErrInvalidCursor
+	}
+
+	var branches []string
+	var args []interface{}
+	for i, key := range keys {
+		var terms []string
+		for j := 0; j < i; j++ {
+			terms = append(terms, fmt.Sprintf("(%s) IS NOT DISTINCT FROM ?::%s", keys[j].Expr, keys[j].Type))
+			args = append(args, keys[j].Args...)
+			args = append(args, values[j])
+		}
+
+		op := ">"
+		if key.Desc != backward {
+			op = "<"
+		}
+		terms = append(terms, fmt.Sprintf("(%s) %s ?::%s", key.Expr, op, key.Type))
+		args = append(args, key.Args...)
+		args = append(args, values[i])
+
+		branches = append(branches, "("+strings.Join(terms, " AND ")+")")
+	}
+
+	return " AND (" + strings.Join(branches, " OR ") + ")", args, nil
+}
+
+// KeysetOrder returns the ORDER BY clause for the keys, reversed when reading
+// backward from a cursor.
+func KeysetOrder(keys []SortKey, backward bool) (string, []interface{}) {
+	parts := make([]string, len(keys))
+	var args []interface{}
+	for i, key := range keys {
+		dir := "ASC"
+		if key.Desc != backward {
+			dir = "DESC"
+		}
+		parts[i] = fmt.Sprintf("%s %s", key.Expr, dir)
+		args = append(args, key.Args...)
+	}
+	return " ORDER BY " + strings.Join(parts, ", "), args
+}
+
+// Count modes for list endpoints.
+const (
+	CountExact     = "exact"
+	CountEstimated = "estimated"
+	CountNone      = "none"
+)
+
+var CountModes = []string{CountExact, CountEstimated, CountNone}
+
+// EstimateCount returns the planner's row estimate for query, which is cheap
+// on large result sets where COUNT(*) is not.
+func EstimateCount(ctx context.Context, db *sqlx.DB, query string, args ...interface{}) (int, error) {
+	var plan []byte
+	if err := db.QueryRowContext(ctx, "EXPLAIN (FORMAT JSON) "+query, args...).Scan(&plan); err != nil {
+		return 0, PostgresError(err)
+	}
+
+	var explain []struct {
+		Plan struct {
+			PlanRows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal(plan, &explain); err != nil || len(explain) == 0 {
+		return 0, fmt.Errorf("failed to read query plan: %v", err)
+	}
+
+	return int(explain[0].Plan.PlanRows), nil
+}
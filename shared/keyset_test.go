@@ -0,0 +1,112 @@
+package shared
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursors := []Cursor{
+		{Sort: "newest", Values: []*string{stringPtr("2026-03-01 12:00:00.123456"), stringPtr("5b1f0e7a-6a2d-4c4e-9d4b-1f2e3a4b5c6d")}},
+		{Sort: "price_asc", Backward: true, Values: []*string{nil, stringPtr("1499.99"), stringPtr("id")}},
+		{Sort: "name", Values: []*string{stringPtr("Gants \"nitrile\" / 安全")}},
+		{Sort: "relevance", Values: []*string{}},
+	}
+
+	for _, cursor := range cursors {
+		encoded := EncodeCursor(cursor)
+		decoded, err := DecodeCursor(encoded)
+		if err != nil {
+			t.Fatalf("DecodeCursor(%q): %v", encoded, err)
+		}
+		if !reflect.DeepEqual(*decoded, cursor) {
+			t.Errorf("round trip of %+v gave %+v", cursor, *decoded)
+		}
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	for _, encoded := range []string{
+		"not base64!",
+		// Padded base64 is not accepted.
+		EncodeCursor(Cursor{Sort: "name"}) + "=",
+		// Valid base64 of "not json".
+		"bm90IGpzb24",
+		// Valid base64 of {"v":1}.
+		"eyJ2IjoxfQ",
+	} {
+		if _, err := DecodeCursor(encoded); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("DecodeCursor(%q) error = %v, want ErrInvalidCursor", encoded, err)
+		}
+	}
+}
+
+func TestKeysetCondition(t *testing.T) {
+	keys := []SortKey{
+		{Expr: "price IS NULL", Type: "boolean"},
+		{Expr: "convert_price(price, ?)", Args: []interface{}{"EUR"}, Type: "numeric", Desc: true},
+		{Expr: "id", Type: "uuid"},
+	}
+	values := []*string{stringPtr("false"), stringPtr("10.5"), stringPtr("abc")}
+
+	tests := []struct {
+		backward bool
+		want     string
+	}{
+		{
+			backward: false,
+			want: " AND (((price IS NULL) > ?::boolean)" +
+				" OR ((price IS NULL) IS NOT DISTINCT FROM ?::boolean AND (convert_price(price, ?)) < ?::numeric)" +
+				" OR ((price IS NULL) IS NOT DISTINCT FROM ?::boolean AND (convert_price(price, ?)) IS NOT DISTINCT FROM ?::numeric AND (id) > ?::uuid))",
+		},
+		{
+			backward: true,
+			want: " AND (((price IS NULL) < ?::boolean)" +
+				" OR ((price IS NULL) IS NOT DISTINCT FROM ?::boolean AND (convert_price(price, ?)) > ?::numeric)" +
+				" OR ((price IS NULL) IS NOT DISTINCT FROM ?::boolean AND (convert_price(price, ?)) IS NOT DISTINCT FROM ?::numeric AND (id) < ?::uuid))",
+		},
+	}
+
+	wantArgs := []interface{}{values[0], values[0], "EUR", values[1], values[0], "EUR", values[1], values[2]}
+	for _, test := range tests {
+		got, args, err := KeysetCondition(keys, values, test.backward)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.want {
+			t.Errorf("backward=%v:\n got %s\nwant %s", test.backward, got, test.want)
+		}
+		if !reflect.DeepEqual(args, wantArgs) {
+			t.Errorf("backward=%v: args = %v, want %v", test.backward, args, wantArgs)
+		}
+	}
+
+	if _, _, err := KeysetCondition(keys, values[:2], false); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("KeysetCondition with too few values: error = %v, want ErrInvalidCursor", err)
+	}
+}
+
+func TestKeysetOrder(t *testing.T) {
+	keys := []SortKey{
+		{Expr: "rating_average", Type: "numeric", Desc: true},
+		{Expr: "similarity(name, ?)", Args: []interface{}{"glove"}, Type: "real", Desc: true},
+		{Expr: "id", Type: "uuid"},
+	}
+
+	order, args := KeysetOrder(keys, false)
+	if want := " ORDER BY rating_average DESC, similarity(name, ?) DESC, id ASC"; order != want {
+		t.Errorf("order = %q, want %q", order, want)
+	}
+	if !reflect.DeepEqual(args, []interface{}{"glove"}) {
+		t.Errorf("args = %v", args)
+	}
+
+	if order, _ := KeysetOrder(keys, true); order != " ORDER BY rating_average ASC, similarity(name, ?) ASC, id DESC" {
+		t.Errorf("backward order = %q", order)
+	}
+}
@@ -42,3 +42,22 @@ func JWTMiddleware(jm *JwtManager) func(next http.Handler) http.Handler {
 		return http.HandlerFunc(fn)
 	}
 }
+
+// OptionalJWTMiddleware lets anonymous requests through and attaches the
+// claims of a bearer token when one is sent, so public routes can tailor their
+// response (e.g. contract prices) to the signed-in user. A token that does not
+// verify is still rejected.
+func OptionalJWTMiddleware(jm *JwtManager) func(next http.Handler) http.Handler {
+	required := JWTMiddleware(jm)
+	return func(next http.Handler) http.Handler {
+		withClaims := required(next)
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			withClaims.ServeHTTP(w, r)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
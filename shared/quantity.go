@@ -0,0 +1,162 @@
+package shared
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// DefaultUnit is the sellable unit of variants that do not name one.
+const DefaultUnit = "PC"
+
+var (
+	ErrInvalidOrderRules = errors.New("invalid order rules")
+	ErrInvalidQuantity   = errors.New("invalid quantity")
+	ErrInvalidPackSizes  = errors.New("invalid pack sizes")
+)
+
+// PackSize is a pack a variant is also sold in, holding Quantity of its
+// base unit, e.g. a BOX of 12 PAIR.
+type PackSize struct {
+	Unit     string `json:"unit" validate:"required,max=10,uppercase"`
+	Quantity int    `json:"quantity" validate:"gt=1"`
+}
+
+// PackSizes reads the JSON array the variant queries aggregate pack sizes to.
+type PackSizes []PackSize
+
+func (p *PackSizes) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*p = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(value, p)
+	case string:
+		return json.Unmarshal([]byte(value), p)
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidPackSizes, src)
+	}
+}
+
+// String formats pack sizes as UNIT=quantity pairs separated by |, e.g.
+// "BOX=12|CASE=144", the form used in CSV files.
+func (p PackSizes) String() string {
+	parts := make([]string, 0, len(p))
+	for _, pack := range p {
+		parts = append(parts, pack.Unit+"="+strconv.Itoa(pack.Quantity))
+	}
+	return strings.Join(parts, "|")
+}
+
+// ParsePackSizes reads the form written by PackSizes.String.
+func ParsePackSizes(value string) (PackSizes, error) {
+	var packs PackSizes
+	for _, part := range strings.Split(value, "|") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		unit, quantity, ok := strings.Cut(part, "=")
+		n, err := strconv.Atoi(strings.TrimSpace(quantity))
+		if !ok || err != nil {
+			return nil, fmt.Errorf("%w: %q is not UNIT=quantity", ErrInvalidPackSizes, part)
+		}
+		packs = append(packs, PackSize{Unit: strings.ToUpper(strings.TrimSpace(unit)), Quantity: n})
+	}
+	return packs, nil
+}
+
+// OrderRules are the quantity rules of a variant. Prices, MinOrderQuantity
+// and OrderMultiple are per base Unit; PackSizes convert larger packs to it.
+// Zero values mean the defaults: DefaultUnit, and a minimum and multiple of 1.
+type OrderRules struct {
+	Unit             string    `db:"unit" json:"unit,omitempty" validate:"omitempty,max=10,uppercase"`
+	MinOrderQuantity int       `db:"min_order_quantity" json:"min_order_quantity,omitempty" validate:"gte=0"`
+	OrderMultiple    int       `db:"order_multiple" json:"order_multiple,omitempty" validate:"gte=0"`
+	PackSizes        PackSizes `db:"pack_sizes" json:"pack_sizes,omitempty" validate:"omitempty,dive"`
+}
+
+// Normalized returns the rules with the defaults filled in.
+func (r OrderRules) Normalized() OrderRules {
+	if r.Unit == "" {
+		r.Unit = DefaultUnit
+	}
+	r.MinOrderQuantity = max(r.MinOrderQuantity, 1)
+	r.OrderMultiple = max(r.OrderMultiple, 1)
+	return r
+}
+
+// Validate checks the rules themselves: pack units must differ from the base
+// unit and from each other, and hold more than one base unit.
+func (r OrderRules) Validate() error {
+	r = r.Normalized()
+	units := []string{r.Unit}
+	for _, pack := range r.PackSizes {
+		if pack.Unit == "" || slices.Contains(units, pack.Unit) {
+			return fmt.Errorf("%w: pack unit %q is empty or listed more than once", ErrInvalidOrderRules, pack.Unit)
+		}
+		if pack.Quantity <= 1 {
+			return fmt.Errorf("%w: a %s must hold more than one %s", ErrInvalidOrderRules, pack.Unit, r.Unit)
+		}
+		units = append(units, pack.Unit)
+	}
+	return nil
+}
+
+// ValidateOrderQuantity checks quantity units of unit (the base unit when
+// empty) against the rules and returns the quantity in base units. Every
+// quote, cart or order flow should go through it so the same quantities are
+// accepted everywhere.
+func ValidateOrderQuantity(rules OrderRules, quantity int, unit string) (int, error) {
+	rules = rules.Normalized()
+	if quantity < 1 {
+		return 0, fmt.Errorf("%w: quantity must be at least 1", ErrInvalidQuantity)
+	}
+
+	factor := 1
+	if unit = strings.ToUpper(strings.TrimSpace(unit)); unit != "" && unit != rules.Unit {
+		i := slices.IndexFunc(rules.PackSizes, func(pack PackSize) bool { return pack.Unit == unit })
+		if i < 0 {
+			sold := []string{rules.Unit}
+			for _, pack := range rules.PackSizes {
+				sold = append(sold, pack.Unit)
+			}
+			return 0, fmt.Errorf("%w: not sold per %s, units: %s", ErrInvalidQuantity, unit, strings.Join(sold, ", "))
+		}
+		factor = rules.PackSizes[i].Quantity
+	}
+
+	if quantity > math.MaxInt32/factor {
+		return 0, fmt.Errorf("%w: quantity is too large", ErrInvalidQuantity)
+	}
+
+	base := quantity * factor
+	if base < rules.MinOrderQuantity {
+		return 0, fmt.Errorf("%w: the minimum order quantity is %d %s", ErrInvalidQuantity, rules.MinOrderQuantity, rules.Unit)
+	}
+	if base%rules.OrderMultiple != 0 {
+		return 0, fmt.Errorf("%w: %d %s is not a multiple of %d %s", ErrInvalidQuantity, base, rules.Unit, rules.OrderMultiple, rules.Unit)
+	}
+	return base, nil
+}
+
+// VariantOrderRules loads the order rules of a variant.
+func VariantOrderRules(ctx context.Context, q sqlx.QueryerContext, variantID string) (*OrderRules, error) {
+	var rules OrderRules
+	query := "SELECT unit, min_order_quantity, order_multiple, pack_sizes(id) AS pack_sizes FROM product_variants WHERE id = $1"
+	if err := sqlx.GetContext(ctx, q, &rules, query, variantID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: variant %s not found", ErrInvalidQuantity, variantID)
+		}
+		return nil, PostgresError(err)
+	}
+	return &rules, nil
+}
@@ -0,0 +1,104 @@
+package shared
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestValidateOrderQuantity(t *testing.T) {
+	gloves := OrderRules{
+		Unit:             "PAIR",
+		MinOrderQuantity: 24,
+		OrderMultiple:    12,
+		PackSizes:        PackSizes{{Unit: "BOX", Quantity: 12}, {Unit: "CASE", Quantity: 144}},
+	}
+
+	tests := []struct {
+		name     string
+		rules    OrderRules
+		quantity int
+		unit     string
+		want     int
+		err      bool
+	}{
+		{name: "defaults", rules: OrderRules{}, quantity: 1, want: 1},
+		{name: "default unit named", rules: OrderRules{}, quantity: 3, unit: "pc", want: 3},
+		{name: "zero", rules: OrderRules{}, quantity: 0, err: true},
+		{name: "negative", rules: OrderRules{}, quantity: -5, err: true},
+		{name: "at minimum", rules: gloves, quantity: 24, want: 24},
+		{name: "below minimum", rules: gloves, quantity: 12, err: true},
+		{name: "not a multiple", rules: gloves, quantity: 30, err: true},
+		{name: "multiple", rules: gloves, quantity: 36, unit: "PAIR", want: 36},
+		{name: "boxes", rules: gloves, quantity: 2, unit: "BOX", want: 24},
+		{name: "boxes lower case", rules: gloves, quantity: 3, unit: " box ", want: 36},
+		{name: "one box below minimum", rules: gloves, quantity: 1, unit: "BOX", err: true},
+		{name: "case", rules: gloves, quantity: 1, unit: "CASE", want: 144},
+		{name: "unknown unit", rules: gloves, quantity: 24, unit: "PC", err: true},
+		{name: "overflow", rules: gloves, quantity: math.MaxInt32/144 + 1, unit: "CASE", err: true},
+		{name: "largest", rules: OrderRules{}, quantity: math.MaxInt32, want: math.MaxInt32},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ValidateOrderQuantity(test.rules, test.quantity, test.unit)
+			if test.err {
+				if !errors.Is(err, ErrInvalidQuantity) {
+					t.Fatalf("error = %v, want ErrInvalidQuantity", err)
+				}
+				return
+			}
+			if err != nil || got != test.want {
+				t.Fatalf("got %d, %v, want %d", got, err, test.want)
+			}
+		})
+	}
+}
+
+func TestOrderRulesValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules OrderRules
+		err   bool
+	}{
+		{name: "defaults", rules: OrderRules{}},
+		{name: "packs", rules: OrderRules{Unit: "PAIR", PackSizes: PackSizes{{Unit: "BOX", Quantity: 12}, {Unit: "CASE", Quantity: 144}}}},
+		{name: "pack of the base unit", rules: OrderRules{Unit: "PAIR", PackSizes: PackSizes{{Unit: "PAIR", Quantity: 2}}}, err: true},
+		{name: "pack of the default unit", rules: OrderRules{PackSizes: PackSizes{{Unit: DefaultUnit, Quantity: 10}}}, err: true},
+		{name: "duplicate pack", rules: OrderRules{PackSizes: PackSizes{{Unit: "BOX", Quantity: 10}, {Unit: "BOX", Quantity: 20}}}, err: true},
+		{name: "empty pack unit", rules: OrderRules{PackSizes: PackSizes{{Quantity: 10}}}, err: true},
+		{name: "pack of one", rules: OrderRules{PackSizes: PackSizes{{Unit: "BOX", Quantity: 1}}}, err: true},
+	}
+
+	for _, test := range tests {
+		err := test.rules.Validate()
+		if test.err != errors.Is(err, ErrInvalidOrderRules) {
+			t.Errorf("%s: error = %v, want error %v", test.name, err, test.err)
+		}
+	}
+}
+
+func TestParsePackSizes(t *testing.T) {
+	packs, err := ParsePackSizes(" box = 12 | CASE=144 |")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := PackSizes{{Unit: "BOX", Quantity: 12}, {Unit: "CASE", Quantity: 144}}
+	if !reflect.DeepEqual(packs, want) {
+		t.Errorf("ParsePackSizes = %+v, want %+v", packs, want)
+	}
+	if got := packs.String(); got != "BOX=12|CASE=144" {
+		t.Errorf("String() = %q", got)
+	}
+
+	if packs, err := ParsePackSizes(""); err != nil || packs != nil {
+		t.Errorf("ParsePackSizes(\"\") = %+v, %v, want none", packs, err)
+	}
+
+	for _, value := range []string{"BOX", "BOX=twelve", "BOX=12|CASE"} {
+		if _, err := ParsePackSizes(value); !errors.Is(err, ErrInvalidPackSizes) {
+			t.Errorf("ParsePackSizes(%q) error = %v, want ErrInvalidPackSizes", value, err)
+		}
+	}
+}
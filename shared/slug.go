@@ -0,0 +1,275 @@
+package shared
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode"
+
+	"github.com/jmoiron/sqlx"
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	ErrSlugTaken   = errors.New("slug is already in use")
+	ErrInvalidSlug = errors.New("slug must contain at least one letter or digit")
+)
+
+// maxSlugLength keeps generated slugs readable; longer names are cut at a word.
+const maxSlugLength = 100
+
+// transliterations covers letters that do not decompose into ASCII under NFKD.
+var transliterations = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d", 'ł': "l", 'þ': "th", 'ı': "i", '&': " and ",
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z", 'и': "i",
+	'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t",
+	'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "",
+	'э': "e", 'ю': "yu", 'я': "ya",
+	// Arabic, with the Persian and Urdu letters. Short vowels are marks and
+	// are dropped; hamza forms decompose to their seat under NFKD.
+	'ا': "a", 'ب': "b", 'ت': "t", 'ث': "th", 'ج': "j", 'ح': "h", 'خ': "kh", 'د': "d", 'ذ': "dh", 'ر': "r",
+	'ز': "z", 'س': "s", 'ش': "sh", 'ص': "s", 'ض': "d", 'ط': "t", 'ظ': "z", 'ع': "", 'غ': "gh", 'ف': "f",
+	'ق': "q", 'ك': "k", 'ل': "l", 'م': "m", 'ن': "n", 'ه': "h", 'و': "w", 'ي': "y", 'ى': "a", 'ة': "a",
+	'ء': "", 'ـ': "", 'پ': "p", 'چ': "ch", 'ژ': "zh", 'گ': "g", 'ک': "k", 'ی': "y", 'ٹ': "t", 'ڈ': "d",
+	'ڑ': "r", 'ں': "n", 'ہ': "h", 'ھ': "h", 'ے': "e",
+	'٠': "0", '١': "1", '٢': "2", '٣': "3", '٤': "4", '٥': "5", '٦': "6", '٧': "7", '٨': "8", '٩': "9",
+	'۰': "0", '۱': "1", '۲': "2", '۳': "3", '۴': "4", '۵': "5", '۶': "6", '۷': "7", '۸': "8", '۹': "9",
+}
+
+// Devanagari consonants carry an inherent "a" that a vowel sign replaces and
+// a virama removes, so they are transliterated by devanagariToLatin rather
+// than rune by rune.
+var (
+	devanagariConsonants = map[rune]string{
+		'क': "k", 'ख': "kh", 'ग': "g", 'घ': "gh", 'ङ': "n", 'च': "ch", 'छ': "chh", 'ज': "j", 'झ': "jh", 'ञ': "n",
+		'ट': "t", 'ठ': "th", 'ड': "d", 'ढ': "dh", 'ण': "n", 'त': "t", 'थ': "th", 'द': "d", 'ध': "dh", 'न': "n",
+		'ऩ': "n", 'प': "p", 'फ': "ph", 'ब': "b", 'भ': "bh", 'म': "m", 'य': "y", 'र': "r", 'ऱ': "r", 'ल': "l",
+		'ळ': "l", 'ऴ': "l", 'व': "v", 'श': "sh", 'ष': "sh", 'स': "s", 'ह': "h",
+	}
+	devanagariVowelSigns = map[rune]string{
+		'ा': "a", 'ि': "i", 'ी': "i", 'ु': "u", 'ू': "u", 'ृ': "ri", 'ॄ': "ri", 'ॅ': "e", 'ॆ': "e", 'े': "e",
+		'ै': "ai", 'ॉ': "o", 'ॊ': "o", 'ो': "o", 'ौ': "au", '्': "",
+	}
+	devanagariLetters = map[rune]string{
+		'अ': "a", 'आ': "a", 'इ': "i", 'ई': "i", 'उ': "u", 'ऊ': "u", 'ऋ': "ri", 'ऍ': "e", 'ऎ': "e", 'ए': "e",
+		'ऐ': "ai", 'ऑ': "o", 'ऒ': "o", 'ओ': "o", 'औ': "au", 'ं': "n", 'ँ': "n", 'ः': "h", 'ॐ': "om",
+		'०': "0", '१': "1", '२': "2", '३': "3", '४': "4", '५': "5", '६': "6", '७': "7", '८': "8", '९': "9",
+	}
+)
+
+// devanagariNukta marks borrowed sounds (क़, ज़, ...) and is dropped.
+const devanagariNukta = '\u093C'
+
+// devanagariToLatin transliterates the Devanagari in s and leaves anything
+// else as is. The inherent vowel is dropped at the end of a word, as Hindi
+// does, so "भारत" becomes "bharat".
+func devanagariToLatin(s string) string {
+	var b strings.Builder
+	inherent, word := false, false
+
+	for _, r := range s {
+		if r == devanagariNukta {
+			continue
+		}
+		if sign, ok := devanagariVowelSigns[r]; ok {
+			if inherent || word {
+				b.WriteString(sign)
+			}
+			inherent = false
+			continue
+		}
+
+		consonant, isConsonant := devanagariConsonants[r]
+		letter, isLetter := devanagariLetters[r]
+		if inherent && (isConsonant || isLetter) {
+			b.WriteByte('a')
+		}
+		inherent = false
+
+		switch {
+		case isConsonant:
+			b.WriteString(consonant)
+			inherent, word = true, true
+		case isLetter:
+			b.WriteString(letter)
+			word = true
+		default:
+			b.WriteRune(r)
+			word = false
+		}
+	}
+
+	return b.String()
+}
+
+// Slugify turns a name into a URL slug: accents are stripped, other scripts
+// transliterated and anything that is not a letter or digit becomes a single
+// dash, so "Safety Shoes – Größe 42" becomes "safety-shoes-grosse-42". Names
+// in scripts without a transliteration give an empty slug.
+func Slugify(name string) string {
+	var b strings.Builder
+	pendingSep := false
+
+	var emit func(r rune)
+	emit = func(r rune) {
+		if t, ok := transliterations[r]; ok {
+			for _, tr := range t {
+				emit(tr)
+			}
+			return
+		}
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if pendingSep && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingSep = false
+			b.WriteRune(r)
+			return
+		}
+		pendingSep = true
+	}
+
+	for _, r := range strings.ToLower(devanagariToLatin(norm.NFKD.String(name))) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		emit(r)
+	}
+
+	slug := b.String()
+	if len(slug) > maxSlugLength {
+		slug = slug[:maxSlugLength]
+		if i := strings.LastIndexByte(slug, '-'); i > 0 {
+			slug = slug[:i]
+		}
+	}
+
+	return slug
+}
+
+// UniqueSlug picks the slug of a row in table. An explicit slug is normalized
+// and must not belong to another row. Without one, the slug is generated from
+// name and suffixed with -2, -3, ... until it is free; slugs kept in
+// slug_history count as taken so old links keep redirecting. A name Slugify
+// makes nothing of, such as one in Chinese or only symbols, gets a generated
+// slug. excludeID is the row being updated, or empty on insert.
+func UniqueSlug(ctx context.Context, q sqlx.QueryerContext, table, name, slug, excludeID string) (string, error) {
+	if slug != "" {
+		slug = Slugify(slug)
+		if slug == "" {
+			return "", ErrInvalidSlug
+		}
+
+		var taken bool
+		query := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE slug = $1 AND id::text <> $2)", table)
+		if err := sqlx.GetContext(ctx, q, &taken, query, slug, excludeID); err != nil {
+			return "", PostgresError(err)
+		}
+		if taken {
+			return "", fmt.Errorf("%w: %s", ErrSlugTaken, slug)
+		}
+		return slug, nil
+	}
+
+	base := Slugify(name)
+	if base == "" {
+		if strings.TrimSpace(name) == "" {
+			return "", ErrInvalidSlug
+		}
+		base = generatedSlug(name)
+	}
+
+	var existing []string
+	query := fmt.Sprintf(`SELECT slug FROM %s WHERE (slug = $1 OR slug LIKE $1 || '-%%') AND id::text <> $2
+		UNION SELECT slug FROM slug_history WHERE entity_type = $3 AND (slug = $1 OR slug LIKE $1 || '-%%') AND entity_id::text <> $2`, table)
+	if err := sqlx.SelectContext(ctx, q, &existing, query, base, excludeID, table); err != nil {
+		return "", PostgresError(err)
+	}
+
+	taken := make(map[string]bool, len(existing))
+	for _, s := range existing {
+		taken[s] = true
+	}
+
+	candidate := base
+	for n := 2; taken[candidate]; n++ {
+		candidate = fmt.Sprintf("%s-%d", base, n)
+	}
+
+	return candidate, nil
+}
+
+// SlugMovedError is returned by slug lookups when From is an old slug of a
+// row whose current slug is Slug.
+type SlugMovedError struct {
+	From string
+	Slug string
+}
+
+func (e *SlugMovedError) Error() string {
+	return fmt.Sprintf("slug %q has moved to %q", e.From, e.Slug)
+}
+
+// MovedSlug checks slug_history for an old slug of a row in table and returns
+// a *SlugMovedError pointing at its current slug, or sql.ErrNoRows.
+func MovedSlug(ctx context.Context, q sqlx.QueryerContext, table, slug string) error {
+	var current string
+	query := fmt.Sprintf("SELECT t.slug FROM slug_history h JOIN %s t ON t.id = h.entity_id WHERE h.entity_type = $1 AND h.slug = $2", table)
+	if err := sqlx.GetContext(ctx, q, &current, query, table, slug); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		return PostgresError(err)
+	}
+
+	return &SlugMovedError{From: slug, Slug: current}
+}
+
+// WriteMoved answers with 301 Moved Permanently, replacing the old slug in the
+// request path to build the Location of the current one.
+func WriteMoved(w http.ResponseWriter, r *http.Request, moved *SlugMovedError) {
+	segments := strings.Split(r.URL.Path, "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if segments[i] == moved.From {
+			segments[i] = moved.Slug
+			break
+		}
+	}
+	location := (&url.URL{Path: strings.Join(segments, "/"), RawQuery: r.URL.RawQuery}).String()
+
+	w.Header().Set("Location", location)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusMovedPermanently)
+	json.NewEncoder(w).Encode(map[string]string{
+		"status":   "moved",
+		"slug":     moved.Slug,
+		"location": location,
+	})
+}
+
+// SlugErrorStatus maps slug errors to HTTP status codes, and any other error
+// to fallback.
+func SlugErrorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, ErrInvalidSlug):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrSlugTaken), errors.Is(err, ErrUniqueViolation):
+		return http.StatusConflict
+	}
+	return fallback
+}
+
+// generatedSlug stands in for the slug of a name that Slugify cannot
+// transliterate, such as one in Chinese or made of symbols. It is derived from the name so the
+// same name always starts from the same slug.
+func generatedSlug(name string) string {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return fmt.Sprintf("n-%08x", h.Sum32())
+}
@@ -0,0 +1,181 @@
+package shared
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Safety Shoes – Größe 42", want: "safety-shoes-grosse-42"},
+		{name: "Crème Brûlée", want: "creme-brulee"},
+		{name: "Łódź Ærø", want: "lodz-aero"},
+		{name: "  Hard Hat (EN 397)  ", want: "hard-hat-en-397"},
+		{name: "Ear & Eye", want: "ear-and-eye"},
+		{name: "ﬁre ext. ⅔", want: "fire-ext-2-3"},
+		{name: "Перчатки", want: "perchatki"},
+		{name: "सुरक्षा जूते", want: "suraksha-jute"},
+		{name: "भारत", want: "bharat"},
+		{name: "हिंदी", want: "hindi"},
+		{name: "कृषि", want: "krishi"},
+		{name: "ज़ीरो २०२४", want: "jiro-2024"},
+		{name: "خوذة أمان ٢٠٢٤", want: "khwdha-aman-2024"},
+		{name: "安全靴", want: ""},
+		{name: "★ !!! ★", want: ""},
+		{name: "", want: ""},
+	}
+
+	for _, test := range tests {
+		if got := Slugify(test.name); got != test.want {
+			t.Errorf("Slugify(%q) = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestSlugifyLength(t *testing.T) {
+	slug := Slugify(strings.Repeat("gloves ", 30))
+	if len(slug) > maxSlugLength || strings.HasSuffix(slug, "-") || !strings.HasSuffix(slug, "gloves") {
+		t.Errorf("Slugify of a long name = %q (%d bytes)", slug, len(slug))
+	}
+}
+
+func TestDevanagariToLatin(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{in: "भारत", want: "bharat"},
+		// A virama drops the inherent vowel inside a word.
+		{in: "सुरक्षा", want: "suraksha"},
+		{in: "कमल", want: "kamal"},
+		{in: "आम", want: "am"},
+		{in: "Hindi: हिंदी!", want: "Hindi: hindi!"},
+		{in: "plain text", want: "plain text"},
+	}
+
+	for _, test := range tests {
+		if got := devanagariToLatin(test.in); got != test.want {
+			t.Errorf("devanagariToLatin(%q) = %q, want %q", test.in, got, test.want)
+		}
+	}
+}
+
+// slugDB is a database/sql driver answering the queries of UniqueSlug from a
+// fixed set of taken slugs.
+type slugDB struct {
+	taken []string
+}
+
+func (d *slugDB) Connect(context.Context) (driver.Conn, error) { return d, nil }
+func (d *slugDB) Driver() driver.Driver                        { return nil }
+func (d *slugDB) Prepare(string) (driver.Stmt, error)          { return nil, errors.New("not supported") }
+func (d *slugDB) Close() error                                 { return nil }
+func (d *slugDB) Begin() (driver.Tx, error)                    { return nil, errors.New("not supported") }
+
+func (d *slugDB) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	slug := args[0].Value.(string)
+	if strings.Contains(query, "EXISTS") {
+		for _, taken := range d.taken {
+			if taken == slug {
+				return &slugRows{column: "exists", values: []driver.Value{true}}, nil
+			}
+		}
+		return &slugRows{column: "exists", values: []driver.Value{false}}, nil
+	}
+
+	rows := &slugRows{column: "slug"}
+	for _, taken := range d.taken {
+		if taken == slug || strings.HasPrefix(taken, slug+"-") {
+			rows.values = append(rows.values, taken)
+		}
+	}
+	return rows, nil
+}
+
+type slugRows struct {
+	column string
+	values []driver.Value
+}
+
+func (r *slugRows) Columns() []string { return []string{r.column} }
+func (r *slugRows) Close() error      { return nil }
+
+func (r *slugRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	dest[0], r.values = r.values[0], r.values[1:]
+	return nil
+}
+
+func TestUniqueSlug(t *testing.T) {
+	generated := regexp.MustCompile(`^n-[0-9a-f]{8}$`)
+
+	tests := []struct {
+		name    string
+		taken   []string
+		product string
+		slug    string
+		want    string
+		match   *regexp.Regexp
+		err     error
+	}{
+		{name: "free", product: "Safety Shoe", want: "safety-shoe"},
+		{name: "taken", taken: []string{"safety-shoe"}, product: "Safety Shoe", want: "safety-shoe-2"},
+		{name: "taken twice", taken: []string{"safety-shoe", "safety-shoe-2"}, product: "Safety Shoe", want: "safety-shoe-3"},
+		{name: "gap", taken: []string{"safety-shoe", "safety-shoe-3"}, product: "Safety Shoe", want: "safety-shoe-2"},
+		{name: "longer slug is not a collision", taken: []string{"safety-shoe-black"}, product: "Safety Shoe", want: "safety-shoe"},
+		{name: "explicit", product: "Safety Shoe", slug: "Shoe XL", want: "shoe-xl"},
+		{name: "explicit taken", taken: []string{"shoe-xl"}, product: "Safety Shoe", slug: "shoe-xl", err: ErrSlugTaken},
+		{name: "explicit symbols", product: "Safety Shoe", slug: "!!!", err: ErrInvalidSlug},
+		{name: "chinese", product: "安全靴", match: generated},
+		{name: "symbols", product: "★ !!! ★", match: generated},
+		{name: "blank", product: "   ", err: ErrInvalidSlug},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db := sqlx.NewDb(sql.OpenDB(&slugDB{taken: test.taken}), "postgres")
+			defer db.Close()
+
+			got, err := UniqueSlug(context.Background(), db, "products", test.product, test.slug, "")
+			switch {
+			case test.err != nil:
+				if !errors.Is(err, test.err) {
+					t.Fatalf("error = %v, want %v", err, test.err)
+				}
+			case err != nil:
+				t.Fatal(err)
+			case test.match != nil && !test.match.MatchString(got):
+				t.Fatalf("got %q, want a generated slug", got)
+			case test.match == nil && got != test.want:
+				t.Fatalf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestUniqueSlugGeneratedCollision(t *testing.T) {
+	base := generatedSlug("安全靴")
+	if base != generatedSlug("安全靴") || base == generatedSlug("安全帽") {
+		t.Fatalf("generatedSlug is not derived from the name: %q", base)
+	}
+
+	db := sqlx.NewDb(sql.OpenDB(&slugDB{taken: []string{base, base + "-2"}}), "postgres")
+	defer db.Close()
+
+	got, err := UniqueSlug(context.Background(), db, "products", "安全靴", "", "")
+	if err != nil || got != base+"-3" {
+		t.Fatalf("got %q, %v, want %q", got, err, base+"-3")
+	}
+}
@@ -0,0 +1,146 @@
+package shared
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var ErrInvalidStateCode = errors.New("invalid state code")
+
+// GST supply types. A supply inside one state is charged CGST and SGST at
+// half the rate each; a supply across states is charged IGST at the full rate.
+const (
+	SupplyIntraState = "INTRA_STATE"
+	SupplyInterState = "INTER_STATE"
+)
+
+const (
+	TaxCGST = "CGST"
+	TaxSGST = "SGST"
+	TaxIGST = "IGST"
+)
+
+// TaxRule is what the tax of a product depends on: its tax class, the GST
+// rate in force for it and the state of its seller. Rate is nil for products
+// without a tax class. It scans the columns of the product_tax SQL function.
+type TaxRule struct {
+	TaxClassID  *string  `db:"tax_class_id"`
+	HSNCode     *string  `db:"hsn_code"`
+	Rate        *Decimal `db:"tax_rate"`
+	SellerState *string  `db:"seller_state"`
+}
+
+type TaxComponent struct {
+	Type   string  `json:"type"`
+	Rate   Decimal `json:"rate"`
+	Amount Decimal `json:"amount"`
+}
+
+// Tax is the GST on a tax-exclusive price.
+type Tax struct {
+	TaxClassID     *string        `json:"tax_class_id"`
+	HSNCode        *string        `json:"hsn_code"`
+	Rate           Decimal        `json:"rate"`
+	Supply         string         `json:"supply"`
+	PriceExclusive Decimal        `json:"price_exclusive"`
+	TaxAmount      Decimal        `json:"tax_amount"`
+	PriceInclusive Decimal        `json:"price_inclusive"`
+	Components     []TaxComponent `json:"components"`
+}
+
+// ComputeTax applies rule to a tax-exclusive price for a buyer in buyerState.
+// Each component is rounded half up to places, the minor units of the
+// currency of price (see CurrencyPlaces), and the total is their sum, so CGST
+// and SGST always add up to the tax charged. An unknown buyer state is
+// taken as the seller's (place of supply of the supplier), and an unknown
+// seller state makes every supply intra-state. It returns nil when the
+// product has no tax class.
+func ComputeTax(price Decimal, rule TaxRule, buyerState string, places int) *Tax {
+	if rule.Rate == nil {
+		return nil
+	}
+
+	tax := &Tax{
+		TaxClassID:     rule.TaxClassID,
+		HSNCode:        rule.HSNCode,
+		Rate:           *rule.Rate,
+		Supply:         SupplyIntraState,
+		PriceExclusive: price,
+	}
+
+	if rule.SellerState != nil && buyerState != "" && buyerState != *rule.SellerState {
+		tax.Supply = SupplyInterState
+		tax.Components = []TaxComponent{{Type: TaxIGST, Rate: tax.Rate}}
+	} else {
+		half := tax.Rate / 2
+		tax.Components = []TaxComponent{{Type: TaxCGST, Rate: half}, {Type: TaxSGST, Rate: half}}
+	}
+
+	for i := range tax.Components {
+		component := &tax.Components[i]
+		component.Amount = price.Percent(component.Rate).Round(places)
+		tax.TaxAmount = tax.TaxAmount.Add(component.Amount)
+	}
+	tax.PriceInclusive = price.Add(tax.TaxAmount)
+
+	return tax
+}
+
+// ProductTaxRule returns the tax rule of a product on a day, see the
+// product_tax SQL function.
+func ProductTaxRule(ctx context.Context, q sqlx.QueryerContext, productID string, on time.Time) (*TaxRule, error) {
+	var rule TaxRule
+	query := "SELECT tax_class_id, hsn_code, tax_rate, seller_state FROM product_tax($1, $2::date)"
+	if err := sqlx.GetContext(ctx, q, &rule, query, productID, on.Format(time.DateOnly)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &TaxRule{}, nil
+		}
+		return nil, PostgresError(err)
+	}
+	return &rule, nil
+}
+
+// ValidateStateCode checks a two-digit GST state code, 01 to 38 or 97 (other
+// territory).
+func ValidateStateCode(code string) error {
+	n, err := strconv.Atoi(code)
+	if err != nil || len(code) != 2 || !(n >= 1 && n <= 38 || n == 97) {
+		return fmt.Errorf("%w: %q", ErrInvalidStateCode, code)
+	}
+	return nil
+}
+
+// BuyerState returns the GST state code tax is computed for: shipTo when
+// given, else the state of the signed-in user, else "" (unknown).
+func BuyerState(ctx context.Context, q sqlx.QueryerContext, shipTo string) (string, error) {
+	if shipTo = strings.TrimSpace(shipTo); shipTo != "" {
+		if err := ValidateStateCode(shipTo); err != nil {
+			return "", err
+		}
+		return shipTo, nil
+	}
+
+	claims, ok := ctx.Value(UserClaimsKey).(*UserClaims)
+	if !ok || claims.UserID == "" {
+		return "", nil
+	}
+
+	var state *string
+	if err := sqlx.GetContext(ctx, q, &state, "SELECT state_code FROM users WHERE id = $1", claims.UserID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", PostgresError(err)
+	}
+	if state == nil {
+		return "", nil
+	}
+	return *state, nil
+}
@@ -0,0 +1,158 @@
+package shared
+
+import "testing"
+
+func decimalPtr(s string) *Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return &d
+}
+
+func TestComputeTax(t *testing.T) {
+	maharashtra := "27"
+
+	type component struct {
+		typ, rate, amount string
+	}
+
+	tests := []struct {
+		name       string
+		price      string
+		rate       *Decimal
+		seller     *string
+		buyer      string
+		places     int
+		supply     string
+		components []component
+		tax        string
+	}{
+		{
+			name: "intra-state", price: "1000", rate: decimalPtr("18"), seller: &maharashtra, buyer: "27", places: 2,
+			supply:     SupplyIntraState,
+			components: []component{{TaxCGST, "9", "90"}, {TaxSGST, "9", "90"}},
+			tax:        "180",
+		},
+		{
+			// 9% of 10.05 is 0.9045 each way, so the halves round to 0.90
+			// and the total is 1.80 rather than 18% of the price, 1.809.
+			name: "intra-state odd paise", price: "10.05", rate: decimalPtr("18"), seller: &maharashtra, buyer: "27", places: 2,
+			supply:     SupplyIntraState,
+			components: []component{{TaxCGST, "9", "0.9"}, {TaxSGST, "9", "0.9"}},
+			tax:        "1.8",
+		},
+		{
+			// 2.5% of 0.3 is 0.0075, exactly half a paisa: rounded up.
+			name: "intra-state half paisa", price: "0.3", rate: decimalPtr("5"), seller: &maharashtra, buyer: "27", places: 2,
+			supply:     SupplyIntraState,
+			components: []component{{TaxCGST, "2.5", "0.01"}, {TaxSGST, "2.5", "0.01"}},
+			tax:        "0.02",
+		},
+		{
+			name: "inter-state", price: "10.05", rate: decimalPtr("18"), seller: &maharashtra, buyer: "29", places: 2,
+			supply:     SupplyInterState,
+			components: []component{{TaxIGST, "18", "1.81"}},
+			tax:        "1.81",
+		},
+		{
+			name: "three decimal currency", price: "10.05", rate: decimalPtr("18"), seller: &maharashtra, buyer: "27", places: 3,
+			supply:     SupplyIntraState,
+			components: []component{{TaxCGST, "9", "0.905"}, {TaxSGST, "9", "0.905"}},
+			tax:        "1.81",
+		},
+		{
+			name: "three decimal currency inter-state", price: "10.05", rate: decimalPtr("18"), seller: &maharashtra, buyer: "29", places: 3,
+			supply:     SupplyInterState,
+			components: []component{{TaxIGST, "18", "1.809"}},
+			tax:        "1.809",
+		},
+		{
+			name: "zero-rated", price: "250", rate: decimalPtr("0"), seller: &maharashtra, buyer: "29", places: 2,
+			supply:     SupplyInterState,
+			components: []component{{TaxIGST, "0", "0"}},
+			tax:        "0",
+		},
+		{
+			name: "unknown buyer state", price: "100", rate: decimalPtr("12"), seller: &maharashtra, buyer: "", places: 2,
+			supply:     SupplyIntraState,
+			components: []component{{TaxCGST, "6", "6"}, {TaxSGST, "6", "6"}},
+			tax:        "12",
+		},
+		{
+			name: "unknown seller state", price: "100", rate: decimalPtr("28"), seller: nil, buyer: "29", places: 2,
+			supply:     SupplyIntraState,
+			components: []component{{TaxCGST, "14", "14"}, {TaxSGST, "14", "14"}},
+			tax:        "28",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			price := *decimalPtr(test.price)
+			tax := ComputeTax(price, TaxRule{Rate: test.rate, SellerState: test.seller}, test.buyer, test.places)
+			if tax == nil {
+				t.Fatal("ComputeTax returned nil")
+			}
+
+			if tax.Supply != test.supply {
+				t.Errorf("supply = %s, want %s", tax.Supply, test.supply)
+			}
+			if len(tax.Components) != len(test.components) {
+				t.Fatalf("components = %+v, want %+v", tax.Components, test.components)
+			}
+			for i, want := range test.components {
+				got := tax.Components[i]
+				if got.Type != want.typ || got.Rate.String() != want.rate || got.Amount.String() != want.amount {
+					t.Errorf("component %d = %s %s%% %s, want %s %s%% %s", i, got.Type, got.Rate, got.Amount, want.typ, want.rate, want.amount)
+				}
+			}
+			if tax.TaxAmount.String() != test.tax {
+				t.Errorf("tax = %s, want %s", tax.TaxAmount, test.tax)
+			}
+			if tax.PriceExclusive != price || tax.PriceInclusive != price.Add(tax.TaxAmount) {
+				t.Errorf("prices = %s and %s for %s", tax.PriceExclusive, tax.PriceInclusive, price)
+			}
+		})
+	}
+}
+
+func TestComputeTaxExempt(t *testing.T) {
+	state := "27"
+	if tax := ComputeTax(NewDecimal(100), TaxRule{SellerState: &state}, "29", 2); tax != nil {
+		t.Errorf("ComputeTax without a tax class = %+v, want nil", tax)
+	}
+}
+
+func TestIncrementPlaces(t *testing.T) {
+	tests := []struct {
+		increment string
+		want      int
+	}{
+		{increment: "1", want: 0},
+		{increment: "0.01", want: 2},
+		{increment: "0.05", want: 2},
+		{increment: "0.001", want: 3},
+		{increment: "0.0001", want: 4},
+		{increment: "0.0100", want: 2},
+	}
+
+	for _, test := range tests {
+		if got := incrementPlaces(*decimalPtr(test.increment)); got != test.want {
+			t.Errorf("incrementPlaces(%s) = %d, want %d", test.increment, got, test.want)
+		}
+	}
+}
+
+func TestValidateStateCode(t *testing.T) {
+	for _, code := range []string{"01", "27", "38", "97"} {
+		if err := ValidateStateCode(code); err != nil {
+			t.Errorf("ValidateStateCode(%q) = %v", code, err)
+		}
+	}
+	for _, code := range []string{"", "0", "00", "39", "96", "99", "7", "027", "ab"} {
+		if err := ValidateStateCode(code); err == nil {
+			t.Errorf("ValidateStateCode(%q) accepted", code)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package shared
+
+import (
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+// DetectContentType sniffs the content type of an uploaded file from its
+// first bytes and rewinds the file for the upload.
+func DetectContentType(file multipart.File) (string, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return http.DetectContentType(head[:n]), nil
+}